@@ -4,6 +4,7 @@ import (
 	_ "github.com/crazydw4rf/book-stock-manager/docs"
 	"github.com/crazydw4rf/book-stock-manager/internal/controller"
 	"github.com/crazydw4rf/book-stock-manager/internal/handler"
+	"github.com/crazydw4rf/book-stock-manager/internal/middleware"
 	"github.com/crazydw4rf/book-stock-manager/internal/repository"
 	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
 	"go.uber.org/fx"
@@ -12,9 +13,35 @@ import (
 func main() {
 	app := fx.New(
 		fx.Provide(newConfig, newFiberApp, newDBConn, newValidator),
-		fx.Provide(repository.NewBookRepository, usecase.NewBookUsecase),
-		fx.Provide(controller.NewBookController),
+		fx.Provide(repository.NewTransactor),
+		fx.Provide(repository.NewBookRepository, repository.NewBookPriceHistoryRepository, usecase.NewBookUsecase),
+		fx.Provide(repository.NewStockMovementRepository, usecase.NewStockUsecase),
+		fx.Provide(repository.NewUserRepository, repository.NewRoleRepository, repository.NewRefreshTokenRepository),
+		fx.Provide(usecase.NewUserUsecase, usecase.NewAuthUsecase),
+		fx.Provide(repository.NewSaleRepository, usecase.NewSaleUsecase),
+		fx.Provide(repository.NewSaleReturnRepository, usecase.NewSaleReturnUsecase),
+		fx.Provide(repository.NewPromotionRepository, usecase.NewPromotionUsecase),
+		fx.Provide(usecase.NewScanUsecase),
+		fx.Provide(repository.NewSupplierRepository, usecase.NewSupplierUsecase),
+		fx.Provide(repository.NewPurchaseOrderRepository, usecase.NewPurchaseOrderUsecase),
+		fx.Provide(usecase.NewReceivingUsecase),
+		fx.Provide(repository.NewLocationRepository, usecase.NewLocationUsecase),
+		fx.Provide(repository.NewStockTransferRepository, usecase.NewStockTransferUsecase),
+		fx.Provide(repository.NewStockTakeRepository, usecase.NewStockTakeUsecase),
+		fx.Provide(repository.NewReservationRepository, usecase.NewReservationUsecase),
+		fx.Provide(repository.NewBackorderRepository, usecase.NewBackorderUsecase),
+		fx.Provide(middleware.NewAuthMiddleware),
+		fx.Provide(controller.NewBookController, controller.NewStockController),
+		fx.Provide(controller.NewAuthController, controller.NewUserController),
+		fx.Provide(controller.NewSaleController, controller.NewSaleReturnController, controller.NewScanController),
+		fx.Provide(controller.NewSupplierController, controller.NewPurchaseOrderController),
+		fx.Provide(controller.NewReceivingController, controller.NewPromotionController),
+		fx.Provide(controller.NewLocationController, controller.NewStockTransferController, controller.NewStockTakeController),
+		fx.Provide(controller.NewReservationController, controller.NewBackorderController),
 		fx.Decorate(handler.SetupBookHandler),
+		fx.Invoke(handler.SetupStockHandler, handler.SetupAuthHandler, handler.SetupSaleHandler, handler.SetupScanHandler, handler.SetupPurchasingHandler, handler.SetupPromotionHandler, handler.SetupLocationHandler, handler.SetupTransferHandler, handler.SetupStockTakeHandler, handler.SetupReservationHandler, handler.SetupBackorderHandler),
+		fx.Invoke(createInitialUser),
+		fx.Invoke(startReservationWorker),
 		fx.Invoke(startApp),
 	)
 
@@ -9,6 +9,8 @@ import (
 
 	_ "github.com/crazydw4rf/book-stock-manager/docs"
 	"github.com/crazydw4rf/book-stock-manager/internal/config"
+	"github.com/crazydw4rf/book-stock-manager/internal/controller"
+	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
 	"github.com/go-playground/validator/v10"
 	"github.com/goccy/go-json"
 	"github.com/gofiber/fiber/v2"
@@ -58,6 +60,7 @@ func newFiberApp() (*fiber.App, error) {
 	app := fiber.New(fiber.Config{
 		JSONEncoder:           json.Marshal,
 		JSONDecoder:           json.Unmarshal,
+		ErrorHandler:          controller.ErrorHandler,
 		DisableStartupMessage: true,
 	})
 
@@ -82,6 +85,54 @@ func newFiberApp() (*fiber.App, error) {
 	return app, nil
 }
 
+// createInitialUser membuat akun pertama dari konfigurasi ADMIN_USERNAME dan ADMIN_PASSWORD
+// jika belum ada user sama sekali di database
+func createInitialUser(lc fx.Lifecycle, userUsecase *usecase.UserUsecase, cfg *config.Config) {
+	lc.Append(fx.StartHook(func(ctx context.Context) error {
+		return userUsecase.EnsureInitialUser(ctx, cfg.ADMIN_USERNAME, cfg.ADMIN_PASSWORD)
+	}))
+}
+
+// startReservationWorker melepas reservasi yang kedaluwarsa setiap RESERVATION_EXPIRY_INTERVAL
+// selama aplikasi berjalan
+func startReservationWorker(lc fx.Lifecycle, reservationUsecase *usecase.ReservationUsecase) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.StartHook(func() {
+		go func() {
+			defer close(done)
+
+			ticker := time.NewTicker(config.RESERVATION_EXPIRY_INTERVAL)
+			defer ticker.Stop()
+
+			for {
+				expired, err := reservationUsecase.ExpireDue(ctx)
+				if err != nil && ctx.Err() == nil {
+					log.Printf("Error expiring reservations: %v\n", err)
+				}
+				if expired > 0 {
+					log.Printf("Released %d expired reservations\n", expired)
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+	}))
+
+	lc.Append(fx.StopHook(func(stopCtx context.Context) {
+		cancel()
+		select {
+		case <-done:
+		case <-stopCtx.Done():
+		}
+	}))
+}
+
 func startApp(lc fx.Lifecycle, app *fiber.App, cfg *config.Config) {
 	lc.Append(fx.StartHook(func() {
 		listenAddr := fmt.Sprintf("%s:%d", cfg.APP_HOST, cfg.APP_PORT)
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS stock_movements (
+    movement_id UUID PRIMARY KEY,
+    book_id UUID NOT NULL REFERENCES books(book_id) ON DELETE CASCADE,
+    movement_type VARCHAR(16) NOT NULL CHECK (movement_type IN ('receipt', 'sale', 'return', 'adjustment', 'damage', 'transfer')),
+    quantity BIGINT NOT NULL CHECK (quantity <> 0),
+    stock_after BIGINT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    reference TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX stock_movements_book_id_index ON stock_movements(book_id, created_at DESC);
+
+-- saldo awal untuk buku yang sudah ada agar jumlah pergerakan sama dengan books.stock
+INSERT INTO stock_movements(movement_id, book_id, movement_type, quantity, stock_after, reason, created_at)
+SELECT gen_random_uuid(), book_id, 'adjustment', stock, stock, 'opening balance', created_at
+FROM books WHERE stock <> 0;
@@ -0,0 +1 @@
+ALTER TABLE books DROP CONSTRAINT IF EXISTS books_stock_non_negative;
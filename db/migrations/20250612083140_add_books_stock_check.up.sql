@@ -0,0 +1 @@
+ALTER TABLE books ADD CONSTRAINT books_stock_non_negative CHECK (stock >= 0);
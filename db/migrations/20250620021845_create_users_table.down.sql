@@ -0,0 +1,3 @@
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS created_by;
+DROP TABLE IF EXISTS refresh_tokens CASCADE;
+DROP TABLE IF EXISTS users CASCADE;
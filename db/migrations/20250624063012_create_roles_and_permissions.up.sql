@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS roles (
+    role_id SERIAL PRIMARY KEY,
+    name VARCHAR(32) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS permissions (
+    permission_id SERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id INT NOT NULL REFERENCES roles(role_id) ON DELETE CASCADE,
+    permission_id INT NOT NULL REFERENCES permissions(permission_id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+INSERT INTO roles(name, description) VALUES
+    ('admin', 'Full access including user management'),
+    ('manager', 'Manage books and stock'),
+    ('cashier', 'Read books and record sales');
+
+INSERT INTO permissions(name, description) VALUES
+    ('books:read', 'Read books'),
+    ('books:write', 'Create, update and delete books'),
+    ('stock:read', 'Read stock movements'),
+    ('stock:adjust', 'Record stock movements and adjust stock'),
+    ('sales:create', 'Record sales'),
+    ('users:manage', 'Create and manage user accounts');
+
+INSERT INTO role_permissions(role_id, permission_id)
+SELECT r.role_id, p.permission_id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'admin'
+   OR (r.name = 'manager' AND p.name IN ('books:read', 'books:write', 'stock:read', 'stock:adjust', 'sales:create'))
+   OR (r.name = 'cashier' AND p.name IN ('books:read', 'sales:create'));
+
+-- user yang sudah ada sebelum RBAC diperlakukan sebagai admin
+ALTER TABLE users ADD COLUMN role_id INT REFERENCES roles(role_id);
+UPDATE users SET role_id = (SELECT role_id FROM roles WHERE name = 'admin');
+ALTER TABLE users ALTER COLUMN role_id SET NOT NULL;
@@ -0,0 +1,3 @@
+DELETE FROM permissions WHERE name = 'sales:read';
+DROP TABLE IF EXISTS sale_items CASCADE;
+DROP TABLE IF EXISTS sales CASCADE;
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS sales (
+    sale_id UUID PRIMARY KEY,
+    cashier_id UUID REFERENCES users(user_id) ON DELETE SET NULL,
+    payment_method VARCHAR(16) NOT NULL CHECK (payment_method IN ('cash', 'card', 'qris', 'transfer')),
+    subtotal NUMERIC(14, 2) NOT NULL,
+    discount_total NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    total NUMERIC(14, 2) NOT NULL,
+    amount_paid NUMERIC(14, 2) NOT NULL,
+    change_due NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX sales_created_at_index ON sales(created_at DESC);
+
+-- judul dan ISBN disimpan sebagai salinan agar riwayat penjualan tetap utuh walaupun buku dihapus
+CREATE TABLE IF NOT EXISTS sale_items (
+    sale_item_id UUID PRIMARY KEY,
+    sale_id UUID NOT NULL REFERENCES sales(sale_id) ON DELETE CASCADE,
+    book_id UUID REFERENCES books(book_id) ON DELETE SET NULL,
+    isbn VARCHAR(17) NOT NULL,
+    title TEXT NOT NULL,
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    unit_price NUMERIC(14, 2) NOT NULL CHECK (unit_price >= 0),
+    discount NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    line_total NUMERIC(14, 2) NOT NULL
+);
+
+CREATE INDEX sale_items_sale_id_index ON sale_items(sale_id);
+
+INSERT INTO permissions(name, description) VALUES ('sales:read', 'Read sales');
+
+INSERT INTO role_permissions(role_id, permission_id)
+SELECT r.role_id, p.permission_id FROM roles r CROSS JOIN permissions p
+WHERE r.name IN ('admin', 'manager', 'cashier') AND p.name = 'sales:read';
@@ -0,0 +1,5 @@
+-- ISBN yang sudah dinormalisasi tidak dikembalikan ke format aslinya
+ALTER TABLE books DROP CONSTRAINT IF EXISTS books_isbn_key;
+ALTER TABLE books ALTER COLUMN isbn TYPE CHAR(17);
+
+CREATE INDEX IF NOT EXISTS book_isbn_hash_index ON books USING HASH(isbn);
@@ -0,0 +1,38 @@
+-- hitung check digit ISBN-13 dari 12 digit pertama
+CREATE FUNCTION pg_temp.isbn13_check_digit(prefix TEXT) RETURNS TEXT AS $$
+    SELECT ((10 - SUM(substr(prefix, i, 1)::INT * CASE WHEN i % 2 = 0 THEN 3 ELSE 1 END) % 10) % 10)::TEXT
+    FROM generate_series(1, 12) AS i
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- buang tanda hubung, spasi dan padding CHAR(17)
+UPDATE books SET isbn = upper(regexp_replace(isbn, '[^0-9Xx]', '', 'g'));
+
+-- ISBN-10 dikonversi menjadi ISBN-13 dengan prefix 978
+UPDATE books
+SET isbn = '978' || substr(isbn, 1, 9) || pg_temp.isbn13_check_digit('978' || substr(isbn, 1, 9))
+WHERE length(isbn) = 10;
+
+DO $$
+DECLARE
+    invalid TEXT;
+    duplicates TEXT;
+BEGIN
+    SELECT string_agg(book_id::TEXT || ' (' || isbn || ')', ', ') INTO invalid
+    FROM books WHERE isbn !~ '^97[89][0-9]{10}$';
+
+    IF invalid IS NOT NULL THEN
+        RAISE EXCEPTION 'books with an invalid ISBN must be fixed before normalization: %', invalid;
+    END IF;
+
+    SELECT string_agg(isbn, ', ') INTO duplicates
+    FROM (SELECT isbn FROM books GROUP BY isbn HAVING COUNT(*) > 1) d;
+
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'duplicate books share the same normalized ISBN and must be merged first: %', duplicates;
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS book_isbn_hash_index;
+
+ALTER TABLE books ALTER COLUMN isbn TYPE VARCHAR(13);
+ALTER TABLE books ADD CONSTRAINT books_isbn_key UNIQUE (isbn);
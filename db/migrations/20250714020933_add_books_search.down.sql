@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS books_author_index;
+DROP INDEX IF EXISTS books_publisher_index;
+DROP INDEX IF EXISTS books_search_vector_index;
+
+ALTER TABLE books DROP COLUMN IF EXISTS search_vector;
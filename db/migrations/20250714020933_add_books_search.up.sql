@@ -0,0 +1,10 @@
+-- konfigurasi 'simple' dipakai karena judul buku campuran bahasa Indonesia dan Inggris
+ALTER TABLE books ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', title), 'A') ||
+    setweight(to_tsvector('simple', author), 'B') ||
+    setweight(to_tsvector('simple', publisher), 'C')
+) STORED;
+
+CREATE INDEX books_search_vector_index ON books USING GIN(search_vector);
+CREATE INDEX books_publisher_index ON books(publisher);
+CREATE INDEX books_author_index ON books(author);
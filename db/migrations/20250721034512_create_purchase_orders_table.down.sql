@@ -0,0 +1,4 @@
+DELETE FROM permissions WHERE name IN ('purchasing:read', 'purchasing:write');
+DROP TABLE IF EXISTS purchase_order_items CASCADE;
+DROP TABLE IF EXISTS purchase_orders CASCADE;
+DROP TABLE IF EXISTS suppliers CASCADE;
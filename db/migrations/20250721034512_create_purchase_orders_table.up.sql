@@ -0,0 +1,50 @@
+CREATE TABLE IF NOT EXISTS suppliers (
+    supplier_id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    contact_name TEXT NOT NULL DEFAULT '',
+    email TEXT NOT NULL DEFAULT '',
+    phone TEXT NOT NULL DEFAULT '',
+    address TEXT NOT NULL DEFAULT '',
+    lead_time_days INT NOT NULL DEFAULT 0 CHECK (lead_time_days >= 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX suppliers_name_key ON suppliers(lower(name));
+
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    purchase_order_id UUID PRIMARY KEY,
+    supplier_id UUID NOT NULL REFERENCES suppliers(supplier_id) ON DELETE RESTRICT,
+    status VARCHAR(24) NOT NULL DEFAULT 'draft'
+        CHECK (status IN ('draft', 'sent', 'partially_received', 'received', 'cancelled')),
+    notes TEXT NOT NULL DEFAULT '',
+    expected_at DATE,
+    total_cost NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    created_by UUID REFERENCES users(user_id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX purchase_orders_supplier_id_index ON purchase_orders(supplier_id);
+CREATE INDEX purchase_orders_status_index ON purchase_orders(status);
+
+-- buku yang masih tercatat di purchase order tidak boleh dihapus
+CREATE TABLE IF NOT EXISTS purchase_order_items (
+    purchase_order_item_id UUID PRIMARY KEY,
+    purchase_order_id UUID NOT NULL REFERENCES purchase_orders(purchase_order_id) ON DELETE CASCADE,
+    book_id UUID NOT NULL REFERENCES books(book_id) ON DELETE RESTRICT,
+    quantity_ordered BIGINT NOT NULL CHECK (quantity_ordered > 0),
+    quantity_received BIGINT NOT NULL DEFAULT 0 CHECK (quantity_received >= 0),
+    unit_cost NUMERIC(14, 2) NOT NULL CHECK (unit_cost >= 0),
+    UNIQUE (purchase_order_id, book_id)
+);
+
+CREATE INDEX purchase_order_items_book_id_index ON purchase_order_items(book_id);
+
+INSERT INTO permissions(name, description) VALUES
+    ('purchasing:read', 'Read suppliers and purchase orders'),
+    ('purchasing:write', 'Manage suppliers and purchase orders');
+
+INSERT INTO role_permissions(role_id, permission_id)
+SELECT r.role_id, p.permission_id FROM roles r CROSS JOIN permissions p
+WHERE r.name IN ('admin', 'manager') AND p.name IN ('purchasing:read', 'purchasing:write');
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS books_low_stock_index;
+
+ALTER TABLE books
+    DROP COLUMN IF EXISTS preferred_supplier_id,
+    DROP COLUMN IF EXISTS reorder_quantity,
+    DROP COLUMN IF EXISTS reorder_point;
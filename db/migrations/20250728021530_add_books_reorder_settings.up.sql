@@ -0,0 +1,7 @@
+-- buku perlu dipesan ulang jika reorder_quantity > 0 dan stock <= reorder_point
+ALTER TABLE books
+    ADD COLUMN IF NOT EXISTS reorder_point BIGINT NOT NULL DEFAULT 0 CHECK (reorder_point >= 0),
+    ADD COLUMN IF NOT EXISTS reorder_quantity BIGINT NOT NULL DEFAULT 0 CHECK (reorder_quantity >= 0),
+    ADD COLUMN IF NOT EXISTS preferred_supplier_id UUID REFERENCES suppliers(supplier_id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS books_low_stock_index ON books(preferred_supplier_id) WHERE reorder_quantity > 0 AND stock <= reorder_point;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS book_price_history;
+
+ALTER TABLE books
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS sale_price,
+    DROP COLUMN IF EXISTS cost_price;
@@ -0,0 +1,25 @@
+ALTER TABLE books
+    ADD COLUMN IF NOT EXISTS cost_price NUMERIC(14, 2) NOT NULL DEFAULT 0 CHECK (cost_price >= 0),
+    ADD COLUMN IF NOT EXISTS sale_price NUMERIC(14, 2) NOT NULL DEFAULT 0 CHECK (sale_price >= 0),
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'IDR' CHECK (currency ~ '^[A-Z]{3}$');
+
+-- setiap perubahan harga menutup baris yang berlaku (effective_to) dan menambah baris baru,
+-- sehingga harga pada waktu tertentu bisa dicari dengan effective_from <= t < effective_to
+CREATE TABLE IF NOT EXISTS book_price_history (
+    price_history_id UUID PRIMARY KEY,
+    book_id UUID NOT NULL REFERENCES books(book_id) ON DELETE CASCADE,
+    cost_price NUMERIC(14, 2) NOT NULL CHECK (cost_price >= 0),
+    sale_price NUMERIC(14, 2) NOT NULL CHECK (sale_price >= 0),
+    currency CHAR(3) NOT NULL,
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    effective_to TIMESTAMP WITH TIME ZONE,
+    changed_by UUID REFERENCES users(user_id) ON DELETE SET NULL,
+    CHECK (effective_to IS NULL OR effective_to >= effective_from)
+);
+
+CREATE INDEX book_price_history_book_id_index ON book_price_history(book_id, effective_from DESC);
+-- hanya boleh ada satu harga yang berlaku untuk setiap buku
+CREATE UNIQUE INDEX book_price_history_current_key ON book_price_history(book_id) WHERE effective_to IS NULL;
+
+INSERT INTO book_price_history(price_history_id, book_id, cost_price, sale_price, currency, effective_from)
+SELECT gen_random_uuid(), book_id, cost_price, sale_price, currency, created_at FROM books;
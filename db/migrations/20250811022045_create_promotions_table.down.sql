@@ -0,0 +1,4 @@
+DELETE FROM permissions WHERE name IN ('promotions:read', 'promotions:write');
+DROP TABLE IF EXISTS sale_promotions CASCADE;
+DROP TABLE IF EXISTS promotion_books CASCADE;
+DROP TABLE IF EXISTS promotions CASCADE;
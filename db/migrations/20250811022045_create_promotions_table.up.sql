@@ -0,0 +1,51 @@
+-- scope menentukan buku yang termasuk promosi: semua buku, penerbit atau penulis tertentu
+-- (scope_value), atau daftar buku pada promotion_books
+CREATE TABLE IF NOT EXISTS promotions (
+    promotion_id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    rule_type VARCHAR(32) NOT NULL CHECK (rule_type IN ('percent_off', 'buy_x_get_y', 'fixed_price_bundle')),
+    scope VARCHAR(16) NOT NULL CHECK (scope IN ('all', 'publisher', 'author', 'books')),
+    scope_value TEXT NOT NULL DEFAULT '',
+    percent NUMERIC(5, 2) NOT NULL DEFAULT 0 CHECK (percent >= 0 AND percent <= 100),
+    buy_quantity BIGINT NOT NULL DEFAULT 0 CHECK (buy_quantity >= 0),
+    free_quantity BIGINT NOT NULL DEFAULT 0 CHECK (free_quantity >= 0),
+    bundle_quantity BIGINT NOT NULL DEFAULT 0 CHECK (bundle_quantity >= 0),
+    bundle_price NUMERIC(14, 2) NOT NULL DEFAULT 0 CHECK (bundle_price >= 0),
+    min_subtotal NUMERIC(14, 2) NOT NULL DEFAULT 0 CHECK (min_subtotal >= 0),
+    priority INT NOT NULL DEFAULT 0,
+    stackable BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    ends_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID REFERENCES users(user_id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX promotions_active_index ON promotions(starts_at, ends_at) WHERE active;
+
+CREATE TABLE IF NOT EXISTS promotion_books (
+    promotion_id UUID NOT NULL REFERENCES promotions(promotion_id) ON DELETE CASCADE,
+    book_id UUID NOT NULL REFERENCES books(book_id) ON DELETE CASCADE,
+    PRIMARY KEY (promotion_id, book_id)
+);
+
+-- nama promosi disalin agar riwayat penjualan tetap terbaca walaupun promosinya dihapus
+CREATE TABLE IF NOT EXISTS sale_promotions (
+    sale_promotion_id UUID PRIMARY KEY,
+    sale_id UUID NOT NULL REFERENCES sales(sale_id) ON DELETE CASCADE,
+    promotion_id UUID REFERENCES promotions(promotion_id) ON DELETE SET NULL,
+    name TEXT NOT NULL,
+    discount NUMERIC(14, 2) NOT NULL CHECK (discount >= 0)
+);
+
+CREATE INDEX sale_promotions_sale_id_index ON sale_promotions(sale_id);
+
+INSERT INTO permissions(name, description) VALUES
+    ('promotions:read', 'Read promotions'),
+    ('promotions:write', 'Manage promotions');
+
+INSERT INTO role_permissions(role_id, permission_id)
+SELECT r.role_id, p.permission_id FROM roles r CROSS JOIN permissions p
+WHERE r.name IN ('admin', 'manager') AND p.name IN ('promotions:read', 'promotions:write');
@@ -0,0 +1,3 @@
+DELETE FROM permissions WHERE name = 'sales:return';
+DROP TABLE IF EXISTS sale_return_items CASCADE;
+DROP TABLE IF EXISTS sale_returns CASCADE;
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS sale_returns (
+    sale_return_id UUID PRIMARY KEY,
+    sale_id UUID NOT NULL REFERENCES sales(sale_id) ON DELETE CASCADE,
+    reason TEXT NOT NULL DEFAULT '',
+    refund_total NUMERIC(14, 2) NOT NULL CHECK (refund_total >= 0),
+    created_by UUID REFERENCES users(user_id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX sale_returns_sale_id_index ON sale_returns(sale_id);
+
+-- barang rusak tetap dicatat sebagai retur lalu langsung dikeluarkan dengan pergerakan damage,
+-- sehingga stok yang bisa dijual tidak bertambah tetapi jejaknya ada di ledger
+CREATE TABLE IF NOT EXISTS sale_return_items (
+    sale_return_item_id UUID PRIMARY KEY,
+    sale_return_id UUID NOT NULL REFERENCES sale_returns(sale_return_id) ON DELETE CASCADE,
+    sale_item_id UUID NOT NULL REFERENCES sale_items(sale_item_id) ON DELETE CASCADE,
+    book_id UUID REFERENCES books(book_id) ON DELETE SET NULL,
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    condition VARCHAR(16) NOT NULL CHECK (condition IN ('resellable', 'damaged')),
+    refund_amount NUMERIC(14, 2) NOT NULL CHECK (refund_amount >= 0)
+);
+
+CREATE INDEX sale_return_items_sale_return_id_index ON sale_return_items(sale_return_id);
+CREATE INDEX sale_return_items_sale_item_id_index ON sale_return_items(sale_item_id);
+
+INSERT INTO permissions(name, description) VALUES ('sales:return', 'Record sale returns and refunds');
+
+INSERT INTO role_permissions(role_id, permission_id)
+SELECT r.role_id, p.permission_id FROM roles r CROSS JOIN permissions p
+WHERE r.name IN ('admin', 'manager') AND p.name = 'sales:return';
@@ -0,0 +1,5 @@
+DELETE FROM permissions WHERE name IN ('locations:read', 'locations:write');
+DROP INDEX IF EXISTS stock_movements_location_id_index;
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS location_id;
+DROP TABLE IF EXISTS location_stock CASCADE;
+DROP TABLE IF EXISTS locations CASCADE;
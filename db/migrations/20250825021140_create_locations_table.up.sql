@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS locations (
+    location_id UUID PRIMARY KEY,
+    code VARCHAR(32) NOT NULL UNIQUE,
+    name VARCHAR(200) NOT NULL,
+    address TEXT NOT NULL DEFAULT '',
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- hanya boleh ada satu lokasi default, dipakai jika pergerakan stok tidak menyebutkan lokasi
+CREATE UNIQUE INDEX locations_default_index ON locations(is_default) WHERE is_default;
+
+-- books.stock tetap disimpan sebagai total stok di semua lokasi
+CREATE TABLE IF NOT EXISTS location_stock (
+    book_id UUID NOT NULL REFERENCES books(book_id) ON DELETE CASCADE,
+    location_id UUID NOT NULL REFERENCES locations(location_id) ON DELETE RESTRICT,
+    quantity BIGINT NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (book_id, location_id)
+);
+
+CREATE INDEX location_stock_location_id_index ON location_stock(location_id);
+
+INSERT INTO locations(location_id, code, name, is_default) VALUES (gen_random_uuid(), 'main', 'Main store', TRUE);
+
+-- stok yang sudah ada dipindahkan ke lokasi default
+INSERT INTO location_stock(book_id, location_id, quantity)
+SELECT b.book_id, l.location_id, b.stock FROM books b CROSS JOIN locations l
+WHERE l.is_default AND b.stock > 0;
+
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS location_id UUID REFERENCES locations(location_id) ON DELETE SET NULL;
+
+UPDATE stock_movements SET location_id = (SELECT location_id FROM locations WHERE is_default);
+
+CREATE INDEX stock_movements_location_id_index ON stock_movements(location_id);
+
+INSERT INTO permissions(name, description) VALUES
+    ('locations:read', 'Read stock locations'),
+    ('locations:write', 'Manage stock locations');
+
+-- kasir perlu membaca daftar lokasi untuk memilih lokasi penjualan
+INSERT INTO role_permissions(role_id, permission_id)
+SELECT r.role_id, p.permission_id FROM roles r CROSS JOIN permissions p
+WHERE (r.name IN ('admin', 'manager', 'cashier') AND p.name = 'locations:read')
+   OR (r.name IN ('admin', 'manager') AND p.name = 'locations:write');
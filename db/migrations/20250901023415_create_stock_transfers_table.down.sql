@@ -0,0 +1,3 @@
+DELETE FROM permissions WHERE name IN ('transfers:read', 'transfers:write');
+DROP TABLE IF EXISTS stock_transfer_items CASCADE;
+DROP TABLE IF EXISTS stock_transfers CASCADE;
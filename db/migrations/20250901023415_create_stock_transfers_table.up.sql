@@ -0,0 +1,40 @@
+-- stok yang sedang dikirim (in_transit) sudah keluar dari lokasi asal tetapi belum masuk ke
+-- lokasi tujuan, sehingga tidak terhitung di books.stock sampai transfer diterima
+CREATE TABLE IF NOT EXISTS stock_transfers (
+    transfer_id UUID PRIMARY KEY,
+    from_location_id UUID NOT NULL REFERENCES locations(location_id) ON DELETE RESTRICT,
+    to_location_id UUID NOT NULL REFERENCES locations(location_id) ON DELETE RESTRICT,
+    status VARCHAR(16) NOT NULL DEFAULT 'requested'
+        CHECK (status IN ('requested', 'in_transit', 'received', 'cancelled')),
+    notes TEXT NOT NULL DEFAULT '',
+    requested_by UUID REFERENCES users(user_id) ON DELETE SET NULL,
+    dispatched_by UUID REFERENCES users(user_id) ON DELETE SET NULL,
+    dispatched_at TIMESTAMP WITH TIME ZONE,
+    received_by UUID REFERENCES users(user_id) ON DELETE SET NULL,
+    received_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (from_location_id <> to_location_id)
+);
+
+CREATE INDEX stock_transfers_from_location_id_index ON stock_transfers(from_location_id);
+CREATE INDEX stock_transfers_to_location_id_index ON stock_transfers(to_location_id);
+CREATE INDEX stock_transfers_status_index ON stock_transfers(status);
+
+CREATE TABLE IF NOT EXISTS stock_transfer_items (
+    transfer_item_id UUID PRIMARY KEY,
+    transfer_id UUID NOT NULL REFERENCES stock_transfers(transfer_id) ON DELETE CASCADE,
+    book_id UUID NOT NULL REFERENCES books(book_id) ON DELETE RESTRICT,
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    UNIQUE (transfer_id, book_id)
+);
+
+CREATE INDEX stock_transfer_items_book_id_index ON stock_transfer_items(book_id);
+
+INSERT INTO permissions(name, description) VALUES
+    ('transfers:read', 'Read stock transfers between locations'),
+    ('transfers:write', 'Request and cancel stock transfers between locations');
+
+INSERT INTO role_permissions(role_id, permission_id)
+SELECT r.role_id, p.permission_id FROM roles r CROSS JOIN permissions p
+WHERE r.name IN ('admin', 'manager') AND p.name IN ('transfers:read', 'transfers:write');
@@ -0,0 +1,3 @@
+DELETE FROM permissions WHERE name IN ('stocktakes:read', 'stocktakes:count', 'stocktakes:manage');
+DROP TABLE IF EXISTS stock_take_lines CASCADE;
+DROP TABLE IF EXISTS stock_takes CASCADE;
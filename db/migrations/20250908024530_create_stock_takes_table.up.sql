@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS stock_takes (
+    stock_take_id UUID PRIMARY KEY,
+    location_id UUID NOT NULL REFERENCES locations(location_id) ON DELETE RESTRICT,
+    status VARCHAR(16) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'approved', 'cancelled')),
+    notes TEXT NOT NULL DEFAULT '',
+    created_by UUID REFERENCES users(user_id) ON DELETE SET NULL,
+    approved_by UUID REFERENCES users(user_id) ON DELETE SET NULL,
+    approved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- setiap lokasi hanya boleh memiliki satu stock take yang sedang berjalan
+CREATE UNIQUE INDEX stock_takes_open_location_index ON stock_takes(location_id) WHERE status = 'open';
+
+-- expected adalah stok lokasi saat stock take dimulai, sehingga penjualan selama penghitungan
+-- tidak mengubah selisih. counted NULL berarti buku belum dihitung
+CREATE TABLE IF NOT EXISTS stock_take_lines (
+    stock_take_id UUID NOT NULL REFERENCES stock_takes(stock_take_id) ON DELETE CASCADE,
+    book_id UUID NOT NULL REFERENCES books(book_id) ON DELETE CASCADE,
+    expected BIGINT NOT NULL,
+    counted BIGINT CHECK (counted >= 0),
+    counted_by UUID REFERENCES users(user_id) ON DELETE SET NULL,
+    counted_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (stock_take_id, book_id)
+);
+
+CREATE INDEX stock_take_lines_book_id_index ON stock_take_lines(book_id);
+
+INSERT INTO permissions(name, description) VALUES
+    ('stocktakes:read', 'Read stock take sessions and discrepancy reports'),
+    ('stocktakes:count', 'Submit counted quantities to a stock take'),
+    ('stocktakes:manage', 'Start, approve and cancel stock takes');
+
+-- kasir ikut menghitung rak tetapi tidak bisa menyetujui penyesuaian stok
+INSERT INTO role_permissions(role_id, permission_id)
+SELECT r.role_id, p.permission_id FROM roles r CROSS JOIN permissions p
+WHERE (r.name IN ('admin', 'manager') AND p.name IN ('stocktakes:read', 'stocktakes:count', 'stocktakes:manage'))
+   OR (r.name = 'cashier' AND p.name IN ('stocktakes:read', 'stocktakes:count'));
@@ -0,0 +1,5 @@
+DELETE FROM permissions WHERE name IN ('reservations:read', 'reservations:write');
+DROP TABLE IF EXISTS reservation_items CASCADE;
+DROP TABLE IF EXISTS reservations CASCADE;
+ALTER TABLE location_stock DROP COLUMN IF EXISTS reserved;
+ALTER TABLE books DROP COLUMN IF EXISTS reserved;
@@ -0,0 +1,41 @@
+-- reserved adalah jumlah eksemplar yang ditahan oleh reservasi aktif. Stok yang bisa dijual
+-- adalah stock - reserved, sedangkan stock tetap jumlah fisik yang ada
+ALTER TABLE books ADD COLUMN reserved BIGINT NOT NULL DEFAULT 0 CHECK (reserved >= 0);
+ALTER TABLE location_stock ADD COLUMN reserved BIGINT NOT NULL DEFAULT 0 CHECK (reserved >= 0);
+
+CREATE TABLE IF NOT EXISTS reservations (
+    reservation_id UUID PRIMARY KEY,
+    location_id UUID NOT NULL REFERENCES locations(location_id) ON DELETE RESTRICT,
+    status VARCHAR(16) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'fulfilled', 'cancelled', 'expired')),
+    channel VARCHAR(16) NOT NULL CHECK (channel IN ('phone', 'online', 'store')),
+    customer_name VARCHAR(200) NOT NULL,
+    customer_contact VARCHAR(200) NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    sale_id UUID REFERENCES sales(sale_id) ON DELETE SET NULL,
+    created_by UUID REFERENCES users(user_id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX reservations_status_index ON reservations(status, created_at DESC);
+-- dipakai worker untuk mencari reservasi yang sudah kedaluwarsa
+CREATE INDEX reservations_active_expires_at_index ON reservations(expires_at) WHERE status = 'active';
+
+CREATE TABLE IF NOT EXISTS reservation_items (
+    reservation_item_id UUID PRIMARY KEY,
+    reservation_id UUID NOT NULL REFERENCES reservations(reservation_id) ON DELETE CASCADE,
+    book_id UUID NOT NULL REFERENCES books(book_id) ON DELETE RESTRICT,
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    UNIQUE (reservation_id, book_id)
+);
+
+CREATE INDEX reservation_items_book_id_index ON reservation_items(book_id);
+
+INSERT INTO permissions(name, description) VALUES
+    ('reservations:read', 'Read stock reservations'),
+    ('reservations:write', 'Hold and release stock for customers');
+
+INSERT INTO role_permissions(role_id, permission_id)
+SELECT r.role_id, p.permission_id FROM roles r CROSS JOIN permissions p
+WHERE r.name IN ('admin', 'manager', 'cashier') AND p.name IN ('reservations:read', 'reservations:write');
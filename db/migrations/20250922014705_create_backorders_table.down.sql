@@ -0,0 +1,5 @@
+DELETE FROM permissions WHERE name IN ('backorders:read', 'backorders:write');
+DROP TABLE IF EXISTS backorders CASCADE;
+UPDATE reservations SET channel = 'store' WHERE channel = 'backorder';
+ALTER TABLE reservations DROP CONSTRAINT reservations_channel_check;
+ALTER TABLE reservations ADD CONSTRAINT reservations_channel_check CHECK (channel IN ('phone', 'online', 'store'));
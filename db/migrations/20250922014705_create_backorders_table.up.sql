@@ -0,0 +1,33 @@
+-- kind preorder untuk buku yang belum terbit dan backorder untuk buku yang stoknya habis.
+-- Antrean dilayani berdasarkan created_at lalu backorder_id untuk setiap buku dan lokasi
+CREATE TABLE IF NOT EXISTS backorders (
+    backorder_id UUID PRIMARY KEY,
+    book_id UUID NOT NULL REFERENCES books(book_id) ON DELETE RESTRICT,
+    location_id UUID NOT NULL REFERENCES locations(location_id) ON DELETE RESTRICT,
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('backorder', 'preorder')),
+    status VARCHAR(16) NOT NULL DEFAULT 'waiting' CHECK (status IN ('waiting', 'allocated', 'cancelled')),
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    customer_name VARCHAR(200) NOT NULL,
+    customer_contact VARCHAR(200) NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    reservation_id UUID REFERENCES reservations(reservation_id) ON DELETE SET NULL,
+    created_by UUID REFERENCES users(user_id) ON DELETE SET NULL,
+    allocated_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX backorders_queue_index ON backorders(book_id, location_id, created_at, backorder_id) WHERE status = 'waiting';
+CREATE INDEX backorders_status_index ON backorders(status, created_at);
+
+-- stok yang dialokasikan ke antrean ditahan sebagai reservasi dengan channel backorder
+ALTER TABLE reservations DROP CONSTRAINT reservations_channel_check;
+ALTER TABLE reservations ADD CONSTRAINT reservations_channel_check CHECK (channel IN ('phone', 'online', 'store', 'backorder'));
+
+INSERT INTO permissions(name, description) VALUES
+    ('backorders:read', 'Read backorders and pre-orders'),
+    ('backorders:write', 'Create, cancel and allocate backorders and pre-orders');
+
+INSERT INTO role_permissions(role_id, permission_id)
+SELECT r.role_id, p.permission_id FROM roles r CROSS JOIN permissions p
+WHERE r.name IN ('admin', 'manager', 'cashier') AND p.name IN ('backorders:read', 'backorders:write');
@@ -4,6 +4,11 @@
 //	@version      0.0.1
 //	@description  Backend API service for Book Stock Manager
 //	@BasePath     /api/v1
+//
+//	@securityDefinitions.apikey  BearerAuth
+//	@in                          header
+//	@name                        Authorization
+//	@description                 Access token from /auth/login, formatted as "Bearer <token>"
 package bookstockmanager
 
 //go:generate swag fmt -exclude doc.go
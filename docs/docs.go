@@ -15,9 +15,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/books": {
-            "get": {
-                "description": "Get a list of books with pagination support including navigation links",
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate with username and password. The refresh token is also set as an HTTP-only cookie.",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,32 +25,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "auth"
                 ],
-                "summary": "Get books with pagination",
+                "summary": "Login",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page offset (default: 0)",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page limit (default: 10, max: 100)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Books information with pagination metadata and navigation links",
+                        "description": "Login successful",
                         "schema": {
-                            "$ref": "#/definitions/model.PaginatedResponse-model_BookResponse"
+                            "$ref": "#/definitions/model.DataResponse-model_TokenResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid username or password",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "User account is disabled",
                         "schema": {
                             "$ref": "#/definitions/types.HTTPError"
                         }
@@ -62,9 +71,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/logout": {
             "post": {
-                "description": "Create a new book with the provided information",
+                "description": "Revoke the refresh token and clear the authentication cookies",
                 "consumes": [
                     "application/json"
                 ],
@@ -72,29 +83,166 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "auth"
                 ],
-                "summary": "Create a new book",
+                "summary": "Logout",
                 "parameters": [
                     {
                         "description": "Request payload",
                         "name": "payload",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateBookRequest"
+                            "$ref": "#/definitions/model.RefreshTokenRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Book created successfully",
+                    "204": {
+                        "description": "Logged out successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.DataResponse-model_BookResponse"
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Rotate the refresh token and issue a new access token. The refresh token is read from the request body or from the refresh token cookie. Reusing a rotated refresh token revokes every session of the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Refresh tokens",
+                "parameters": [
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tokens refreshed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_TokenResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or revoked refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/backorders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of backorders and pre-orders in queue order, oldest first, optionally filtered by status, kind, book and location",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "backorders"
+                ],
+                "summary": "Get backorders with pagination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c",
+                        "name": "bookID",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "backorder",
+                            "preorder"
+                        ],
+                        "type": "string",
+                        "example": "preorder",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b",
+                        "name": "locationID",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "waiting",
+                            "allocated",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "example": "waiting",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Backorders with pagination metadata and navigation links",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedResponse-model_BackorderResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request payload",
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/types.HTTPError"
                         }
@@ -107,8 +255,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
-                "description": "Update book information partially. For stock field, use -1 as a sentinel value to indicate no update is intended.",
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue a customer order for a book at a pickup location, or at the default location when location_id is omitted. Orders for books with a future published_at become pre-orders, all other orders become backorders. A backorder is rejected while the location has enough available stock and nobody is waiting, since a reservation can be made instead. When goods are received at the location, waiting orders are served first-come first-served and each allocated order gets a reservation on the backorder channel. If that reservation expires or is cancelled before pickup, the order is cancelled and the released copies go to the next waiting order.",
                 "consumes": [
                     "application/json"
                 ],
@@ -116,9 +269,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "backorders"
                 ],
-                "summary": "Update book",
+                "summary": "Create a backorder or pre-order",
                 "parameters": [
                     {
                         "description": "Request payload",
@@ -126,15 +279,15 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateBookRequest"
+                            "$ref": "#/definitions/model.CreateBackorderRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Book updated successfully",
+                    "201": {
+                        "description": "Backorder created successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.DataResponse-model_BookResponse"
+                            "$ref": "#/definitions/model.DataResponse-model_BackorderResponse"
                         }
                     },
                     "400": {
@@ -143,8 +296,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.HTTPError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
                     "404": {
-                        "description": "Book not found",
+                        "description": "Location or book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Book is in stock at this location",
                         "schema": {
                             "$ref": "#/definitions/types.HTTPError"
                         }
@@ -158,9 +329,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/books/isbn/{isbn}": {
-            "get": {
-                "description": "Get a book information by ISBN",
+        "/backorders/allocate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Serve the queue of a book at a location, or at the default location when location_id is omitted, from the copies that are currently available. Allocation runs automatically when goods are received from purchase orders, stock transfers, scans, receipt movements and sale returns; use this endpoint after stock becomes available another way, such as a stock adjustment. Orders are served oldest first and only in full, stopping at the first order that cannot be filled.",
                 "consumes": [
                     "application/json"
                 ],
@@ -168,33 +344,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "backorders"
                 ],
-                "summary": "Get book by ISBN",
+                "summary": "Allocate available stock to waiting backorders",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ISBN",
-                        "name": "isbn",
-                        "in": "path",
-                        "required": true
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AllocateBackordersRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Book information retrieved successfully",
+                        "description": "Allocated backorders, empty when nothing could be allocated",
                         "schema": {
-                            "$ref": "#/definitions/model.DataResponse-model_BookResponse"
+                            "$ref": "#/definitions/model.DataResponse-array_model_BackorderResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid ISBN format or ISBN is required",
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/types.HTTPError"
                         }
                     },
                     "404": {
-                        "description": "Book not found",
+                        "description": "Location or book not found",
                         "schema": {
                             "$ref": "#/definitions/types.HTTPError"
                         }
@@ -208,9 +398,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/books/{book_id}": {
+        "/backorders/{backorder_id}": {
             "get": {
-                "description": "Get a book information by ID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a backorder or pre-order by ID. Waiting orders include their queue_position, where 1 is the next order to be served.",
                 "consumes": [
                     "application/json"
                 ],
@@ -218,33 +413,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "backorders"
                 ],
-                "summary": "Get book by ID",
+                "summary": "Get backorder by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Book ID",
-                        "name": "book_id",
+                        "description": "Backorder ID",
+                        "name": "backorder_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Book information retrieved successfully",
+                        "description": "Backorder retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.DataResponse-model_BookResponse"
+                            "$ref": "#/definitions/model.DataResponse-model_BackorderResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid Book ID format or Book ID is required",
+                        "description": "Invalid Backorder ID format or Backorder ID is required",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/types.HTTPError"
                         }
                     },
                     "404": {
-                        "description": "Book not found",
+                        "description": "Backorder not found",
                         "schema": {
                             "$ref": "#/definitions/types.HTTPError"
                         }
@@ -256,9 +463,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete book by ID",
+            }
+        },
+        "/backorders/{backorder_id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a waiting backorder or pre-order from the queue. Allocated orders are cancelled by cancelling their reservation.",
                 "consumes": [
                     "application/json"
                 ],
@@ -266,33 +480,5095 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "backorders"
                 ],
-                "summary": "Delete book",
+                "summary": "Cancel a backorder",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Book ID",
-                        "name": "book_id",
+                        "description": "Backorder ID",
+                        "name": "backorder_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Book deleted successfully",
+                    "200": {
+                        "description": "Backorder cancelled successfully",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.DataResponse-model_BackorderResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid Book ID format or Book ID is required",
+                        "description": "Invalid Backorder ID",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/types.HTTPError"
                         }
                     },
                     "404": {
-                        "description": "Book not found",
+                        "description": "Backorder not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Backorder is not waiting",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/books": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of books with pagination support including navigation links. Supports full-text search over title, author and publisher, exact publisher/author filters, published_at and stock ranges, and sorting. Filters are kept in the navigation links. Passing the cursor parameter (empty for the first page) switches to keyset pagination ordered by book_id: the response then has has_next/has_prev instead of offset/total, and next_cursor/prev_cursor links instead of a last link.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get books with pagination",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor/prev_cursor, empty for the first page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Free-text search over title, author and publisher",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exact publisher",
+                        "name": "publisher",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exact author",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Published on or after (YYYY-MM-DD)",
+                        "name": "published_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Published on or before (YYYY-MM-DD)",
+                        "name": "published_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum stock",
+                        "name": "stock_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum stock",
+                        "name": "stock_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Location ID or code, stock filters and sorting then use the stock at that location",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "relevance",
+                            "title",
+                            "author",
+                            "publisher",
+                            "published_at",
+                            "stock",
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "description": "Sort field (default: relevance when q is set, otherwise book_id)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort direction (default: asc, desc for relevance)",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Books information with pagination metadata and navigation links",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedResponse-model_BookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new book with the provided information. The ISBN may be sent as ISBN-10 or ISBN-13, with or without hyphens, and is stored as canonical ISBN-13.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Create a new book",
+                "parameters": [
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateBookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Book created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_BookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Preferred supplier not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Book with the same ISBN already exists",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update book information partially. For stock field, use -1 as a sentinel value to indicate no update is intended. stock sets the quantity at location_id (the default location when omitted) and the difference is recorded as an adjustment in the stock movement ledger. Reorder settings and prices that are not sent are left unchanged, a price change is recorded in the price history, set clear_preferred_supplier to remove the preferred supplier.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Update book",
+                "parameters": [
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateBookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Book updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_BookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Book, location or preferred supplier not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Book with the same ISBN already exists, or stock below the reserved quantity",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stream all books matching the optional filters as a file download. The same filters and sorting as the book list are supported. Books are read row by row from the database, so large catalogs are not loaded into memory.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Export books",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "jsonl",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format (default: csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Free-text search over title, author and publisher",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exact publisher",
+                        "name": "publisher",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exact author",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Published on or after (YYYY-MM-DD)",
+                        "name": "published_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Published on or before (YYYY-MM-DD)",
+                        "name": "published_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum stock",
+                        "name": "stock_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum stock",
+                        "name": "stock_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Location ID or code, stock filters and sorting then use the stock at that location",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "relevance",
+                            "title",
+                            "author",
+                            "publisher",
+                            "published_at",
+                            "stock",
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "description": "Sort field (default: relevance when q is set, otherwise book_id)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort direction (default: asc, desc for relevance)",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exported catalog",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Import books from a CSV file. Columns isbn, title, author, publisher and published_at (YYYY-MM-DD) are required, stock is optional and sets the quantity at the default location. Header names are matched case-insensitively, or through mapping, a JSON object of field to header name. Rows are validated with the same rules as book creation, and invalid rows are reported without affecting the others. Valid rows are saved in a single transaction. With upsert, rows whose ISBN already exists update that book instead of failing. With dry_run, nothing is saved and the response shows what would happen.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Import books from CSV",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate without saving",
+                        "name": "dry_run",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Update books whose ISBN already exists",
+                        "name": "upsert",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON object mapping field names to CSV header names",
+                        "name": "mapping",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Per-row import result",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_BookImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid CSV file or options",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/isbn/{isbn}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a book information by ISBN",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get book by ISBN",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISBN",
+                        "name": "isbn",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Book information retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_BookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ISBN format or ISBN is required",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/labels": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Render an A4 PDF sheet of shelf labels (QR code, title, author, sale price with currency, ISBN) for the given book IDs, or for books matching the publisher/author filter, in a configurable grid layout",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get printable label sheet",
+                "parameters": [
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BookLabelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF label sheet",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/low-stock": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get books whose stock is at or below their reorder point and that have a reorder quantity set, largest shortfall first. on_order counts quantities still outstanding on draft, sent and partially received purchase orders.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get low stock books",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only books with this preferred supplier",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Low stock books with pagination metadata and navigation links",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedResponse-model_LowStockBookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{book_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a book information by ID. When location is set, stock is the stock held at that location instead of the total across all locations.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get book by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "book_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Location ID or code",
+                        "name": "location",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Book information retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_BookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Book ID format or Book ID is required",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Book or location not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete book by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Delete book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "book_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Book deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Book ID format or Book ID is required",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Book is used by purchase orders, stock transfers or reservations",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{book_id}/prices": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get every cost price, sale price and currency change of a book, newest first. The current price has no effective_to.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get price history of a book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "book_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price history with pagination metadata and navigation links",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedResponse-model_BookPriceHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{book_id}/qr": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a scannable QR code encoding the book ID, or a URL to the book detail endpoint when content is \"url\"",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get book QR code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "book_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "png",
+                            "svg"
+                        ],
+                        "type": "string",
+                        "default": "png",
+                        "description": "Image format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 2048,
+                        "minimum": 64,
+                        "type": "integer",
+                        "default": 256,
+                        "description": "Image size in pixels",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "L",
+                            "M",
+                            "Q",
+                            "H"
+                        ],
+                        "type": "string",
+                        "default": "M",
+                        "description": "Error correction level",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "url"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "Encoded content",
+                        "name": "content",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "QR code image",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Book ID or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{book_id}/stock/locations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get how many copies of a book are held at each location. stock is the total across all locations.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "Get stock of a book per location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "book_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock per location retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_BookLocationStockResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Book ID format or Book ID is required",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{book_id}/stock/movements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the stock movement ledger of a book, newest first, with pagination support",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "Get stock movements of a book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "book_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock movements with pagination metadata and navigation links",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedResponse-model_StockMovementResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a stock change (receipt, sale, return, adjustment, damage, transfer) and update the book stock in the same transaction. Quantity is a signed delta. Positive receipt and return movements are allocated to waiting backorders and pre-orders at the location first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "Record a stock movement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "book_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateStockMovementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Stock movement recorded successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_StockMovementResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Stock would become negative",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{book_id}/stock:adjust": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Atomically increment or decrement the book stock by a relative delta. The change is rejected when the stock would become negative.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "Adjust book stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "book_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AdjustStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock adjusted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_BookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Stock would become negative",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of locations, the default location first, then ordered by name, with pagination support including navigation links",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Get locations with pagination",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Locations with pagination metadata and navigation links",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedResponse-model_LocationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new stock location such as a store, booth or warehouse. Setting is_default moves the default location, which receives stock whenever a request does not name a location, to the new location.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Create a new location",
+                "parameters": [
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Location created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_LocationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Location with the same code already exists",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{location_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get location information by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Get location by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "location_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Location information retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_LocationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Location ID format or Location ID is required",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete location by ID. The default location and locations that still hold stock or appear on stock transfers cannot be deleted. Stock movements recorded at the location are kept without a location.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Delete location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "location_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Location deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Location ID format or Location ID is required",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Location is the default location, still holds stock or has stock transfers",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update location information partially. Fields that are not sent are left unchanged. is_default can only be set to true, which moves the default from the current default location.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Update location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "location_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Location updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_LocationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Location with the same code already exists",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of promotions ordered by priority with pagination support including navigation links",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Get promotions with pagination",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotions with pagination metadata and navigation links",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedResponse-model_PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a promotion with a rule type (percent_off, buy_x_get_y or fixed_price_bundle), a scope (all books, a publisher, an author or a list of books), a validity window and priority. Promotions are applied from the highest priority; a non-stackable promotion only applies to lines without a discount and blocks other promotions on the lines it discounts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Create a new promotion",
+                "parameters": [
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Promotion created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions/{promotion_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a promotion and its book list by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Get promotion by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promotion ID",
+                        "name": "promotion_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion information retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Promotion ID format or Promotion ID is required",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the rule, scope, book list and validity window of a promotion. Recorded sales keep their discounts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Update promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promotion ID",
+                        "name": "promotion_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion or book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete promotion by ID. Recorded sales keep the promotion name and discount.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Delete promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promotion ID",
+                        "name": "promotion_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Promotion deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Promotion ID format or Promotion ID is required",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of purchase orders, newest first, optionally filtered by status and supplier. Line items are not included.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Get purchase orders with pagination",
+                "parameters": [
+                    {
+                        "enum": [
+                            "draft",
+                            "sent",
+                            "partially_received",
+                            "received",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "example": "sent",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b",
+                        "name": "supplierID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purchase orders with pagination metadata and navigation links",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedResponse-model_PurchaseOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a draft purchase order for a supplier. Each line item references a book and can only appear once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Create a new purchase order",
+                "parameters": [
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PurchaseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Purchase order created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_PurchaseOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Supplier or book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-orders/reorder": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create one draft purchase order per preferred supplier for books at or below their reorder point. Books without a preferred supplier, or whose outstanding orders already cover the shortfall, are skipped. Unit costs are taken from the last order to the same supplier, falling back to the book cost price, and the expected date from the supplier lead time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Generate purchase orders from low stock books",
+                "parameters": [
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReorderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Draft purchase orders created",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_ReorderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-orders/{purchase_order_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a purchase order and its line items by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Get purchase order by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase order ID",
+                        "name": "purchase_order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purchase order retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_PurchaseOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Purchase order ID format or Purchase order ID is required",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the supplier, expected date, notes and line items of a draft purchase order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Update purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase order ID",
+                        "name": "purchase_order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PurchaseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purchase order updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_PurchaseOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order, supplier or book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Purchase order is no longer a draft",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a draft purchase order and its line items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Delete purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase order ID",
+                        "name": "purchase_order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Purchase order deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Purchase order ID format or Purchase order ID is required",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Purchase order is no longer a draft",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-orders/{purchase_order_id}/receipts": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a delivery for a sent or partially received purchase order. Each item is a scanned code (book ID, book QR code URL or ISBN) with an optional quantity (default 1). Stock is increased with receipt movements referencing the purchase order, received quantities are updated, and the purchase order moves to partially_received or received in the same database transaction. Over and short deliveries are reported per line item. Received copies are then allocated to waiting backorders and pre-orders at the location, oldest first, and each allocation is returned with its reservation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Receive goods against a purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase order ID",
+                        "name": "purchase_order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReceivePurchaseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Goods received successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_ReceivePurchaseOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order or book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Purchase order cannot receive goods or book is not on the purchase order",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-orders/{purchase_order_id}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a draft purchase order as sent, or cancel a draft or sent purchase order. Received statuses are set by goods receipts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Update purchase order status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase order ID",
+                        "name": "purchase_order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdatePurchaseOrderStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purchase order status updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_PurchaseOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Status change is not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of reservations, newest first, optionally filtered by status, location and a book held by the reservation. Line items are not included.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Get reservations with pagination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c",
+                        "name": "bookID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b",
+                        "name": "locationID",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "fulfilled",
+                            "cancelled",
+                            "expired"
+                        ],
+                        "type": "string",
+                        "example": "active",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reservations with pagination metadata and navigation links",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedResponse-model_ReservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hold books for a customer at a location, or at the default location when location_id is omitted. Held copies stay in on_hand but are no longer available, so they cannot be sold to someone else or sent to another location. The hold is released automatically at expires_at, which defaults to the configured reservation TTL, and released copies are offered to waiting backorders first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Create a reservation",
+                "parameters": [
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateReservationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Reservation created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_ReservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Location or book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient available stock",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{reservation_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a reservation and its line items by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Get reservation by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reservation retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_ReservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Reservation ID format or Reservation ID is required",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{reservation_id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel an active reservation and release the held copies to waiting backorders first, then to sale. A backorder allocated to the reservation is cancelled as well.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Cancel a reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reservation cancelled successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_ReservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Reservation ID",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservation is not active",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{reservation_id}/sale": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record the sale of every book held by an active reservation at the reservation location. The hold is released and the stock is decremented in the same database transaction, and the reservation moves to fulfilled with a reference to the sale. Prices use the current sale price and active promotions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Sell a reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "reservation_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReservationSaleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Reservation sold successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_ReservationSaleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Reservation ID or request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservation is not active or has expired, or insufficient stock",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of sales, newest first, with pagination support including navigation links",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales"
+                ],
+                "summary": "Get sales with pagination",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sales with pagination metadata and navigation links",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedResponse-model_SaleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a point-of-sale transaction. Stock of every line item is decremented in the same database transaction. Line items are always charged at the book sale price. Active promotions are applied automatically and listed in the response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales"
+                ],
+                "summary": "Create a new sale",
+                "parameters": [
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateSaleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Sale created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_SaleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient stock or book has no sale price",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales/preview": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Price a cart with the currently active promotions without recording a sale or changing stock. The result matches what creating the sale would charge at the same moment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales"
+                ],
+                "summary": "Preview a sale price",
+                "parameters": [
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PreviewSaleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cart priced successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_SalePreviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Book has no sale price",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales/{sale_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a sale and its line items by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales"
+                ],
+                "summary": "Get sale by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sale ID",
+                        "name": "sale_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sale information retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_SaleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Sale ID format or Sale ID is required",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Sale not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales/{sale_id}/receipt": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Render a printable receipt with the store header, line items, discounts, included tax, totals, payment method and a QR code linking to the sale. Use pdf for regular printers or sharing, escpos for raw ESC/POS bytes sent directly to a 58mm or 80mm thermal printer, and txt for plain text.",
+                "produces": [
+                    "application/pdf",
+                    "application/octet-stream",
+                    "text/plain"
+                ],
+                "tags": [
+                    "sales"
+                ],
+                "summary": "Get sale receipt",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sale ID",
+                        "name": "sale_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pdf",
+                            "escpos",
+                            "txt"
+                        ],
+                        "type": "string",
+                        "default": "pdf",
+                        "description": "Receipt format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            58,
+                            80
+                        ],
+                        "type": "integer",
+                        "default": 58,
+                        "description": "Paper width in millimeters",
+                        "name": "paper",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sale receipt",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Sale ID or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Sale not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales/{sale_id}/returns": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all returns recorded for a sale, oldest first, with their line items and refund amounts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales"
+                ],
+                "summary": "Get returns of a sale",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sale ID",
+                        "name": "sale_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sale returns retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-array_model_SaleReturnResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid sale ID",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Sale not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a return of line items from a sale. Returned quantities, including earlier returns, cannot exceed the sold quantity. The refund is taken from the discounted line total. Resellable items are put back into stock with return movements; damaged items are recorded as returned and written off with damage movements in the same database transaction. Resellable copies are allocated to waiting backorders and pre-orders at the location first and returned in allocations.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales"
+                ],
+                "summary": "Return items from a sale",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sale ID",
+                        "name": "sale_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateSaleReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Sale return recorded successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_SaleReturnResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Sale or sale item not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Returned quantity exceeds sold quantity",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/scan": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Resolve a raw scanner payload (book ID, book QR code URL, ISBN-10 or ISBN-13) to a book. When action is set, a sale (sell) or receipt (receive) stock movement is recorded for the resolved book; sell requires the sales:create permission and receive requires the stock:adjust permission. Received copies are allocated to waiting backorders and pre-orders at the location first and returned in allocations.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scan"
+                ],
+                "summary": "Resolve a scanned code",
+                "parameters": [
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ScanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Code resolved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_ScanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient stock",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-takes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of stock takes, newest first, optionally filtered by status and location. Summaries are not included.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-takes"
+                ],
+                "summary": "Get stock takes with pagination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b",
+                        "name": "locationID",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "open",
+                            "approved",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "example": "open",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock takes with pagination metadata and navigation links",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedResponse-model_StockTakeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start a physical inventory count at a location, or at the default location when location_id is omitted. The current location stock of every book is snapshotted as the expected quantity, so sales and receipts during counting do not distort the variance. A location can only have one open stock take.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-takes"
+                ],
+                "summary": "Start a stock take",
+                "parameters": [
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateStockTakeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Stock take started successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_StockTakeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Location already has an open stock take",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-takes/{stock_take_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a stock take by ID with a summary of the books counted so far, the books whose count differs from the expected quantity and the net variance",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-takes"
+                ],
+                "summary": "Get stock take by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock take ID",
+                        "name": "stock_take_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock take retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_StockTakeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Stock take ID format or Stock take ID is required",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Stock take not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-takes/{stock_take_id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Close an open stock take and post the variance (counted minus expected) of every counted book as an adjustment movement at the stock take location, referencing the stock take, all in one database transaction. The variance is applied to the current stock, so sales made during counting are kept. Uncounted books are left unchanged unless zero_uncounted is true. The whole approval is refused if any book would go negative.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-takes"
+                ],
+                "summary": "Approve a stock take",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock take ID",
+                        "name": "stock_take_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.ApproveStockTakeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock take approved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_StockTakeApproveResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Stock take ID or request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Stock take not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Stock take is not open or insufficient stock",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-takes/{stock_take_id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel an open stock take. Stock is not changed and the counts are kept for reference.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-takes"
+                ],
+                "summary": "Cancel a stock take",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock take ID",
+                        "name": "stock_take_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock take cancelled successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_StockTakeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Stock take ID",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Stock take not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Stock take is not open",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-takes/{stock_take_id}/counts": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record scanned books in an open stock take. Each code can be a book QR code, a book URL or an ISBN barcode, and quantity defaults to one copy. In add mode (the default) quantities are added to the previous count so every scan can be sent as it happens, in set mode the previous count is replaced. Books missing from the snapshot use their current location stock as the expected quantity. The updated lines are returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-takes"
+                ],
+                "summary": "Submit stock take counts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock take ID",
+                        "name": "stock_take_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StockTakeCountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Counts recorded successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-array_model_StockTakeLineResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or unrecognized code",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Stock take or book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Stock take is not open",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-takes/{stock_take_id}/lines": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the counted, expected and variance quantity of every book in a stock take, ordered by title. Use status=discrepancy for books whose count differs from the expected quantity and status=uncounted for books nobody has counted yet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-takes"
+                ],
+                "summary": "Get stock take discrepancy report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock take ID",
+                        "name": "stock_take_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "counted",
+                            "uncounted",
+                            "discrepancy"
+                        ],
+                        "type": "string",
+                        "example": "discrepancy",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock take lines with pagination metadata and navigation links",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedResponse-model_StockTakeLineResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Stock take ID or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Stock take not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of suppliers ordered by name with pagination support including navigation links",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Get suppliers with pagination",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Suppliers with pagination metadata and navigation links",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedResponse-model_SupplierResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new supplier with contact information and lead time in days",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Create a new supplier",
+                "parameters": [
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateSupplierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Supplier created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_SupplierResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Supplier with the same name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers/{supplier_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get supplier information by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Get supplier by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Supplier ID",
+                        "name": "supplier_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Supplier information retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_SupplierResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Supplier ID format or Supplier ID is required",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Supplier not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete supplier by ID. Suppliers that still have purchase orders cannot be deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Delete supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Supplier ID",
+                        "name": "supplier_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Supplier deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Supplier ID format or Supplier ID is required",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Supplier not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Supplier has purchase orders",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update supplier information partially. Fields that are not sent are left unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Update supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Supplier ID",
+                        "name": "supplier_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateSupplierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Supplier updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_SupplierResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Supplier not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Supplier with the same name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of stock transfers, newest first, optionally filtered by status and by a location that is either the source or the destination. Line items are not included.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Get stock transfers with pagination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b",
+                        "name": "locationID",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "requested",
+                            "in_transit",
+                            "received",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "example": "in_transit",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock transfers with pagination metadata and navigation links",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedResponse-model_StockTransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Request moving books from one location to another. The transfer starts as requested and does not change stock yet, but it is refused when the source location does not currently hold enough copies of every book.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Request a stock transfer",
+                "parameters": [
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateStockTransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Stock transfer requested successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_StockTransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Location or book not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient stock at the source location",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{transfer_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a stock transfer and its line items by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Get stock transfer by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock transfer ID",
+                        "name": "transfer_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock transfer retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_StockTransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Stock transfer ID format or Stock transfer ID is required",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Stock transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{transfer_id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a transfer that is still requested. Dispatched transfers cannot be cancelled, receive them and request a transfer back instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Cancel a stock transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock transfer ID",
+                        "name": "transfer_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock transfer cancelled successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_StockTransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Stock transfer ID",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Stock transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Stock transfer is not requested",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{transfer_id}/dispatch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send a requested transfer on its way. Every line item is taken out of the source location with a transfer movement referencing the transfer, and the transfer moves to in_transit, all in one database transaction. The whole dispatch is refused if any book would go negative at the source location. Copies in transit are not counted in the book stock until the transfer is received.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Dispatch a stock transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock transfer ID",
+                        "name": "transfer_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock transfer dispatched successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_StockTransferProcessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Stock transfer ID",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Stock transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Stock transfer is not requested or insufficient stock at the source location",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{transfer_id}/receive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record the arrival of an in-transit transfer. Every line item is added to the destination location with a transfer movement referencing the transfer, and the transfer moves to received in the same database transaction. Received copies are then allocated to waiting backorders and pre-orders at the destination, oldest first, and each allocation is returned with its reservation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Receive a stock transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock transfer ID",
+                        "name": "transfer_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock transfer received successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_StockTransferProcessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Stock transfer ID",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Stock transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Stock transfer is not in transit",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new user account that can login to the API",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "description": "Request payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.DataResponse-model_UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/types.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Username already exists",
                         "schema": {
                             "$ref": "#/definitions/types.HTTPError"
                         }
@@ -308,141 +5584,2725 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "model.AdjustStockRequest": {
+            "type": "object",
+            "required": [
+                "delta"
+            ],
+            "properties": {
+                "delta": {
+                    "type": "integer",
+                    "example": -1
+                },
+                "location_id": {
+                    "description": "LocationID kosong berarti lokasi default",
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Sold at till 2"
+                },
+                "reference": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "TILL-2"
+                }
+            }
+        },
+        "model.AllocateBackordersRequest": {
+            "type": "object",
+            "required": [
+                "book_id"
+            ],
+            "properties": {
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "location_id": {
+                    "description": "LocationID kosong berarti lokasi default",
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                }
+            }
+        },
+        "model.ApproveStockTakeRequest": {
+            "type": "object",
+            "properties": {
+                "zero_uncounted": {
+                    "description": "ZeroUncounted menganggap buku yang belum dihitung tidak ada di rak sehingga stoknya dijadikan\nnol. Jika false, stok buku yang belum dihitung tidak diubah",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "model.BackorderResponse": {
+            "type": "object",
+            "properties": {
+                "allocated_at": {
+                    "type": "string",
+                    "example": "2025-09-25T04:10:00Z"
+                },
+                "backorder_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-22T01:47:05Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "customer_contact": {
+                    "type": "string",
+                    "example": "0812-3456-7890"
+                },
+                "customer_name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "preorder"
+                },
+                "location_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Signed edition if available"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "queue_position": {
+                    "description": "QueuePosition hanya terisi selama pesanan masih waiting, 1 berarti pesanan berikutnya yang dilayani",
+                    "type": "integer",
+                    "example": 3
+                },
+                "reservation_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "waiting"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-09-22T01:47:05Z"
+                }
+            }
+        },
+        "model.BookImportResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BookImportRowResult"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "model.BookImportRowResult": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "ISBN already exists"
+                },
+                "isbn": {
+                    "type": "string",
+                    "example": "9783161484100"
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "status": {
+                    "type": "string",
+                    "example": "created"
+                }
+            }
+        },
+        "model.BookLabelRequest": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "Tere Liye"
+                },
+                "book_ids": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                    ]
+                },
+                "columns": {
+                    "type": "integer",
+                    "maximum": 6,
+                    "minimum": 1,
+                    "example": 3
+                },
+                "content": {
+                    "type": "string",
+                    "enum": [
+                        "id",
+                        "url"
+                    ],
+                    "example": "id"
+                },
+                "publisher": {
+                    "type": "string",
+                    "example": "Gramedia"
+                },
+                "rows": {
+                    "type": "integer",
+                    "maximum": 20,
+                    "minimum": 1,
+                    "example": 8
+                }
+            }
+        },
+        "model.BookLocationStockResponse": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LocationStockResponse"
+                    }
+                },
+                "stock": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "model.BookPriceHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "changed_by": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "cost_price": {
+                    "type": "string",
+                    "example": "62000.00"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "effective_from": {
+                    "type": "string",
+                    "example": "2025-08-04T03:12:10Z"
+                },
+                "effective_to": {
+                    "type": "string",
+                    "example": "2025-09-01T00:00:00Z"
+                },
+                "price_history_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "sale_price": {
+                    "type": "string",
+                    "example": "89000.00"
+                }
+            }
+        },
         "model.BookResponse": {
             "type": "object",
             "properties": {
-                "author": {
+                "author": {
+                    "type": "string",
+                    "example": "Tere Liye"
+                },
+                "available": {
+                    "type": "integer",
+                    "example": 197
+                },
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "cost_price": {
+                    "type": "string",
+                    "example": "62000.00"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "isbn": {
+                    "type": "string",
+                    "example": "9783161484100"
+                },
+                "on_hand": {
+                    "description": "OnHand adalah jumlah fisik, Reserved adalah bagian yang ditahan reservasi aktif dan Available\nadalah jumlah yang masih bisa dijual",
+                    "type": "integer",
+                    "example": 200
+                },
+                "preferred_supplier_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "published_at": {
+                    "type": "string",
+                    "example": "2016-01-28"
+                },
+                "publisher": {
+                    "type": "string",
+                    "example": "Gramedia"
+                },
+                "reorder_point": {
+                    "description": "buku perlu dipesan ulang jika reorder_quantity \u003e 0 dan stock \u003c= reorder_point",
+                    "type": "integer",
+                    "example": 20
+                },
+                "reorder_quantity": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "reserved": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "sale_price": {
+                    "type": "string",
+                    "example": "89000.00"
+                },
+                "stock": {
+                    "description": "Stock sama dengan OnHand, dipertahankan untuk klien lama",
+                    "type": "integer",
+                    "example": 200
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Hujan"
+                }
+            }
+        },
+        "model.CreateBackorderRequest": {
+            "type": "object",
+            "required": [
+                "book_id",
+                "customer_contact",
+                "customer_name",
+                "quantity"
+            ],
+            "properties": {
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "customer_contact": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "0812-3456-7890"
+                },
+                "customer_name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Budi Santoso"
+                },
+                "location_id": {
+                    "description": "LocationID adalah lokasi tempat buku akan diambil, kosong berarti lokasi default",
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Signed edition if available"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "example": 1
+                }
+            }
+        },
+        "model.CreateBookRequest": {
+            "type": "object",
+            "required": [
+                "author",
+                "isbn",
+                "published_at",
+                "publisher",
+                "stock",
+                "title"
+            ],
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "Tere Liye"
+                },
+                "cost_price": {
+                    "type": "string",
+                    "example": "62000.00"
+                },
+                "currency": {
+                    "description": "Currency adalah kode mata uang ISO 4217, default IDR",
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "isbn": {
+                    "type": "string",
+                    "example": "9783161484100"
+                },
+                "preferred_supplier_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "published_at": {
+                    "type": "string",
+                    "example": "2016-01-28"
+                },
+                "publisher": {
+                    "type": "string",
+                    "example": "Gramedia"
+                },
+                "reorder_point": {
+                    "description": "ReorderQuantity 0 berarti buku tidak pernah masuk daftar stok menipis",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 20
+                },
+                "reorder_quantity": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 50
+                },
+                "sale_price": {
+                    "type": "string",
+                    "example": "89000.00"
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 200
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Hujan"
+                }
+            }
+        },
+        "model.CreateLocationRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Gedung Student Center Lt. 1"
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "campus"
+                },
+                "is_default": {
+                    "description": "IsDefault menjadikan lokasi ini lokasi default menggantikan lokasi default sebelumnya",
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Campus booth"
+                }
+            }
+        },
+        "model.CreateReservationRequest": {
+            "type": "object",
+            "required": [
+                "channel",
+                "customer_name",
+                "items"
+            ],
+            "properties": {
+                "channel": {
+                    "type": "string",
+                    "enum": [
+                        "phone",
+                        "online",
+                        "store"
+                    ],
+                    "example": "phone"
+                },
+                "customer_contact": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "0812-3456-7890"
+                },
+                "customer_name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Budi Santoso"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt kosong berarti reservasi ditahan selama RESERVATION_TTL",
+                    "type": "string",
+                    "example": "2025-09-17T03:18:22Z"
+                },
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.ReservationItemRequest"
+                    }
+                },
+                "location_id": {
+                    "description": "LocationID adalah lokasi tempat buku ditahan dan diambil, kosong berarti lokasi default",
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Picks up after work"
+                }
+            }
+        },
+        "model.CreateSaleRequest": {
+            "type": "object",
+            "required": [
+                "items",
+                "payment_method"
+            ],
+            "properties": {
+                "amount_paid": {
+                    "type": "string",
+                    "example": "200000.00"
+                },
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.SaleItemRequest"
+                    }
+                },
+                "location_id": {
+                    "description": "LocationID adalah lokasi asal barang yang dijual, kosong berarti lokasi default",
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": ""
+                },
+                "payment_method": {
+                    "type": "string",
+                    "enum": [
+                        "cash",
+                        "card",
+                        "qris",
+                        "transfer"
+                    ],
+                    "example": "cash"
+                }
+            }
+        },
+        "model.CreateSaleReturnRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.SaleReturnItemRequest"
+                    }
+                },
+                "location_id": {
+                    "description": "LocationID adalah lokasi tujuan barang yang dikembalikan, kosong berarti lokasi default",
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Halaman terbalik"
+                }
+            }
+        },
+        "model.CreateStockMovementRequest": {
+            "type": "object",
+            "required": [
+                "movement_type",
+                "quantity"
+            ],
+            "properties": {
+                "location_id": {
+                    "description": "LocationID kosong berarti lokasi default",
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "movement_type": {
+                    "type": "string",
+                    "enum": [
+                        "receipt",
+                        "sale",
+                        "return",
+                        "adjustment",
+                        "damage",
+                        "transfer"
+                    ],
+                    "example": "receipt"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Restock from supplier"
+                },
+                "reference": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "INV-2025-0001"
+                }
+            }
+        },
+        "model.CreateStockTakeRequest": {
+            "type": "object",
+            "properties": {
+                "location_id": {
+                    "description": "LocationID kosong berarti lokasi default",
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Odd semester count"
+                }
+            }
+        },
+        "model.CreateStockTransferRequest": {
+            "type": "object",
+            "required": [
+                "from_location_id",
+                "items",
+                "to_location_id"
+            ],
+            "properties": {
+                "from_location_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "items": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.StockTransferItemRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Stock for the new semester"
+                },
+                "to_location_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5c"
+                }
+            }
+        },
+        "model.CreateSupplierRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Jl. Palmerah Barat 29, Jakarta"
+                },
+                "contact_name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Budi Santoso"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "order@gramedia.example"
+                },
+                "lead_time_days": {
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 0,
+                    "example": 7
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Gramedia Distribusi"
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "+62215550100"
+                }
+            }
+        },
+        "model.CreateUserRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "role",
+                "username"
+            ],
+            "properties": {
+                "full_name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Ucup Surucup"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8,
+                    "example": "s3cr3t-p4ssw0rd"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "cashier"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 3,
+                    "example": "ucup"
+                }
+            }
+        },
+        "model.DataResponse-array_model_BackorderResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BackorderResponse"
+                    }
+                }
+            }
+        },
+        "model.DataResponse-array_model_SaleReturnResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SaleReturnResponse"
+                    }
+                }
+            }
+        },
+        "model.DataResponse-array_model_StockTakeLineResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockTakeLineResponse"
+                    }
+                }
+            }
+        },
+        "model.DataResponse-model_BackorderResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.BackorderResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_BookImportResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.BookImportResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_BookLocationStockResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.BookLocationStockResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_BookResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.BookResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_LocationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.LocationResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_PromotionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.PromotionResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_PurchaseOrderResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.PurchaseOrderResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_ReceivePurchaseOrderResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.ReceivePurchaseOrderResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_ReorderResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.ReorderResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_ReservationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.ReservationResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_ReservationSaleResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.ReservationSaleResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_SalePreviewResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.SalePreviewResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_SaleResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.SaleResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_SaleReturnResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.SaleReturnResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_ScanResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.ScanResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_StockMovementResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.StockMovementResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_StockTakeApproveResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.StockTakeApproveResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_StockTakeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.StockTakeResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_StockTransferProcessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.StockTransferProcessResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_StockTransferResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.StockTransferResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_SupplierResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.SupplierResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_TokenResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.TokenResponse"
+                }
+            }
+        },
+        "model.DataResponse-model_UserResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.UserResponse"
+                }
+            }
+        },
+        "model.LocationResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Gedung Student Center Lt. 1"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "campus"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-25T02:11:40Z"
+                },
+                "is_default": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "location_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Campus booth"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-08-25T02:11:40Z"
+                }
+            }
+        },
+        "model.LocationStockResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "campus"
+                },
+                "location_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Campus booth"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "model.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "s3cr3t-p4ssw0rd"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "model.LowStockBookResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "Tere Liye"
+                },
+                "available": {
+                    "type": "integer",
+                    "example": 197
+                },
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "cost_price": {
+                    "type": "string",
+                    "example": "62000.00"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "isbn": {
+                    "type": "string",
+                    "example": "9783161484100"
+                },
+                "on_hand": {
+                    "description": "OnHand adalah jumlah fisik, Reserved adalah bagian yang ditahan reservasi aktif dan Available\nadalah jumlah yang masih bisa dijual",
+                    "type": "integer",
+                    "example": 200
+                },
+                "on_order": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "preferred_supplier_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "published_at": {
+                    "type": "string",
+                    "example": "2016-01-28"
+                },
+                "publisher": {
+                    "type": "string",
+                    "example": "Gramedia"
+                },
+                "reorder_point": {
+                    "description": "buku perlu dipesan ulang jika reorder_quantity \u003e 0 dan stock \u003c= reorder_point",
+                    "type": "integer",
+                    "example": 20
+                },
+                "reorder_quantity": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "reserved": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "sale_price": {
+                    "type": "string",
+                    "example": "89000.00"
+                },
+                "stock": {
+                    "description": "Stock sama dengan OnHand, dipertahankan untuk klien lama",
+                    "type": "integer",
+                    "example": 200
+                },
+                "suggested_quantity": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Hujan"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_BackorderResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BackorderResponse"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/model.PaginationLinks"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.PaginationMeta"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_BookPriceHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BookPriceHistoryResponse"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/model.PaginationLinks"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.PaginationMeta"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_BookResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BookResponse"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/model.PaginationLinks"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.PaginationMeta"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_LocationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LocationResponse"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/model.PaginationLinks"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.PaginationMeta"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_LowStockBookResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LowStockBookResponse"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/model.PaginationLinks"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.PaginationMeta"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_PromotionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PromotionResponse"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/model.PaginationLinks"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.PaginationMeta"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_PurchaseOrderResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PurchaseOrderResponse"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/model.PaginationLinks"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.PaginationMeta"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_ReservationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReservationResponse"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/model.PaginationLinks"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.PaginationMeta"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_SaleResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SaleResponse"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/model.PaginationLinks"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.PaginationMeta"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_StockMovementResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockMovementResponse"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/model.PaginationLinks"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.PaginationMeta"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_StockTakeLineResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockTakeLineResponse"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/model.PaginationLinks"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.PaginationMeta"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_StockTakeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockTakeResponse"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/model.PaginationLinks"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.PaginationMeta"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_StockTransferResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockTransferResponse"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/model.PaginationLinks"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.PaginationMeta"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_SupplierResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SupplierResponse"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/model.PaginationLinks"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.PaginationMeta"
+                }
+            }
+        },
+        "model.PaginationLinks": {
+            "type": "object",
+            "properties": {
+                "first": {
+                    "type": "string",
+                    "example": "/api/v1/books?offset=0\u0026limit=10"
+                },
+                "last": {
+                    "type": "string",
+                    "example": "/api/v1/books?offset=90\u0026limit=10"
+                },
+                "next": {
+                    "type": "string",
+                    "example": "/api/v1/books?offset=10\u0026limit=10"
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJpZCI6IjAxOTZmMWEyLTdjM2QtN2U0Zi04YTliLTBjMWQyZTNmNGE1YiIsImJhY2t3YXJkIjpmYWxzZX0"
+                },
+                "prev": {
+                    "type": "string",
+                    "example": "/api/v1/books?offset=0\u0026limit=10"
+                },
+                "prev_cursor": {
+                    "type": "string",
+                    "example": "eyJpZCI6IjAxOTZmMWEyLTdjM2QtN2U0Zi04YTliLTBjMWQyZTNmNGE1YiIsImJhY2t3YXJkIjp0cnVlfQ"
+                },
+                "self": {
+                    "type": "string",
+                    "example": "/api/v1/books?offset=0\u0026limit=10"
+                }
+            }
+        },
+        "model.PaginationMeta": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "model.PreviewSaleRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.SaleItemRequest"
+                    }
+                }
+            }
+        },
+        "model.PromotionRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "rule_type",
+                "scope"
+            ],
+            "properties": {
+                "active": {
+                    "description": "Active bernilai true jika tidak dikirim",
+                    "type": "boolean",
+                    "example": true
+                },
+                "book_ids": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "bundle_price": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "bundle_quantity": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0,
+                    "example": 0
+                },
+                "buy_quantity": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0,
+                    "example": 0
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-08-31T00:00:00Z"
+                },
+                "free_quantity": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0,
+                    "example": 0
+                },
+                "min_subtotal": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Diskon 10% Gramedia"
+                },
+                "percent": {
+                    "type": "string",
+                    "example": "10"
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0,
+                    "example": 10
+                },
+                "rule_type": {
+                    "type": "string",
+                    "enum": [
+                        "percent_off",
+                        "buy_x_get_y",
+                        "fixed_price_bundle"
+                    ],
+                    "example": "percent_off"
+                },
+                "scope": {
+                    "type": "string",
+                    "enum": [
+                        "all",
+                        "publisher",
+                        "author",
+                        "books"
+                    ],
+                    "example": "publisher"
+                },
+                "scope_value": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Gramedia Pustaka Utama"
+                },
+                "stackable": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-08-11T00:00:00Z"
+                }
+            }
+        },
+        "model.PromotionResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "book_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "bundle_price": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "bundle_quantity": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "buy_quantity": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-11T02:20:45Z"
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-08-31T00:00:00Z"
+                },
+                "free_quantity": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "min_subtotal": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Diskon 10% Gramedia"
+                },
+                "percent": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "promotion_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "rule_type": {
+                    "type": "string",
+                    "example": "percent_off"
+                },
+                "scope": {
+                    "type": "string",
+                    "example": "publisher"
+                },
+                "scope_value": {
+                    "type": "string",
+                    "example": "Gramedia Pustaka Utama"
+                },
+                "stackable": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-08-11T00:00:00Z"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-08-11T02:20:45Z"
+                }
+            }
+        },
+        "model.PurchaseOrderItemRequest": {
+            "type": "object",
+            "required": [
+                "book_id",
+                "quantity"
+            ],
+            "properties": {
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "unit_cost": {
+                    "type": "string",
+                    "example": "62000.00"
+                }
+            }
+        },
+        "model.PurchaseOrderItemResponse": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "line_total": {
+                    "type": "string",
+                    "example": "1240000.00"
+                },
+                "purchase_order_item_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "quantity_ordered": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "quantity_over": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "quantity_received": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "quantity_short": {
+                    "description": "QuantityShort adalah jumlah yang belum diterima, QuantityOver adalah kelebihan kiriman",
+                    "type": "integer",
+                    "example": 20
+                },
+                "unit_cost": {
+                    "type": "string",
+                    "example": "62000.00"
+                }
+            }
+        },
+        "model.PurchaseOrderRequest": {
+            "type": "object",
+            "required": [
+                "items",
+                "supplier_id"
+            ],
+            "properties": {
+                "expected_at": {
+                    "type": "string",
+                    "example": "2025-07-28"
+                },
+                "items": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.PurchaseOrderItemRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": ""
+                },
+                "supplier_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                }
+            }
+        },
+        "model.PurchaseOrderResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-21T03:45:12Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "expected_at": {
+                    "type": "string",
+                    "example": "2025-07-28T00:00:00Z"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PurchaseOrderItemResponse"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": ""
+                },
+                "purchase_order_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "draft"
+                },
+                "supplier_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "total_cost": {
+                    "type": "string",
+                    "example": "1240000.00"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-07-21T03:45:12Z"
+                }
+            }
+        },
+        "model.ReceiptItemRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "9786020324784"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "model.ReceivePurchaseOrderRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.ReceiptItemRequest"
+                    }
+                },
+                "location_id": {
+                    "description": "LocationID adalah lokasi penyimpanan barang yang diterima, kosong berarti lokasi default",
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "reference": {
+                    "description": "Reference biasanya berisi nomor surat jalan dari supplier",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "SJ-2025-0712"
+                }
+            }
+        },
+        "model.ReceivePurchaseOrderResponse": {
+            "type": "object",
+            "properties": {
+                "allocations": {
+                    "description": "Allocations berisi backorder yang langsung mendapat stok dari barang yang diterima",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BackorderResponse"
+                    }
+                },
+                "movements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockMovementResponse"
+                    }
+                },
+                "purchase_order": {
+                    "$ref": "#/definitions/model.PurchaseOrderResponse"
+                }
+            }
+        },
+        "model.RefreshTokenRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "model.ReorderRequest": {
+            "type": "object",
+            "properties": {
+                "supplier_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                }
+            }
+        },
+        "model.ReorderResponse": {
+            "type": "object",
+            "properties": {
+                "purchase_orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PurchaseOrderResponse"
+                    }
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReorderSkippedBook"
+                    }
+                }
+            }
+        },
+        "model.ReorderSkippedBook": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "no preferred supplier"
+                }
+            }
+        },
+        "model.ReservationItemRequest": {
+            "type": "object",
+            "required": [
+                "book_id",
+                "quantity"
+            ],
+            "properties": {
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "model.ReservationItemResponse": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reservation_item_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                }
+            }
+        },
+        "model.ReservationResponse": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string",
+                    "example": "phone"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-15T03:18:22Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "customer_contact": {
+                    "type": "string",
+                    "example": "0812-3456-7890"
+                },
+                "customer_name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-09-17T03:18:22Z"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReservationItemResponse"
+                    }
+                },
+                "location_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Picks up after work"
+                },
+                "reservation_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "sale_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-09-15T03:18:22Z"
+                }
+            }
+        },
+        "model.ReservationSaleRequest": {
+            "type": "object",
+            "required": [
+                "payment_method"
+            ],
+            "properties": {
+                "amount_paid": {
+                    "type": "string",
+                    "example": "100000.00"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": ""
+                },
+                "payment_method": {
+                    "type": "string",
+                    "enum": [
+                        "cash",
+                        "card",
+                        "qris",
+                        "transfer"
+                    ],
+                    "example": "cash"
+                }
+            }
+        },
+        "model.ReservationSaleResponse": {
+            "type": "object",
+            "properties": {
+                "reservation": {
+                    "$ref": "#/definitions/model.ReservationResponse"
+                },
+                "sale": {
+                    "$ref": "#/definitions/model.SaleResponse"
+                }
+            }
+        },
+        "model.SaleItemRequest": {
+            "type": "object",
+            "required": [
+                "book_id",
+                "quantity"
+            ],
+            "properties": {
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "model.SaleItemResponse": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "discount": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "isbn": {
+                    "type": "string",
+                    "example": "9783161484100"
+                },
+                "line_total": {
+                    "type": "string",
+                    "example": "178000.00"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "sale_item_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Hujan"
+                },
+                "unit_price": {
+                    "type": "string",
+                    "example": "89000.00"
+                }
+            }
+        },
+        "model.SalePreviewItemResponse": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "discount": {
+                    "type": "string",
+                    "example": "17800.00"
+                },
+                "isbn": {
+                    "type": "string",
+                    "example": "9783161484100"
+                },
+                "line_total": {
+                    "type": "string",
+                    "example": "160200.00"
+                },
+                "promotion_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Hujan"
+                },
+                "unit_price": {
+                    "type": "string",
+                    "example": "89000.00"
+                }
+            }
+        },
+        "model.SalePreviewResponse": {
+            "type": "object",
+            "properties": {
+                "discount_total": {
+                    "type": "string",
+                    "example": "17800.00"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SalePreviewItemResponse"
+                    }
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SalePromotionResponse"
+                    }
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "178000.00"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "160200.00"
+                }
+            }
+        },
+        "model.SalePromotionResponse": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "string",
+                    "example": "17800.00"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Diskon 10% Gramedia"
+                },
+                "promotion_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                }
+            }
+        },
+        "model.SaleResponse": {
+            "type": "object",
+            "properties": {
+                "amount_paid": {
+                    "type": "string",
+                    "example": "200000.00"
+                },
+                "cashier_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "change_due": {
+                    "type": "string",
+                    "example": "22000.00"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-02T03:15:07Z"
+                },
+                "discount_total": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SaleItemResponse"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": ""
+                },
+                "payment_method": {
+                    "type": "string",
+                    "example": "cash"
+                },
+                "promotions": {
+                    "description": "Promotions berisi promosi yang memberi diskon pada penjualan ini",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SalePromotionResponse"
+                    }
+                },
+                "sale_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "178000.00"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "178000.00"
+                }
+            }
+        },
+        "model.SaleReturnItemRequest": {
+            "type": "object",
+            "required": [
+                "quantity",
+                "sale_item_id"
+            ],
+            "properties": {
+                "condition": {
+                    "type": "string",
+                    "enum": [
+                        "resellable",
+                        "damaged"
+                    ],
+                    "example": "resellable"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sale_item_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                }
+            }
+        },
+        "model.SaleReturnItemResponse": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "condition": {
+                    "type": "string",
+                    "example": "resellable"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "refund_amount": {
+                    "type": "string",
+                    "example": "80100.00"
+                },
+                "sale_item_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "sale_return_item_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                }
+            }
+        },
+        "model.SaleReturnResponse": {
+            "type": "object",
+            "properties": {
+                "allocations": {
+                    "description": "Allocations berisi backorder yang mendapat stok dari barang retur, hanya diisi saat retur dibuat",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BackorderResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-18T03:04:15Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SaleReturnItemResponse"
+                    }
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Halaman terbalik"
+                },
+                "refund_total": {
+                    "type": "string",
+                    "example": "80100.00"
+                },
+                "sale_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "sale_return_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                }
+            }
+        },
+        "model.ScanRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "sell",
+                        "receive"
+                    ],
+                    "example": "sell"
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 512,
+                    "example": "978-3-16-148410-0"
+                },
+                "location_id": {
+                    "description": "LocationID kosong berarti lokasi default",
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "reference": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "TILL-2"
+                }
+            }
+        },
+        "model.ScanResponse": {
+            "type": "object",
+            "properties": {
+                "allocations": {
+                    "description": "Allocations berisi backorder yang mendapat stok dari barang yang diterima",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BackorderResponse"
+                    }
+                },
+                "book": {
+                    "$ref": "#/definitions/model.BookResponse"
+                },
+                "code_type": {
+                    "type": "string",
+                    "example": "isbn13"
+                },
+                "movement": {
+                    "$ref": "#/definitions/model.StockMovementResponse"
+                }
+            }
+        },
+        "model.StockMovementResponse": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-06-08T04:12:56Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "location_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "movement_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "movement_type": {
+                    "type": "string",
+                    "example": "receipt"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Restock from supplier"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "INV-2025-0001"
+                },
+                "stock_after": {
+                    "type": "integer",
+                    "example": 220
+                }
+            }
+        },
+        "model.StockTakeApproveResponse": {
+            "type": "object",
+            "properties": {
+                "movements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockMovementResponse"
+                    }
+                },
+                "stock_take": {
+                    "$ref": "#/definitions/model.StockTakeResponse"
+                }
+            }
+        },
+        "model.StockTakeCountItemRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
                     "type": "string",
-                    "example": "Tere Liye"
+                    "maxLength": 2048,
+                    "example": "9789793062792"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
+                }
+            }
+        },
+        "model.StockTakeCountRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.StockTakeCountItemRequest"
+                    }
                 },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "add",
+                        "set"
+                    ],
+                    "example": "add"
+                }
+            }
+        },
+        "model.StockTakeLineResponse": {
+            "type": "object",
+            "properties": {
                 "book_id": {
                     "type": "string",
                     "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
                 },
-                "isbn": {
-                    "type": "string",
-                    "example": "978-3-16-148410-0"
+                "counted": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "published_at": {
+                "counted_at": {
                     "type": "string",
-                    "example": "2016-01-28"
+                    "example": "2025-09-08T03:10:12Z"
                 },
-                "publisher": {
+                "counted_by": {
                     "type": "string",
-                    "example": "Gramedia"
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
                 },
-                "stock": {
+                "expected": {
                     "type": "integer",
-                    "example": 200
+                    "example": 12
+                },
+                "isbn": {
+                    "type": "string",
+                    "example": "9789793062792"
                 },
                 "title": {
                     "type": "string",
-                    "example": "Hujan"
+                    "example": "Laskar Pelangi"
+                },
+                "variance": {
+                    "type": "integer",
+                    "example": -2
                 }
             }
         },
-        "model.CreateBookRequest": {
+        "model.StockTakeResponse": {
             "type": "object",
-            "required": [
-                "author",
-                "isbn",
-                "published_at",
-                "publisher",
-                "stock",
-                "title"
-            ],
             "properties": {
-                "author": {
+                "approved_at": {
                     "type": "string",
-                    "example": "Tere Liye"
+                    "example": "2025-09-08T09:30:00Z"
                 },
-                "isbn": {
+                "approved_by": {
                     "type": "string",
-                    "example": "9783161484100"
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
                 },
-                "published_at": {
+                "created_at": {
                     "type": "string",
-                    "example": "2016-01-28"
+                    "example": "2025-09-08T02:45:30Z"
                 },
-                "publisher": {
+                "created_by": {
                     "type": "string",
-                    "example": "Gramedia"
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
                 },
-                "stock": {
+                "location_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Odd semester count"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "open"
+                },
+                "stock_take_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "summary": {
+                    "$ref": "#/definitions/model.StockTakeSummaryResponse"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-09-08T02:45:30Z"
+                }
+            }
+        },
+        "model.StockTakeSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "counted_books": {
                     "type": "integer",
-                    "minimum": 0,
-                    "example": 200
+                    "example": 1180
                 },
-                "title": {
+                "discrepancy_books": {
+                    "type": "integer",
+                    "example": 14
+                },
+                "net_variance": {
+                    "description": "NetVariance adalah total selisih hasil hitung terhadap jumlah yang diharapkan untuk buku\nyang sudah dihitung. Nilai negatif berarti ada buku yang hilang",
+                    "type": "integer",
+                    "example": -9
+                },
+                "total_books": {
+                    "type": "integer",
+                    "example": 1250
+                }
+            }
+        },
+        "model.StockTransferItemRequest": {
+            "type": "object",
+            "required": [
+                "book_id",
+                "quantity"
+            ],
+            "properties": {
+                "book_id": {
                     "type": "string",
-                    "example": "Hujan"
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
                 }
             }
         },
-        "model.DataResponse-model_BookResponse": {
+        "model.StockTransferItemResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/model.BookResponse"
+                "book_id": {
+                    "type": "string",
+                    "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "transfer_item_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
                 }
             }
         },
-        "model.PaginatedResponse-model_BookResponse": {
+        "model.StockTransferProcessResponse": {
             "type": "object",
             "properties": {
-                "data": {
+                "allocations": {
+                    "description": "Allocations berisi backorder di lokasi tujuan yang langsung mendapat stok saat transfer diterima",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.BookResponse"
+                        "$ref": "#/definitions/model.BackorderResponse"
                     }
                 },
-                "links": {
-                    "$ref": "#/definitions/model.PaginationLinks"
+                "movements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockMovementResponse"
+                    }
                 },
-                "meta": {
-                    "$ref": "#/definitions/model.PaginationMeta"
+                "transfer": {
+                    "$ref": "#/definitions/model.StockTransferResponse"
                 }
             }
         },
-        "model.PaginationLinks": {
+        "model.StockTransferResponse": {
             "type": "object",
             "properties": {
-                "first": {
+                "created_at": {
                     "type": "string",
-                    "example": "/api/v1/books?offset=0\u0026limit=10"
+                    "example": "2025-09-01T02:34:15Z"
                 },
-                "last": {
+                "dispatched_at": {
                     "type": "string",
-                    "example": "/api/v1/books?offset=90\u0026limit=10"
+                    "example": "2025-09-01T02:34:15Z"
                 },
-                "next": {
+                "dispatched_by": {
                     "type": "string",
-                    "example": "/api/v1/books?offset=10\u0026limit=10"
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
                 },
-                "prev": {
+                "from_location_id": {
                     "type": "string",
-                    "example": "/api/v1/books?offset=0\u0026limit=10"
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
                 },
-                "self": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockTransferItemResponse"
+                    }
+                },
+                "notes": {
                     "type": "string",
-                    "example": "/api/v1/books?offset=0\u0026limit=10"
+                    "example": "Stock for the new semester"
+                },
+                "received_at": {
+                    "type": "string",
+                    "example": "2025-09-01T05:12:40Z"
+                },
+                "received_by": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "requested_by": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "requested"
+                },
+                "to_location_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5c"
+                },
+                "transfer_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-09-01T02:34:15Z"
                 }
             }
         },
-        "model.PaginationMeta": {
+        "model.SupplierResponse": {
             "type": "object",
             "properties": {
-                "limit": {
-                    "type": "integer",
-                    "example": 10
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Palmerah Barat 29, Jakarta"
                 },
-                "offset": {
+                "contact_name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-21T03:45:12Z"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "order@gramedia.example"
+                },
+                "lead_time_days": {
                     "type": "integer",
-                    "example": 0
+                    "example": 7
                 },
-                "total": {
+                "name": {
+                    "type": "string",
+                    "example": "Gramedia Distribusi"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+62215550100"
+                },
+                "supplier_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-07-21T03:45:12Z"
+                }
+            }
+        },
+        "model.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "expires_in": {
                     "type": "integer",
-                    "example": 100
+                    "example": 900
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
                 }
             }
         },
@@ -460,10 +8320,31 @@ const docTemplate = `{
                     "type": "string",
                     "example": "b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"
                 },
+                "clear_preferred_supplier": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "cost_price": {
+                    "type": "string",
+                    "example": "62000.00"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
                 "isbn": {
                     "type": "string",
                     "example": "9783161484100"
                 },
+                "location_id": {
+                    "description": "LocationID adalah lokasi yang stoknya ditetapkan menjadi Stock, kosong berarti lokasi default",
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "preferred_supplier_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
                 "published_at": {
                     "type": "string",
                     "example": "2016-01-28"
@@ -472,6 +8353,21 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Gramedia"
                 },
+                "reorder_point": {
+                    "description": "field pemesanan ulang yang tidak dikirim tidak diubah. ClearPreferredSupplier menghapus\nsupplier dan tidak boleh dikirim bersama PreferredSupplierID",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 20
+                },
+                "reorder_quantity": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 50
+                },
+                "sale_price": {
+                    "type": "string",
+                    "example": "89000.00"
+                },
                 "stock": {
                     "type": "integer",
                     "minimum": -1,
@@ -483,6 +8379,113 @@ const docTemplate = `{
                 }
             }
         },
+        "model.UpdateLocationRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Gedung Student Center Lt. 1"
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 1,
+                    "example": "campus"
+                },
+                "is_default": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 1,
+                    "example": "Campus booth"
+                }
+            }
+        },
+        "model.UpdatePurchaseOrderStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "sent",
+                        "cancelled"
+                    ],
+                    "example": "sent"
+                }
+            }
+        },
+        "model.UpdateSupplierRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Jl. Palmerah Barat 29, Jakarta"
+                },
+                "contact_name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Budi Santoso"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "order@gramedia.example"
+                },
+                "lead_time_days": {
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 0,
+                    "example": 7
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 1,
+                    "example": "Gramedia Distribusi"
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "+62215550100"
+                }
+            }
+        },
+        "model.UserResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-06-20T02:18:45Z"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "Ucup Surucup"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "role": {
+                    "type": "string",
+                    "example": "cashier"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "ucup"
+                }
+            }
+        },
         "types.HTTPError": {
             "type": "object",
             "properties": {
@@ -508,6 +8511,14 @@ const docTemplate = `{
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Access token from /auth/login, formatted as \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
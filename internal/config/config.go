@@ -1,10 +1,14 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"reflect"
+	"strings"
 	"time"
 
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/shopspring/decimal"
 	"github.com/spf13/viper"
 )
 
@@ -27,6 +31,9 @@ const (
 	CSRF_COOKIE_NAME              = "__Host_csrf_"
 	ACCESS_TOKEN_EXPIRATION_TIME  = time.Minute * 15
 	REFRESH_TOKEN_EXPIRATION_TIME = (time.Hour * 24) * 7
+	DEFAULT_CURRENCY              = "IDR"
+	DEFAULT_RESERVATION_TTL       = time.Hour * 48
+	RESERVATION_EXPIRY_INTERVAL   = time.Minute
 )
 
 type Config struct {
@@ -39,6 +46,19 @@ type Config struct {
 	DB_NAME                  string `mapstructure:"DB_NAME"`
 	JWT_ACCESS_TOKEN_SECRET  string `mapstructure:"JWT_ACCESS_TOKEN_SECRET"`
 	JWT_REFRESH_TOKEN_SECRET string `mapstructure:"JWT_REFRESH_TOKEN_SECRET"`
+	ADMIN_USERNAME           string `mapstructure:"ADMIN_USERNAME"`
+	ADMIN_PASSWORD           string `mapstructure:"ADMIN_PASSWORD"`
+	STORE_NAME               string `mapstructure:"STORE_NAME"`
+	STORE_ADDRESS            string `mapstructure:"STORE_ADDRESS"`
+	STORE_PHONE              string `mapstructure:"STORE_PHONE"`
+	STORE_TAX_ID             string `mapstructure:"STORE_TAX_ID"`
+	// STORE_TAX_RATE adalah persen pajak yang sudah termasuk dalam harga jual, misalnya 11.
+	// Nilainya diparse langsung dari teks agar tidak melewati float64
+	STORE_TAX_RATE decimal.Decimal `mapstructure:"STORE_TAX_RATE"`
+	RECEIPT_FOOTER string          `mapstructure:"RECEIPT_FOOTER"`
+	// RESERVATION_TTL adalah lama reservasi ditahan sebelum dilepas otomatis, misalnya 48h.
+	// Nilai kosong berarti DEFAULT_RESERVATION_TTL
+	RESERVATION_TTL time.Duration `mapstructure:"RESERVATION_TTL"`
 }
 
 func InitConfig() (*Config, error) {
@@ -58,7 +78,12 @@ func InitConfig() (*Config, error) {
 	}
 
 	// TODO: struct validation?
-	err = v.Unmarshal(cfg)
+	err = v.Unmarshal(cfg, viper.DecodeHook(decodeHook))
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateTaxRate(cfg.STORE_TAX_RATE)
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +91,36 @@ func InitConfig() (*Config, error) {
 	return cfg, nil
 }
 
+// decodeHook sama dengan decode hook bawaan viper ditambah konversi teks ke decimal
+var decodeHook = mapstructure.ComposeDecodeHookFunc(
+	mapstructure.StringToTimeDurationHookFunc(),
+	mapstructure.StringToSliceHookFunc(","),
+	stringToDecimalHook,
+)
+
+// stringToDecimalHook mengubah teks menjadi decimal tanpa melewati float64. Teks kosong berarti nol
+func stringToDecimalHook(from reflect.Type, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to != reflect.TypeOf(decimal.Decimal{}) {
+		return data, nil
+	}
+
+	s := strings.TrimSpace(data.(string))
+	if s == "" {
+		return decimal.Zero, nil
+	}
+
+	return decimal.NewFromString(s)
+}
+
+// validateTaxRate memastikan STORE_TAX_RATE berada di antara 0 dan kurang dari 100
+func validateTaxRate(rate decimal.Decimal) error {
+	if rate.IsNegative() || rate.GreaterThanOrEqual(decimal.NewFromInt(100)) {
+		return fmt.Errorf("invalid STORE_TAX_RATE %s: must be at least 0 and less than 100", rate)
+	}
+
+	return nil
+}
+
 func bindEnvStruct(v *viper.Viper, s any) {
 	val := reflect.ValueOf(s)
 	if val.Kind() == reflect.Ptr {
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/viper"
+)
+
+func TestStoreTaxRate(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "", want: "0"},
+		{input: "11", want: "11"},
+		{input: " 11.1 ", want: "11.1"},
+		{input: "0", want: "0"},
+		{input: "abc", wantErr: true},
+		{input: "11,1", wantErr: true},
+		{input: "-1", wantErr: true},
+		{input: "100", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			v := viper.New()
+			v.Set("STORE_TAX_RATE", tt.input)
+
+			cfg := new(Config)
+			err := v.Unmarshal(cfg, viper.DecodeHook(decodeHook))
+			if err == nil {
+				err = validateTaxRate(cfg.STORE_TAX_RATE)
+			}
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("STORE_TAX_RATE=%q expected an error", tt.input)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("STORE_TAX_RATE=%q unexpected error: %v", tt.input, err)
+			}
+			if !cfg.STORE_TAX_RATE.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("STORE_TAX_RATE=%q parsed as %s, want %s", tt.input, cfg.STORE_TAX_RATE, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"log"
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/config"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+)
+
+type AuthController struct {
+	authUsecase *usecase.AuthUsecase
+}
+
+func NewAuthController(authUsecase *usecase.AuthUsecase) *AuthController {
+	return &AuthController{authUsecase}
+}
+
+// Login mengautentikasi user dan menerbitkan access token serta refresh token
+//
+//	@Summary		Login
+//	@Description	Authenticate with username and password. The refresh token is also set as an HTTP-only cookie.
+//	@Tags			auth
+//	@Router			/auth/login [post]
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		model.LoginRequest						true	"Request payload"
+//	@Success		200		{object}	model.DataResponse[model.TokenResponse]	"Login successful"
+//	@Failure		500		{object}	types.HTTPError							"Internal server error"
+//	@Failure		403		{object}	types.HTTPError							"User account is disabled"
+//	@Failure		401		{object}	types.HTTPError							"Invalid username or password"
+//	@Failure		400		{object}	types.HTTPError							"Invalid request payload"
+func (a AuthController) Login(c *fiber.Ctx) error {
+	request := new(model.LoginRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	tokens, err := a.authUsecase.Login(c.Context(), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error logging in:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to login")
+	}
+
+	setAuthCookies(c, tokens)
+
+	response := model.DataResponse[model.TokenResponse]{
+		Data: tokens,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Refresh menukar refresh token dengan pasangan token yang baru
+//
+//	@Summary		Refresh tokens
+//	@Description	Rotate the refresh token and issue a new access token. The refresh token is read from the request body or from the refresh token cookie. Reusing a rotated refresh token revokes every session of the user.
+//	@Tags			auth
+//	@Router			/auth/refresh [post]
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		model.RefreshTokenRequest				false	"Request payload"
+//	@Success		200		{object}	model.DataResponse[model.TokenResponse]	"Tokens refreshed successfully"
+//	@Failure		500		{object}	types.HTTPError							"Internal server error"
+//	@Failure		401		{object}	types.HTTPError							"Invalid or revoked refresh token"
+func (a AuthController) Refresh(c *fiber.Ctx) error {
+	tokens, err := a.authUsecase.Refresh(c.Context(), refreshTokenFromRequest(c))
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error refreshing token:", eris.ToString(err, true))
+
+		clearAuthCookies(c)
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to refresh token")
+	}
+
+	setAuthCookies(c, tokens)
+
+	response := model.DataResponse[model.TokenResponse]{
+		Data: tokens,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Logout mencabut refresh token dan menghapus cookie autentikasi
+//
+//	@Summary		Logout
+//	@Description	Revoke the refresh token and clear the authentication cookies
+//	@Tags			auth
+//	@Router			/auth/logout [post]
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		model.RefreshTokenRequest	false	"Request payload"
+//	@Success		204		{string}	string						"Logged out successfully"
+//	@Failure		500		{object}	types.HTTPError				"Internal server error"
+func (a AuthController) Logout(c *fiber.Ctx) error {
+	err := a.authUsecase.Logout(c.Context(), refreshTokenFromRequest(c))
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error logging out:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to logout")
+	}
+
+	clearAuthCookies(c)
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// refreshTokenFromRequest mengambil refresh token dari body request, atau dari cookie jika body kosong
+func refreshTokenFromRequest(c *fiber.Ctx) string {
+	request := new(model.RefreshTokenRequest)
+	if len(c.Body()) > 0 {
+		_ = c.BodyParser(request)
+	}
+
+	if request.RefreshToken != "" {
+		return request.RefreshToken
+	}
+
+	return c.Cookies(config.REFRESH_TOKEN_COOKIE_NAME)
+}
+
+func setAuthCookies(c *fiber.Ctx, tokens model.TokenResponse) {
+	now := time.Now()
+	secure := config.APP_ENV == "production"
+
+	c.Cookie(&fiber.Cookie{
+		Name:     config.ACCESS_TOKEN_COOKIE_NAME,
+		Value:    tokens.AccessToken,
+		Path:     "/",
+		Expires:  now.Add(config.ACCESS_TOKEN_EXPIRATION_TIME),
+		Secure:   secure,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteStrictMode,
+	})
+
+	c.Cookie(&fiber.Cookie{
+		Name:     config.REFRESH_TOKEN_COOKIE_NAME,
+		Value:    tokens.RefreshToken,
+		Path:     config.BASE_API_HTTP_PATH + "/auth",
+		Expires:  now.Add(config.REFRESH_TOKEN_EXPIRATION_TIME),
+		Secure:   secure,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteStrictMode,
+	})
+}
+
+func clearAuthCookies(c *fiber.Ctx) {
+	secure := config.APP_ENV == "production"
+
+	c.Cookie(&fiber.Cookie{
+		Name:     config.ACCESS_TOKEN_COOKIE_NAME,
+		Path:     "/",
+		MaxAge:   -1,
+		Secure:   secure,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteStrictMode,
+	})
+
+	c.Cookie(&fiber.Cookie{
+		Name:     config.REFRESH_TOKEN_COOKIE_NAME,
+		Path:     config.BASE_API_HTTP_PATH + "/auth",
+		MaxAge:   -1,
+		Secure:   secure,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteStrictMode,
+	})
+}
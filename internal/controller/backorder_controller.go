@@ -0,0 +1,217 @@
+package controller
+
+import (
+	"log"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/middleware"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+)
+
+type BackorderController struct {
+	backorderUsecase *usecase.BackorderUsecase
+}
+
+func NewBackorderController(backorderUsecase *usecase.BackorderUsecase) *BackorderController {
+	return &BackorderController{backorderUsecase}
+}
+
+// Create memasukkan pesanan pelanggan ke antrean backorder atau preorder
+//
+//	@Summary		Create a backorder or pre-order
+//	@Description	Queue a customer order for a book at a pickup location, or at the default location when location_id is omitted. Orders for books with a future published_at become pre-orders, all other orders become backorders. A backorder is rejected while the location has enough available stock and nobody is waiting, since a reservation can be made instead. When goods are received at the location, waiting orders are served first-come first-served and each allocated order gets a reservation on the backorder channel. If that reservation expires or is cancelled before pickup, the order is cancelled and the released copies go to the next waiting order.
+//	@Tags			backorders
+//	@Router			/backorders [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		model.CreateBackorderRequest				true	"Request payload"
+//	@Success		201		{object}	model.DataResponse[model.BackorderResponse]	"Backorder created successfully"
+//	@Failure		500		{object}	types.HTTPError								"Internal server error"
+//	@Failure		409		{object}	types.HTTPError								"Book is in stock at this location"
+//	@Failure		404		{object}	types.HTTPError								"Location or book not found"
+//	@Failure		403		{object}	types.HTTPError								"Forbidden"
+//	@Failure		401		{object}	types.HTTPError								"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError								"Invalid request payload"
+func (b BackorderController) Create(c *fiber.Ctx) error {
+	request := new(model.CreateBackorderRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	backorder, err := b.backorderUsecase.Create(c.Context(), middleware.GetUserID(c), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error creating backorder:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to create backorder")
+	}
+
+	response := model.DataResponse[model.BackorderResponse]{
+		Data: backorder,
+	}
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// GetByID mengambil backorder beserta posisi antreannya berdasarkan ID
+//
+//	@Summary		Get backorder by ID
+//	@Description	Get a backorder or pre-order by ID. Waiting orders include their queue_position, where 1 is the next order to be served.
+//	@Tags			backorders
+//	@Router			/backorders/{backorder_id} [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			backorder_id	path		string										true	"Backorder ID"
+//	@Success		200				{object}	model.DataResponse[model.BackorderResponse]	"Backorder retrieved successfully"
+//	@Failure		500				{object}	types.HTTPError								"Internal server error"
+//	@Failure		404				{object}	types.HTTPError								"Backorder not found"
+//	@Failure		403				{object}	types.HTTPError								"Forbidden"
+//	@Failure		401				{object}	types.HTTPError								"Unauthorized"
+//	@Failure		400				{object}	types.HTTPError								"Invalid Backorder ID format or Backorder ID is required"
+func (b BackorderController) GetByID(c *fiber.Ctx) error {
+	backorderId := c.Params("backorder_id")
+	if backorderId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Backorder ID is required")
+	}
+
+	backorder, err := b.backorderUsecase.GetById(c.Context(), backorderId)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get backorder by ID")
+	}
+
+	response := model.DataResponse[model.BackorderResponse]{
+		Data: backorder,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetMany mengambil daftar backorder dengan filter status, jenis, buku dan lokasi
+//
+//	@Summary		Get backorders with pagination
+//	@Description	Get a list of backorders and pre-orders in queue order, oldest first, optionally filtered by status, kind, book and location
+//	@Tags			backorders
+//	@Router			/backorders [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			search	query		model.BackorderSearchRequest						false	"Filters"
+//	@Param			offset	query		int													false	"Page offset (default: 0)"
+//	@Param			limit	query		int													false	"Page limit (default: 10, max: 100)"
+//	@Success		200		{object}	model.PaginatedResponse[model.BackorderResponse]	"Backorders with pagination metadata and navigation links"
+//	@Failure		500		{object}	types.HTTPError										"Internal server error"
+//	@Failure		403		{object}	types.HTTPError										"Forbidden"
+//	@Failure		401		{object}	types.HTTPError										"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError										"Invalid query parameters"
+func (b BackorderController) GetMany(c *fiber.Ctx) error {
+	pagination, fe := parsePagination(c)
+	if fe != nil {
+		return newHTTPError(c, fe.Code, fe.Message)
+	}
+
+	search := new(model.BackorderSearchRequest)
+	if err := c.QueryParser(search); err != nil {
+		log.Println("Error parsing query parameters:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	backorders, total, err := b.backorderUsecase.GetMany(c.Context(), search, pagination.Offset, pagination.Limit)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get backorders")
+	}
+
+	response := newPaginatedResponse(c.BaseURL()+c.Route().Path, queryFilters(c), backorders, pagination, total)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Cancel mengeluarkan backorder yang masih menunggu dari antrean
+//
+//	@Summary		Cancel a backorder
+//	@Description	Remove a waiting backorder or pre-order from the queue. Allocated orders are cancelled by cancelling their reservation.
+//	@Tags			backorders
+//	@Router			/backorders/{backorder_id}/cancel [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			backorder_id	path		string										true	"Backorder ID"
+//	@Success		200				{object}	model.DataResponse[model.BackorderResponse]	"Backorder cancelled successfully"
+//	@Failure		500				{object}	types.HTTPError								"Internal server error"
+//	@Failure		409				{object}	types.HTTPError								"Backorder is not waiting"
+//	@Failure		404				{object}	types.HTTPError								"Backorder not found"
+//	@Failure		403				{object}	types.HTTPError								"Forbidden"
+//	@Failure		401				{object}	types.HTTPError								"Unauthorized"
+//	@Failure		400				{object}	types.HTTPError								"Invalid Backorder ID"
+func (b BackorderController) Cancel(c *fiber.Ctx) error {
+	backorder, err := b.backorderUsecase.Cancel(c.Context(), c.Params("backorder_id"))
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to cancel backorder")
+	}
+
+	response := model.DataResponse[model.BackorderResponse]{
+		Data: backorder,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Allocate mengalokasikan stok yang tersedia ke antrean sebuah buku
+//
+//	@Summary		Allocate available stock to waiting backorders
+//	@Description	Serve the queue of a book at a location, or at the default location when location_id is omitted, from the copies that are currently available. Allocation runs automatically when goods are received from purchase orders, stock transfers, scans, receipt movements and sale returns; use this endpoint after stock becomes available another way, such as a stock adjustment. Orders are served oldest first and only in full, stopping at the first order that cannot be filled.
+//	@Tags			backorders
+//	@Router			/backorders/allocate [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		model.AllocateBackordersRequest					true	"Request payload"
+//	@Success		200		{object}	model.DataResponse[[]model.BackorderResponse]	"Allocated backorders, empty when nothing could be allocated"
+//	@Failure		500		{object}	types.HTTPError									"Internal server error"
+//	@Failure		404		{object}	types.HTTPError									"Location or book not found"
+//	@Failure		403		{object}	types.HTTPError									"Forbidden"
+//	@Failure		401		{object}	types.HTTPError									"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError									"Invalid request payload"
+func (b BackorderController) Allocate(c *fiber.Ctx) error {
+	request := new(model.AllocateBackordersRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	backorders, err := b.backorderUsecase.Allocate(c.Context(), middleware.GetUserID(c), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error allocating backorders:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to allocate backorders")
+	}
+
+	response := model.DataResponse[[]model.BackorderResponse]{
+		Data: backorders,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
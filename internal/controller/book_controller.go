@@ -1,30 +1,19 @@
 package controller
 
 import (
+	"bufio"
+	"context"
 	"fmt"
 	"log"
-	"net/http"
 	"time"
 
+	"github.com/crazydw4rf/book-stock-manager/internal/middleware"
 	"github.com/crazydw4rf/book-stock-manager/internal/model"
-	"github.com/crazydw4rf/book-stock-manager/internal/types"
 	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
 	"github.com/gofiber/fiber/v2"
 	"github.com/rotisserie/eris"
 )
 
-// newHTTPError creates a new HTTPError response with additional context information
-func newHTTPError(c *fiber.Ctx, status int, message string) error {
-	now := time.Now().Format(time.RFC3339)
-	return c.Status(status).JSON(types.HTTPError{
-		Code:      status,
-		Message:   message,
-		Error:     http.StatusText(status),
-		Timestamp: now,
-		Path:      c.Path(),
-	})
-}
-
 type BookController struct {
 	bookUsecase *usecase.BookUsecase
 }
@@ -36,14 +25,19 @@ func NewBookController(bookUsecase *usecase.BookUsecase) *BookController {
 // BookCreate membuat buku baru
 //
 //	@Summary		Create a new book
-//	@Description	Create a new book with the provided information
+//	@Description	Create a new book with the provided information. The ISBN may be sent as ISBN-10 or ISBN-13, with or without hyphens, and is stored as canonical ISBN-13.
 //	@Tags			books
 //	@Router			/books [post]
+//	@Security		BearerAuth
 //	@Accept			json
 //	@Produce		json
 //	@Param			payload	body		model.CreateBookRequest					true	"Request payload"
 //	@Success		201		{object}	model.DataResponse[model.BookResponse]	"Book created successfully"
 //	@Failure		500		{object}	types.HTTPError							"Internal server error"
+//	@Failure		409		{object}	types.HTTPError							"Book with the same ISBN already exists"
+//	@Failure		404		{object}	types.HTTPError							"Preferred supplier not found"
+//	@Failure		403		{object}	types.HTTPError							"Forbidden"
+//	@Failure		401		{object}	types.HTTPError							"Unauthorized"
 //	@Failure		400		{object}	types.HTTPError							"Invalid request payload"
 func (b BookController) BookCreate(c *fiber.Ctx) error {
 	// parse request body ke dalam struct CreateBookRequest
@@ -54,7 +48,7 @@ func (b BookController) BookCreate(c *fiber.Ctx) error {
 	}
 
 	// panggil usecase untuk membuat buku baru
-	bookResp, err := b.bookUsecase.Create(c.Context(), request)
+	bookResp, err := b.bookUsecase.Create(c.Context(), middleware.GetUserID(c), request)
 	if err != nil {
 		var fe *fiber.Error
 		log.Println("Error creating book:", eris.ToString(err, true))
@@ -78,12 +72,15 @@ func (b BookController) BookCreate(c *fiber.Ctx) error {
 //	@Description	Get a book information by ISBN
 //	@Tags			books
 //	@Router			/books/isbn/{isbn} [get]
+//	@Security		BearerAuth
 //	@Accept			json
 //	@Produce		json
 //	@Param			isbn	path		string									true	"ISBN"
 //	@Success		200		{object}	model.DataResponse[model.BookResponse]	"Book information retrieved successfully"
 //	@Failure		500		{object}	types.HTTPError							"Internal server error"
 //	@Failure		404		{object}	types.HTTPError							"Book not found"
+//	@Failure		403		{object}	types.HTTPError							"Forbidden"
+//	@Failure		401		{object}	types.HTTPError							"Unauthorized"
 //	@Failure		400		{object}	types.HTTPError							"Invalid ISBN format or ISBN is required"
 func (b BookController) GetBookByISBN(c *fiber.Ctx) error {
 	isbn := c.Params("isbn")
@@ -111,23 +108,27 @@ func (b BookController) GetBookByISBN(c *fiber.Ctx) error {
 // GetBookByID mengambil data buku berdasarkan ID
 //
 //	@Summary		Get book by ID
-//	@Description	Get a book information by ID
+//	@Description	Get a book information by ID. When location is set, stock is the stock held at that location instead of the total across all locations.
 //	@Tags			books
 //	@Router			/books/{book_id} [get]
+//	@Security		BearerAuth
 //	@Accept			json
 //	@Produce		json
-//	@Param			book_id	path		string									true	"Book ID"
-//	@Success		200		{object}	model.DataResponse[model.BookResponse]	"Book information retrieved successfully"
-//	@Failure		500		{object}	types.HTTPError							"Internal server error"
-//	@Failure		404		{object}	types.HTTPError							"Book not found"
-//	@Failure		400		{object}	types.HTTPError							"Invalid Book ID format or Book ID is required"
+//	@Param			book_id		path		string									true	"Book ID"
+//	@Param			location	query		string									false	"Location ID or code"
+//	@Success		200			{object}	model.DataResponse[model.BookResponse]	"Book information retrieved successfully"
+//	@Failure		500			{object}	types.HTTPError							"Internal server error"
+//	@Failure		404			{object}	types.HTTPError							"Book or location not found"
+//	@Failure		403			{object}	types.HTTPError							"Forbidden"
+//	@Failure		401			{object}	types.HTTPError							"Unauthorized"
+//	@Failure		400			{object}	types.HTTPError							"Invalid Book ID format or Book ID is required"
 func (b BookController) GetBookByID(c *fiber.Ctx) error {
 	bookId := c.Params("book_id")
 	if bookId == "" {
 		return newHTTPError(c, fiber.StatusBadRequest, "Book ID is required")
 	}
 
-	book, err := b.bookUsecase.GetById(c.Context(), bookId)
+	book, err := b.bookUsecase.GetById(c.Context(), bookId, c.Query("location"))
 	if err != nil {
 		var fe *fiber.Error
 		if eris.As(err, &fe) {
@@ -143,37 +144,270 @@ func (b BookController) GetBookByID(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
+// GetBookQRCode membuat gambar QR code untuk label rak buku
+//
+//	@Summary		Get book QR code
+//	@Description	Generate a scannable QR code encoding the book ID, or a URL to the book detail endpoint when content is "url"
+//	@Tags			books
+//	@Router			/books/{book_id}/qr [get]
+//	@Security		BearerAuth
+//	@Produce		png
+//	@Produce		image/svg+xml
+//	@Param			book_id	path		string			true	"Book ID"
+//	@Param			format	query		string			false	"Image format"				Enums(png, svg)		default(png)
+//	@Param			size	query		int				false	"Image size in pixels"		minimum(64)			maximum(2048)	default(256)
+//	@Param			level	query		string			false	"Error correction level"	Enums(L, M, Q, H)	default(M)
+//	@Param			content	query		string			false	"Encoded content"			Enums(id, url)		default(id)
+//	@Success		200		{file}		file			"QR code image"
+//	@Failure		500		{object}	types.HTTPError	"Internal server error"
+//	@Failure		404		{object}	types.HTTPError	"Book not found"
+//	@Failure		403		{object}	types.HTTPError	"Forbidden"
+//	@Failure		401		{object}	types.HTTPError	"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError	"Invalid Book ID or query parameters"
+func (b BookController) GetBookQRCode(c *fiber.Ctx) error {
+	bookId := c.Params("book_id")
+	if bookId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Book ID is required")
+	}
+
+	request := new(model.BookQRCodeRequest)
+	if err := c.QueryParser(request); err != nil {
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	image, format, err := b.bookUsecase.GetQRCode(c.Context(), bookId, request, c.BaseURL())
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error generating QR code:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to generate QR code")
+	}
+
+	c.Set(fiber.HeaderContentType, format.ContentType())
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="%s.%s"`, bookId, format))
+	return c.Status(fiber.StatusOK).Send(image)
+}
+
+// GetLabelSheet membuat lembar label rak buku dalam format PDF
+//
+//	@Summary		Get printable label sheet
+//	@Description	Render an A4 PDF sheet of shelf labels (QR code, title, author, sale price with currency, ISBN) for the given book IDs, or for books matching the publisher/author filter, in a configurable grid layout
+//	@Tags			books
+//	@Router			/books/labels [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		application/pdf
+//	@Param			payload	body		model.BookLabelRequest	true	"Request payload"
+//	@Success		200		{file}		file					"PDF label sheet"
+//	@Failure		500		{object}	types.HTTPError			"Internal server error"
+//	@Failure		404		{object}	types.HTTPError			"Book not found"
+//	@Failure		403		{object}	types.HTTPError			"Forbidden"
+//	@Failure		401		{object}	types.HTTPError			"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError			"Invalid request payload"
+func (b BookController) GetLabelSheet(c *fiber.Ctx) error {
+	request := new(model.BookLabelRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	pdf, err := b.bookUsecase.GetLabelSheet(c.Context(), request, c.BaseURL())
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error generating label sheet:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to generate label sheet")
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, `inline; filename="book-labels.pdf"`)
+	return c.Status(fiber.StatusOK).Send(pdf)
+}
+
+// ImportBooks mengimpor banyak buku sekaligus dari file CSV
+//
+//	@Summary		Import books from CSV
+//	@Description	Import books from a CSV file. Columns isbn, title, author, publisher and published_at (YYYY-MM-DD) are required, stock is optional and sets the quantity at the default location. Header names are matched case-insensitively, or through mapping, a JSON object of field to header name. Rows are validated with the same rules as book creation, and invalid rows are reported without affecting the others. Valid rows are saved in a single transaction. With upsert, rows whose ISBN already exists update that book instead of failing. With dry_run, nothing is saved and the response shows what would happen.
+//	@Tags			books
+//	@Router			/books/import [post]
+//	@Security		BearerAuth
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file	formData	file											true	"CSV file"
+//	@Param			dry_run	formData	bool											false	"Validate without saving"
+//	@Param			upsert	formData	bool											false	"Update books whose ISBN already exists"
+//	@Param			mapping	formData	string											false	"JSON object mapping field names to CSV header names"
+//	@Success		200		{object}	model.DataResponse[model.BookImportResponse]	"Per-row import result"
+//	@Failure		500		{object}	types.HTTPError									"Internal server error"
+//	@Failure		403		{object}	types.HTTPError									"Forbidden"
+//	@Failure		401		{object}	types.HTTPError									"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError									"Invalid CSV file or options"
+func (b BookController) ImportBooks(c *fiber.Ctx) error {
+	request := new(model.BookImportRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return newHTTPError(c, fiber.StatusBadRequest, "CSV file is required")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return newHTTPError(c, fiber.StatusBadRequest, "Failed to read CSV file")
+	}
+	defer file.Close()
+
+	result, err := b.bookUsecase.Import(c.Context(), middleware.GetUserID(c), file, request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error importing books:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to import books")
+	}
+
+	response := model.DataResponse[model.BookImportResponse]{
+		Data: result,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// ExportBooks mengunduh katalog buku dalam format CSV, JSON Lines atau XLSX
+//
+//	@Summary		Export books
+//	@Description	Stream all books matching the optional filters as a file download. The same filters and sorting as the book list are supported. Books are read row by row from the database, so large catalogs are not loaded into memory.
+//	@Tags			books
+//	@Router			/books/export [get]
+//	@Security		BearerAuth
+//	@Produce		text/csv
+//	@Produce		application/x-ndjson
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Param			format			query		string			false	"Export format (default: csv)"	Enums(csv, jsonl, xlsx)
+//	@Param			q				query		string			false	"Free-text search over title, author and publisher"
+//	@Param			publisher		query		string			false	"Exact publisher"
+//	@Param			author			query		string			false	"Exact author"
+//	@Param			published_from	query		string			false	"Published on or after (YYYY-MM-DD)"
+//	@Param			published_to	query		string			false	"Published on or before (YYYY-MM-DD)"
+//	@Param			stock_min		query		int				false	"Minimum stock"
+//	@Param			stock_max		query		int				false	"Maximum stock"
+//	@Param			location		query		string			false	"Location ID or code, stock filters and sorting then use the stock at that location"
+//	@Param			sort			query		string			false	"Sort field (default: relevance when q is set, otherwise book_id)"	Enums(relevance, title, author, publisher, published_at, stock, created_at, updated_at)
+//	@Param			order			query		string			false	"Sort direction (default: asc, desc for relevance)"					Enums(asc, desc)
+//	@Success		200				{file}		file			"Exported catalog"
+//	@Failure		500				{object}	types.HTTPError	"Internal server error"
+//	@Failure		404				{object}	types.HTTPError	"Location not found"
+//	@Failure		403				{object}	types.HTTPError	"Forbidden"
+//	@Failure		401				{object}	types.HTTPError	"Unauthorized"
+//	@Failure		400				{object}	types.HTTPError	"Invalid query parameters"
+func (b BookController) ExportBooks(c *fiber.Ctx) error {
+	search := new(model.BookSearchRequest)
+	if err := c.QueryParser(search); err != nil {
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	format, write, err := b.bookUsecase.Export(c.Context(), c.Query("format", "csv"), search)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to export books")
+	}
+
+	filename := fmt.Sprintf("books-%s.%s", time.Now().Format("20060102-150405"), format)
+	c.Set(fiber.HeaderContentType, format.ContentType())
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	// body ditulis setelah handler selesai, jadi context request tidak bisa dipakai lagi untuk kueri
+	c.Status(fiber.StatusOK).Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := write(context.Background(), w); err != nil {
+			log.Println("Error exporting books:", eris.ToString(err, true))
+		}
+	})
+
+	return nil
+}
+
 // GetBooks mengambil daftar buku dengan pagination
 //
 //	@Summary		Get books with pagination
-//	@Description	Get a list of books with pagination support including navigation links
+//	@Description	Get a list of books with pagination support including navigation links. Supports full-text search over title, author and publisher, exact publisher/author filters, published_at and stock ranges, and sorting. Filters are kept in the navigation links. Passing the cursor parameter (empty for the first page) switches to keyset pagination ordered by book_id: the response then has has_next/has_prev instead of offset/total, and next_cursor/prev_cursor links instead of a last link.
 //	@Tags			books
 //	@Router			/books [get]
+//	@Security		BearerAuth
 //	@Accept			json
 //	@Produce		json
-//	@Param			offset	query		int											false	"Page offset (default: 0)"
-//	@Param			limit	query		int											false	"Page limit (default: 10, max: 100)"
-//	@Success		200		{object}	model.PaginatedResponse[model.BookResponse]	"Books information with pagination metadata and navigation links"
-//	@Failure		500		{object}	types.HTTPError								"Internal server error"
-//	@Failure		400		{object}	types.HTTPError								"Invalid query parameters"
+//	@Param			offset			query		int											false	"Page offset (default: 0)"
+//	@Param			limit			query		int											false	"Page limit (default: 10, max: 100)"
+//	@Param			cursor			query		string										false	"Opaque cursor from next_cursor/prev_cursor, empty for the first page"
+//	@Param			q				query		string										false	"Free-text search over title, author and publisher"
+//	@Param			publisher		query		string										false	"Exact publisher"
+//	@Param			author			query		string										false	"Exact author"
+//	@Param			published_from	query		string										false	"Published on or after (YYYY-MM-DD)"
+//	@Param			published_to	query		string										false	"Published on or before (YYYY-MM-DD)"
+//	@Param			stock_min		query		int											false	"Minimum stock"
+//	@Param			stock_max		query		int											false	"Maximum stock"
+//	@Param			location		query		string										false	"Location ID or code, stock filters and sorting then use the stock at that location"
+//	@Param			sort			query		string										false	"Sort field (default: relevance when q is set, otherwise book_id)"	Enums(relevance, title, author, publisher, published_at, stock, created_at, updated_at)
+//	@Param			order			query		string										false	"Sort direction (default: asc, desc for relevance)"					Enums(asc, desc)
+//	@Success		200				{object}	model.PaginatedResponse[model.BookResponse]	"Books information with pagination metadata and navigation links"
+//	@Failure		500				{object}	types.HTTPError								"Internal server error"
+//	@Failure		404				{object}	types.HTTPError								"Location not found"
+//	@Failure		403				{object}	types.HTTPError								"Forbidden"
+//	@Failure		401				{object}	types.HTTPError								"Unauthorized"
+//	@Failure		400				{object}	types.HTTPError								"Invalid query parameters"
 func (b BookController) GetBooks(c *fiber.Ctx) error {
-	pagination := new(model.PaginationRequest)
-	if err := c.QueryParser(pagination); err != nil {
+	pagination, fe := parsePagination(c)
+	if fe != nil {
+		return newHTTPError(c, fe.Code, fe.Message)
+	}
+
+	search := new(model.BookSearchRequest)
+	if err := c.QueryParser(search); err != nil {
 		return newHTTPError(c, fiber.StatusBadRequest, "Invalid query parameters")
 	}
 
-	if pagination.Limit <= 0 {
-		pagination.Limit = 10
+	// parameter cursor (boleh kosong untuk halaman pertama) mengaktifkan paginasi keyset
+	if c.Context().QueryArgs().Has("cursor") {
+		return b.getBooksByCursor(c, search, pagination)
 	}
-	if pagination.Offset < 0 {
-		pagination.Offset = 0
+
+	books, total, err := b.bookUsecase.GetMany(c.Context(), search, pagination.Offset, pagination.Limit)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get books")
 	}
 
-	if pagination.Limit > 100 {
-		return newHTTPError(c, fiber.StatusBadRequest, "Maximum limit is 100")
+	response := newPaginatedResponse(c.BaseURL()+c.Route().Path, queryFilters(c), books, pagination, total)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+func (b BookController) getBooksByCursor(c *fiber.Ctx, search *model.BookSearchRequest, pagination *model.PaginationRequest) error {
+	if pagination.Offset > 0 {
+		return newHTTPError(c, fiber.StatusBadRequest, "Offset cannot be combined with cursor")
 	}
 
-	books, total, err := b.bookUsecase.GetMany(c.Context(), pagination.Offset, pagination.Limit)
+	cursor := c.Query("cursor")
+	books, page, err := b.bookUsecase.GetManyByCursor(c.Context(), search, cursor, pagination.Limit)
 	if err != nil {
 		var fe *fiber.Error
 		if eris.As(err, &fe) {
@@ -183,47 +417,112 @@ func (b BookController) GetBooks(c *fiber.Ctx) error {
 		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get books")
 	}
 
-	hasNext := pagination.Offset+pagination.Limit < total
-	hasPrev := pagination.Offset > 0
+	response := newCursorPaginatedResponse(c.BaseURL()+c.Route().Path, queryFilters(c), books, pagination.Limit, cursor, page)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetBookPriceHistory mengambil riwayat harga sebuah buku dengan pagination
+//
+//	@Summary		Get price history of a book
+//	@Description	Get every cost price, sale price and currency change of a book, newest first. The current price has no effective_to.
+//	@Tags			books
+//	@Router			/books/{book_id}/prices [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			book_id	path		string													true	"Book ID"
+//	@Param			offset	query		int														false	"Page offset (default: 0)"
+//	@Param			limit	query		int														false	"Page limit (default: 10, max: 100)"
+//	@Success		200		{object}	model.PaginatedResponse[model.BookPriceHistoryResponse]	"Price history with pagination metadata and navigation links"
+//	@Failure		500		{object}	types.HTTPError											"Internal server error"
+//	@Failure		404		{object}	types.HTTPError											"Book not found"
+//	@Failure		403		{object}	types.HTTPError											"Forbidden"
+//	@Failure		401		{object}	types.HTTPError											"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError											"Invalid query parameters"
+func (b BookController) GetBookPriceHistory(c *fiber.Ctx) error {
+	bookId := c.Params("book_id")
+	if bookId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Book ID is required")
+	}
+
+	pagination, fe := parsePagination(c)
+	if fe != nil {
+		return newHTTPError(c, fe.Code, fe.Message)
+	}
+
+	history, total, err := b.bookUsecase.GetPriceHistory(c.Context(), bookId, pagination.Offset, pagination.Limit)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get price history")
+	}
 
-	baseURL := c.BaseURL() + c.Route().Path
-	response := model.PaginatedResponse[model.BookResponse]{
-		Data: books,
-		Meta: model.PaginationMeta{
-			Offset: pagination.Offset,
-			Limit:  pagination.Limit,
-			Total:  total,
-		},
-		Links: model.PaginationLinks{
-			Self:  fmt.Sprintf("%s?offset=%d&limit=%d", baseURL, pagination.Offset, pagination.Limit),
-			First: fmt.Sprintf("%s?offset=0&limit=%d", baseURL, pagination.Limit),
-			Last:  fmt.Sprintf("%s?offset=%d&limit=%d", baseURL, (total/pagination.Limit)*pagination.Limit, pagination.Limit),
-		},
+	response := newPaginatedResponse(c.BaseURL()+c.Path(), nil, history, pagination, total)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetLowStockBooks mengambil daftar buku yang stoknya sudah mencapai reorder point
+//
+//	@Summary		Get low stock books
+//	@Description	Get books whose stock is at or below their reorder point and that have a reorder quantity set, largest shortfall first. on_order counts quantities still outstanding on draft, sent and partially received purchase orders.
+//	@Tags			books
+//	@Router			/books/low-stock [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			supplier_id	query		string												false	"Only books with this preferred supplier"
+//	@Param			offset		query		int													false	"Page offset (default: 0)"
+//	@Param			limit		query		int													false	"Page limit (default: 10, max: 100)"
+//	@Success		200			{object}	model.PaginatedResponse[model.LowStockBookResponse]	"Low stock books with pagination metadata and navigation links"
+//	@Failure		500			{object}	types.HTTPError										"Internal server error"
+//	@Failure		403			{object}	types.HTTPError										"Forbidden"
+//	@Failure		401			{object}	types.HTTPError										"Unauthorized"
+//	@Failure		400			{object}	types.HTTPError										"Invalid query parameters"
+func (b BookController) GetLowStockBooks(c *fiber.Ctx) error {
+	pagination, fe := parsePagination(c)
+	if fe != nil {
+		return newHTTPError(c, fe.Code, fe.Message)
 	}
 
-	if hasNext {
-		response.Links.Next = fmt.Sprintf("%s?offset=%d&limit=%d", baseURL, pagination.Offset+pagination.Limit, pagination.Limit)
+	search := new(model.LowStockSearchRequest)
+	if err := c.QueryParser(search); err != nil {
+		log.Println("Error parsing query parameters:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid query parameters")
 	}
 
-	if hasPrev {
-		response.Links.Prev = fmt.Sprintf("%s?offset=%d&limit=%d", baseURL, max(0, pagination.Offset-pagination.Limit), pagination.Limit)
+	books, total, err := b.bookUsecase.GetLowStock(c.Context(), search, pagination.Offset, pagination.Limit)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get low stock books")
 	}
 
+	response := newPaginatedResponse(c.BaseURL()+c.Route().Path, queryFilters(c), books, pagination, total)
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
 // Update memperbarui data buku
 //
 //	@Summary		Update book
-//	@Description	Update book information partially. For stock field, use -1 as a sentinel value to indicate no update is intended.
+//	@Description	Update book information partially. For stock field, use -1 as a sentinel value to indicate no update is intended. stock sets the quantity at location_id (the default location when omitted) and the difference is recorded as an adjustment in the stock movement ledger. Reorder settings and prices that are not sent are left unchanged, a price change is recorded in the price history, set clear_preferred_supplier to remove the preferred supplier.
 //	@Tags			books
 //	@Router			/books [patch]
+//	@Security		BearerAuth
 //	@Accept			json
 //	@Produce		json
 //	@Param			payload	body		model.UpdateBookRequest					true	"Request payload"
 //	@Success		200		{object}	model.DataResponse[model.BookResponse]	"Book updated successfully"
 //	@Failure		500		{object}	types.HTTPError							"Internal server error"
-//	@Failure		404		{object}	types.HTTPError							"Book not found"
+//	@Failure		409		{object}	types.HTTPError							"Book with the same ISBN already exists, or stock below the reserved quantity"
+//	@Failure		404		{object}	types.HTTPError							"Book, location or preferred supplier not found"
+//	@Failure		403		{object}	types.HTTPError							"Forbidden"
+//	@Failure		401		{object}	types.HTTPError							"Unauthorized"
 //	@Failure		400		{object}	types.HTTPError							"Invalid request payload"
 func (b BookController) Update(c *fiber.Ctx) error {
 	request := new(model.UpdateBookRequest)
@@ -233,7 +532,7 @@ func (b BookController) Update(c *fiber.Ctx) error {
 		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
 	}
 
-	book, err := b.bookUsecase.Update(c.Context(), request)
+	book, err := b.bookUsecase.Update(c.Context(), middleware.GetUserID(c), request)
 	if err != nil {
 		log.Println("Error updating book:", eris.ToString(err, true))
 		var fe *fiber.Error
@@ -255,12 +554,16 @@ func (b BookController) Update(c *fiber.Ctx) error {
 //	@Description	Delete book by ID
 //	@Tags			books
 //	@Router			/books/{book_id} [delete]
+//	@Security		BearerAuth
 //	@Accept			json
 //	@Produce		json
 //	@Param			book_id	path		string			true	"Book ID"
 //	@Success		204		{string}	string			"Book deleted successfully"
 //	@Failure		500		{object}	types.HTTPError	"Internal server error"
+//	@Failure		409		{object}	types.HTTPError	"Book is used by purchase orders, stock transfers or reservations"
 //	@Failure		404		{object}	types.HTTPError	"Book not found"
+//	@Failure		403		{object}	types.HTTPError	"Forbidden"
+//	@Failure		401		{object}	types.HTTPError	"Unauthorized"
 //	@Failure		400		{object}	types.HTTPError	"Invalid Book ID format or Book ID is required"
 func (b BookController) Delete(c *fiber.Ctx) error {
 	bookId := c.Params("book_id")
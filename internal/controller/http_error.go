@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+)
+
+// newHTTPError creates a new HTTPError response with additional context information
+func newHTTPError(c *fiber.Ctx, status int, message string) error {
+	now := time.Now().Format(time.RFC3339)
+	return c.Status(status).JSON(types.HTTPError{
+		Code:      status,
+		Message:   message,
+		Error:     http.StatusText(status),
+		Timestamp: now,
+		Path:      c.Path(),
+	})
+}
+
+// ErrorHandler mengubah error yang tidak ditangani oleh controller, seperti error dari
+// middleware atau route yang tidak ditemukan, menjadi respons HTTPError
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	var fe *fiber.Error
+	if eris.As(err, &fe) {
+		return newHTTPError(c, fe.Code, fe.Message)
+	}
+
+	log.Println("Unhandled error:", eris.ToString(err, true))
+	return newHTTPError(c, fiber.StatusInternalServerError, "Internal server error")
+}
@@ -0,0 +1,218 @@
+package controller
+
+import (
+	"log"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+)
+
+type LocationController struct {
+	locationUsecase *usecase.LocationUsecase
+}
+
+func NewLocationController(locationUsecase *usecase.LocationUsecase) *LocationController {
+	return &LocationController{locationUsecase}
+}
+
+// Create menambahkan lokasi penyimpanan stok baru
+//
+//	@Summary		Create a new location
+//	@Description	Create a new stock location such as a store, booth or warehouse. Setting is_default moves the default location, which receives stock whenever a request does not name a location, to the new location.
+//	@Tags			locations
+//	@Router			/locations [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		model.CreateLocationRequest					true	"Request payload"
+//	@Success		201		{object}	model.DataResponse[model.LocationResponse]	"Location created successfully"
+//	@Failure		500		{object}	types.HTTPError								"Internal server error"
+//	@Failure		409		{object}	types.HTTPError								"Location with the same code already exists"
+//	@Failure		403		{object}	types.HTTPError								"Forbidden"
+//	@Failure		401		{object}	types.HTTPError								"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError								"Invalid request payload"
+func (s LocationController) Create(c *fiber.Ctx) error {
+	request := new(model.CreateLocationRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	location, err := s.locationUsecase.Create(c.Context(), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error creating location:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to create location")
+	}
+
+	response := model.DataResponse[model.LocationResponse]{
+		Data: location,
+	}
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// GetByID mengambil data lokasi berdasarkan ID
+//
+//	@Summary		Get location by ID
+//	@Description	Get location information by ID
+//	@Tags			locations
+//	@Router			/locations/{location_id} [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			location_id	path		string										true	"Location ID"
+//	@Success		200			{object}	model.DataResponse[model.LocationResponse]	"Location information retrieved successfully"
+//	@Failure		500			{object}	types.HTTPError								"Internal server error"
+//	@Failure		404			{object}	types.HTTPError								"Location not found"
+//	@Failure		403			{object}	types.HTTPError								"Forbidden"
+//	@Failure		401			{object}	types.HTTPError								"Unauthorized"
+//	@Failure		400			{object}	types.HTTPError								"Invalid Location ID format or Location ID is required"
+func (s LocationController) GetByID(c *fiber.Ctx) error {
+	locationId := c.Params("location_id")
+	if locationId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Location ID is required")
+	}
+
+	location, err := s.locationUsecase.GetById(c.Context(), locationId)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get location by ID")
+	}
+
+	response := model.DataResponse[model.LocationResponse]{
+		Data: location,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetMany mengambil daftar lokasi dengan pagination
+//
+//	@Summary		Get locations with pagination
+//	@Description	Get a list of locations, the default location first, then ordered by name, with pagination support including navigation links
+//	@Tags			locations
+//	@Router			/locations [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			offset	query		int												false	"Page offset (default: 0)"
+//	@Param			limit	query		int												false	"Page limit (default: 10, max: 100)"
+//	@Success		200		{object}	model.PaginatedResponse[model.LocationResponse]	"Locations with pagination metadata and navigation links"
+//	@Failure		500		{object}	types.HTTPError									"Internal server error"
+//	@Failure		403		{object}	types.HTTPError									"Forbidden"
+//	@Failure		401		{object}	types.HTTPError									"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError									"Invalid query parameters"
+func (s LocationController) GetMany(c *fiber.Ctx) error {
+	pagination, fe := parsePagination(c)
+	if fe != nil {
+		return newHTTPError(c, fe.Code, fe.Message)
+	}
+
+	locations, total, err := s.locationUsecase.GetMany(c.Context(), pagination.Offset, pagination.Limit)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get locations")
+	}
+
+	response := newPaginatedResponse(c.BaseURL()+c.Route().Path, nil, locations, pagination, total)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Update memperbarui sebagian data lokasi
+//
+//	@Summary		Update location
+//	@Description	Update location information partially. Fields that are not sent are left unchanged. is_default can only be set to true, which moves the default from the current default location.
+//	@Tags			locations
+//	@Router			/locations/{location_id} [patch]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			location_id	path		string										true	"Location ID"
+//	@Param			payload		body		model.UpdateLocationRequest					true	"Request payload"
+//	@Success		200			{object}	model.DataResponse[model.LocationResponse]	"Location updated successfully"
+//	@Failure		500			{object}	types.HTTPError								"Internal server error"
+//	@Failure		409			{object}	types.HTTPError								"Location with the same code already exists"
+//	@Failure		404			{object}	types.HTTPError								"Location not found"
+//	@Failure		403			{object}	types.HTTPError								"Forbidden"
+//	@Failure		401			{object}	types.HTTPError								"Unauthorized"
+//	@Failure		400			{object}	types.HTTPError								"Invalid request payload"
+func (s LocationController) Update(c *fiber.Ctx) error {
+	locationId, err := uuid.Parse(c.Params("location_id"))
+	if err != nil {
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid location ID")
+	}
+
+	request := new(model.UpdateLocationRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+	request.LocationID = locationId
+
+	location, err := s.locationUsecase.Update(c.Context(), request)
+	if err != nil {
+		log.Println("Error updating location:", eris.ToString(err, true))
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to update location")
+	}
+
+	response := model.DataResponse[model.LocationResponse]{
+		Data: location,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Delete menghapus lokasi yang sudah tidak menyimpan stok
+//
+//	@Summary		Delete location
+//	@Description	Delete location by ID. The default location and locations that still hold stock or appear on stock transfers cannot be deleted. Stock movements recorded at the location are kept without a location.
+//	@Tags			locations
+//	@Router			/locations/{location_id} [delete]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			location_id	path		string			true	"Location ID"
+//	@Success		204			{string}	string			"Location deleted successfully"
+//	@Failure		500			{object}	types.HTTPError	"Internal server error"
+//	@Failure		409			{object}	types.HTTPError	"Location is the default location, still holds stock or has stock transfers"
+//	@Failure		404			{object}	types.HTTPError	"Location not found"
+//	@Failure		403			{object}	types.HTTPError	"Forbidden"
+//	@Failure		401			{object}	types.HTTPError	"Unauthorized"
+//	@Failure		400			{object}	types.HTTPError	"Invalid Location ID format or Location ID is required"
+func (s LocationController) Delete(c *fiber.Ctx) error {
+	locationId := c.Params("location_id")
+	if locationId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Location ID is required")
+	}
+
+	err := s.locationUsecase.Delete(c.Context(), locationId)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to delete location")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/gofiber/fiber/v2"
+)
+
+// parsePagination membaca parameter offset dan limit dari query string
+// dan menerapkan nilai default jika tidak diisi
+func parsePagination(c *fiber.Ctx) (*model.PaginationRequest, *fiber.Error) {
+	pagination := new(model.PaginationRequest)
+	if err := c.QueryParser(pagination); err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	if pagination.Limit <= 0 {
+		pagination.Limit = 10
+	}
+	if pagination.Offset < 0 {
+		pagination.Offset = 0
+	}
+
+	if pagination.Limit > 100 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Maximum limit is 100")
+	}
+
+	return pagination, nil
+}
+
+// queryFilters mengambil parameter kueri selain offset, limit dan cursor agar filter tetap
+// terbawa di tautan navigasi paginasi
+func queryFilters(c *fiber.Ctx) url.Values {
+	filters := url.Values{}
+	for key, value := range c.Queries() {
+		if key == "offset" || key == "limit" || key == "cursor" || value == "" {
+			continue
+		}
+		filters.Set(key, value)
+	}
+
+	return filters
+}
+
+// newPaginatedResponse membangun PaginatedResponse beserta metadata dan tautan navigasinya.
+// filters ditambahkan ke setiap tautan, boleh bernilai nil
+func newPaginatedResponse[T any](baseURL string, filters url.Values, data []T, pagination *model.PaginationRequest, total int64) model.PaginatedResponse[T] {
+	hasNext := pagination.Offset+pagination.Limit < total
+	hasPrev := pagination.Offset > 0
+
+	link := func(offset int64) string {
+		l := fmt.Sprintf("%s?offset=%d&limit=%d", baseURL, offset, pagination.Limit)
+		if len(filters) > 0 {
+			l += "&" + filters.Encode()
+		}
+		return l
+	}
+
+	response := model.PaginatedResponse[T]{
+		Data: data,
+		Meta: model.PaginationMeta{
+			Offset: pagination.Offset,
+			Limit:  pagination.Limit,
+			Total:  total,
+		},
+		Links: model.PaginationLinks{
+			Self:  link(pagination.Offset),
+			First: link(0),
+			Last:  link((total / pagination.Limit) * pagination.Limit),
+		},
+	}
+
+	if hasNext {
+		response.Links.Next = link(pagination.Offset + pagination.Limit)
+	}
+
+	if hasPrev {
+		response.Links.Prev = link(max(0, pagination.Offset-pagination.Limit))
+	}
+
+	return response
+}
+
+// newCursorPaginatedResponse membangun CursorPaginatedResponse beserta tautan navigasi berbasis cursor
+func newCursorPaginatedResponse[T any](baseURL string, filters url.Values, data []T, limit int64, cursor string, page model.CursorPage) model.CursorPaginatedResponse[T] {
+	link := func(cursor string) string {
+		l := fmt.Sprintf("%s?cursor=%s&limit=%d", baseURL, url.QueryEscape(cursor), limit)
+		if len(filters) > 0 {
+			l += "&" + filters.Encode()
+		}
+		return l
+	}
+
+	response := model.CursorPaginatedResponse[T]{
+		Data: data,
+		Meta: model.CursorPaginationMeta{
+			Limit:   limit,
+			HasNext: page.Next != "",
+			HasPrev: page.Prev != "",
+		},
+		Links: model.PaginationLinks{
+			Self:       link(cursor),
+			First:      link(""),
+			NextCursor: page.Next,
+			PrevCursor: page.Prev,
+		},
+	}
+
+	if page.Next != "" {
+		response.Links.Next = link(page.Next)
+	}
+
+	if page.Prev != "" {
+		response.Links.Prev = link(page.Prev)
+	}
+
+	return response
+}
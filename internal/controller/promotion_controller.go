@@ -0,0 +1,210 @@
+package controller
+
+import (
+	"log"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/middleware"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+)
+
+type PromotionController struct {
+	promotionUsecase *usecase.PromotionUsecase
+}
+
+func NewPromotionController(promotionUsecase *usecase.PromotionUsecase) *PromotionController {
+	return &PromotionController{promotionUsecase}
+}
+
+// Create menambahkan promosi baru
+//
+//	@Summary		Create a new promotion
+//	@Description	Create a promotion with a rule type (percent_off, buy_x_get_y or fixed_price_bundle), a scope (all books, a publisher, an author or a list of books), a validity window and priority. Promotions are applied from the highest priority; a non-stackable promotion only applies to lines without a discount and blocks other promotions on the lines it discounts.
+//	@Tags			promotions
+//	@Router			/promotions [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		model.PromotionRequest						true	"Request payload"
+//	@Success		201		{object}	model.DataResponse[model.PromotionResponse]	"Promotion created successfully"
+//	@Failure		500		{object}	types.HTTPError								"Internal server error"
+//	@Failure		404		{object}	types.HTTPError								"Book not found"
+//	@Failure		403		{object}	types.HTTPError								"Forbidden"
+//	@Failure		401		{object}	types.HTTPError								"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError								"Invalid request payload"
+func (p PromotionController) Create(c *fiber.Ctx) error {
+	request := new(model.PromotionRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	promotion, err := p.promotionUsecase.Create(c.Context(), middleware.GetUserID(c), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error creating promotion:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to create promotion")
+	}
+
+	response := model.DataResponse[model.PromotionResponse]{
+		Data: promotion,
+	}
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// GetByID mengambil data promosi beserta daftar bukunya berdasarkan ID
+//
+//	@Summary		Get promotion by ID
+//	@Description	Get a promotion and its book list by ID
+//	@Tags			promotions
+//	@Router			/promotions/{promotion_id} [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			promotion_id	path		string										true	"Promotion ID"
+//	@Success		200				{object}	model.DataResponse[model.PromotionResponse]	"Promotion information retrieved successfully"
+//	@Failure		500				{object}	types.HTTPError								"Internal server error"
+//	@Failure		404				{object}	types.HTTPError								"Promotion not found"
+//	@Failure		403				{object}	types.HTTPError								"Forbidden"
+//	@Failure		401				{object}	types.HTTPError								"Unauthorized"
+//	@Failure		400				{object}	types.HTTPError								"Invalid Promotion ID format or Promotion ID is required"
+func (p PromotionController) GetByID(c *fiber.Ctx) error {
+	promotionId := c.Params("promotion_id")
+	if promotionId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Promotion ID is required")
+	}
+
+	promotion, err := p.promotionUsecase.GetById(c.Context(), promotionId)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get promotion by ID")
+	}
+
+	response := model.DataResponse[model.PromotionResponse]{
+		Data: promotion,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetMany mengambil daftar promosi dengan pagination
+//
+//	@Summary		Get promotions with pagination
+//	@Description	Get a list of promotions ordered by priority with pagination support including navigation links
+//	@Tags			promotions
+//	@Router			/promotions [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			offset	query		int													false	"Page offset (default: 0)"
+//	@Param			limit	query		int													false	"Page limit (default: 10, max: 100)"
+//	@Success		200		{object}	model.PaginatedResponse[model.PromotionResponse]	"Promotions with pagination metadata and navigation links"
+//	@Failure		500		{object}	types.HTTPError										"Internal server error"
+//	@Failure		403		{object}	types.HTTPError										"Forbidden"
+//	@Failure		401		{object}	types.HTTPError										"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError										"Invalid query parameters"
+func (p PromotionController) GetMany(c *fiber.Ctx) error {
+	pagination, fe := parsePagination(c)
+	if fe != nil {
+		return newHTTPError(c, fe.Code, fe.Message)
+	}
+
+	promotions, total, err := p.promotionUsecase.GetMany(c.Context(), pagination.Offset, pagination.Limit)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get promotions")
+	}
+
+	response := newPaginatedResponse(c.BaseURL()+c.Route().Path, nil, promotions, pagination, total)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Update mengganti seluruh pengaturan promosi
+//
+//	@Summary		Update promotion
+//	@Description	Replace the rule, scope, book list and validity window of a promotion. Recorded sales keep their discounts.
+//	@Tags			promotions
+//	@Router			/promotions/{promotion_id} [put]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			promotion_id	path		string										true	"Promotion ID"
+//	@Param			payload			body		model.PromotionRequest						true	"Request payload"
+//	@Success		200				{object}	model.DataResponse[model.PromotionResponse]	"Promotion updated successfully"
+//	@Failure		500				{object}	types.HTTPError								"Internal server error"
+//	@Failure		404				{object}	types.HTTPError								"Promotion or book not found"
+//	@Failure		403				{object}	types.HTTPError								"Forbidden"
+//	@Failure		401				{object}	types.HTTPError								"Unauthorized"
+//	@Failure		400				{object}	types.HTTPError								"Invalid request payload"
+func (p PromotionController) Update(c *fiber.Ctx) error {
+	request := new(model.PromotionRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	promotion, err := p.promotionUsecase.Update(c.Context(), c.Params("promotion_id"), request)
+	if err != nil {
+		log.Println("Error updating promotion:", eris.ToString(err, true))
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to update promotion")
+	}
+
+	response := model.DataResponse[model.PromotionResponse]{
+		Data: promotion,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Delete menghapus promosi. Diskon pada penjualan yang sudah tercatat tidak berubah
+//
+//	@Summary		Delete promotion
+//	@Description	Delete promotion by ID. Recorded sales keep the promotion name and discount.
+//	@Tags			promotions
+//	@Router			/promotions/{promotion_id} [delete]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			promotion_id	path		string			true	"Promotion ID"
+//	@Success		204				{string}	string			"Promotion deleted successfully"
+//	@Failure		500				{object}	types.HTTPError	"Internal server error"
+//	@Failure		404				{object}	types.HTTPError	"Promotion not found"
+//	@Failure		403				{object}	types.HTTPError	"Forbidden"
+//	@Failure		401				{object}	types.HTTPError	"Unauthorized"
+//	@Failure		400				{object}	types.HTTPError	"Invalid Promotion ID format or Promotion ID is required"
+func (p PromotionController) Delete(c *fiber.Ctx) error {
+	promotionId := c.Params("promotion_id")
+	if promotionId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Promotion ID is required")
+	}
+
+	err := p.promotionUsecase.Delete(c.Context(), promotionId)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to delete promotion")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,303 @@
+package controller
+
+import (
+	"log"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/middleware"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+)
+
+type PurchaseOrderController struct {
+	orderUsecase *usecase.PurchaseOrderUsecase
+}
+
+func NewPurchaseOrderController(orderUsecase *usecase.PurchaseOrderUsecase) *PurchaseOrderController {
+	return &PurchaseOrderController{orderUsecase}
+}
+
+// Create membuat purchase order baru berstatus draft
+//
+//	@Summary		Create a new purchase order
+//	@Description	Create a draft purchase order for a supplier. Each line item references a book and can only appear once.
+//	@Tags			purchase-orders
+//	@Router			/purchase-orders [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		model.PurchaseOrderRequest						true	"Request payload"
+//	@Success		201		{object}	model.DataResponse[model.PurchaseOrderResponse]	"Purchase order created successfully"
+//	@Failure		500		{object}	types.HTTPError									"Internal server error"
+//	@Failure		404		{object}	types.HTTPError									"Supplier or book not found"
+//	@Failure		403		{object}	types.HTTPError									"Forbidden"
+//	@Failure		401		{object}	types.HTTPError									"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError									"Invalid request payload"
+func (p PurchaseOrderController) Create(c *fiber.Ctx) error {
+	request := new(model.PurchaseOrderRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	order, err := p.orderUsecase.Create(c.Context(), middleware.GetUserID(c), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error creating purchase order:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to create purchase order")
+	}
+
+	response := model.DataResponse[model.PurchaseOrderResponse]{
+		Data: order,
+	}
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// Reorder membuat purchase order draft dari daftar buku dengan stok menipis
+//
+//	@Summary		Generate purchase orders from low stock books
+//	@Description	Create one draft purchase order per preferred supplier for books at or below their reorder point. Books without a preferred supplier, or whose outstanding orders already cover the shortfall, are skipped. Unit costs are taken from the last order to the same supplier, falling back to the book cost price, and the expected date from the supplier lead time.
+//	@Tags			purchase-orders
+//	@Router			/purchase-orders/reorder [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		model.ReorderRequest						false	"Request payload"
+//	@Success		201		{object}	model.DataResponse[model.ReorderResponse]	"Draft purchase orders created"
+//	@Failure		500		{object}	types.HTTPError								"Internal server error"
+//	@Failure		403		{object}	types.HTTPError								"Forbidden"
+//	@Failure		401		{object}	types.HTTPError								"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError								"Invalid request payload"
+func (p PurchaseOrderController) Reorder(c *fiber.Ctx) error {
+	request := new(model.ReorderRequest)
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(request); err != nil {
+			log.Println("Error parsing request body:", err)
+			return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+		}
+	}
+
+	reorder, err := p.orderUsecase.Reorder(c.Context(), middleware.GetUserID(c), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error generating purchase orders:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to generate purchase orders")
+	}
+
+	response := model.DataResponse[model.ReorderResponse]{
+		Data: reorder,
+	}
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// GetByID mengambil purchase order beserta item-itemnya berdasarkan ID
+//
+//	@Summary		Get purchase order by ID
+//	@Description	Get a purchase order and its line items by ID
+//	@Tags			purchase-orders
+//	@Router			/purchase-orders/{purchase_order_id} [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			purchase_order_id	path		string											true	"Purchase order ID"
+//	@Success		200					{object}	model.DataResponse[model.PurchaseOrderResponse]	"Purchase order retrieved successfully"
+//	@Failure		500					{object}	types.HTTPError									"Internal server error"
+//	@Failure		404					{object}	types.HTTPError									"Purchase order not found"
+//	@Failure		403					{object}	types.HTTPError									"Forbidden"
+//	@Failure		401					{object}	types.HTTPError									"Unauthorized"
+//	@Failure		400					{object}	types.HTTPError									"Invalid Purchase order ID format or Purchase order ID is required"
+func (p PurchaseOrderController) GetByID(c *fiber.Ctx) error {
+	orderId := c.Params("purchase_order_id")
+	if orderId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Purchase order ID is required")
+	}
+
+	order, err := p.orderUsecase.GetById(c.Context(), orderId)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get purchase order by ID")
+	}
+
+	response := model.DataResponse[model.PurchaseOrderResponse]{
+		Data: order,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetMany mengambil daftar purchase order dengan filter status dan supplier
+//
+//	@Summary		Get purchase orders with pagination
+//	@Description	Get a list of purchase orders, newest first, optionally filtered by status and supplier. Line items are not included.
+//	@Tags			purchase-orders
+//	@Router			/purchase-orders [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			search	query		model.PurchaseOrderSearchRequest						false	"Filters"
+//	@Param			offset	query		int														false	"Page offset (default: 0)"
+//	@Param			limit	query		int														false	"Page limit (default: 10, max: 100)"
+//	@Success		200		{object}	model.PaginatedResponse[model.PurchaseOrderResponse]	"Purchase orders with pagination metadata and navigation links"
+//	@Failure		500		{object}	types.HTTPError											"Internal server error"
+//	@Failure		403		{object}	types.HTTPError											"Forbidden"
+//	@Failure		401		{object}	types.HTTPError											"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError											"Invalid query parameters"
+func (p PurchaseOrderController) GetMany(c *fiber.Ctx) error {
+	pagination, fe := parsePagination(c)
+	if fe != nil {
+		return newHTTPError(c, fe.Code, fe.Message)
+	}
+
+	search := new(model.PurchaseOrderSearchRequest)
+	if err := c.QueryParser(search); err != nil {
+		log.Println("Error parsing query parameters:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	orders, total, err := p.orderUsecase.GetMany(c.Context(), search, pagination.Offset, pagination.Limit)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get purchase orders")
+	}
+
+	response := newPaginatedResponse(c.BaseURL()+c.Route().Path, queryFilters(c), orders, pagination, total)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Update mengganti isi purchase order yang masih berstatus draft
+//
+//	@Summary		Update purchase order
+//	@Description	Replace the supplier, expected date, notes and line items of a draft purchase order
+//	@Tags			purchase-orders
+//	@Router			/purchase-orders/{purchase_order_id} [put]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			purchase_order_id	path		string											true	"Purchase order ID"
+//	@Param			payload				body		model.PurchaseOrderRequest						true	"Request payload"
+//	@Success		200					{object}	model.DataResponse[model.PurchaseOrderResponse]	"Purchase order updated successfully"
+//	@Failure		500					{object}	types.HTTPError									"Internal server error"
+//	@Failure		409					{object}	types.HTTPError									"Purchase order is no longer a draft"
+//	@Failure		404					{object}	types.HTTPError									"Purchase order, supplier or book not found"
+//	@Failure		403					{object}	types.HTTPError									"Forbidden"
+//	@Failure		401					{object}	types.HTTPError									"Unauthorized"
+//	@Failure		400					{object}	types.HTTPError									"Invalid request payload"
+func (p PurchaseOrderController) Update(c *fiber.Ctx) error {
+	request := new(model.PurchaseOrderRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	order, err := p.orderUsecase.Update(c.Context(), c.Params("purchase_order_id"), request)
+	if err != nil {
+		log.Println("Error updating purchase order:", eris.ToString(err, true))
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to update purchase order")
+	}
+
+	response := model.DataResponse[model.PurchaseOrderResponse]{
+		Data: order,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// UpdateStatus mengirim atau membatalkan purchase order
+//
+//	@Summary		Update purchase order status
+//	@Description	Mark a draft purchase order as sent, or cancel a draft or sent purchase order. Received statuses are set by goods receipts.
+//	@Tags			purchase-orders
+//	@Router			/purchase-orders/{purchase_order_id}/status [patch]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			purchase_order_id	path		string											true	"Purchase order ID"
+//	@Param			payload				body		model.UpdatePurchaseOrderStatusRequest			true	"Request payload"
+//	@Success		200					{object}	model.DataResponse[model.PurchaseOrderResponse]	"Purchase order status updated successfully"
+//	@Failure		500					{object}	types.HTTPError									"Internal server error"
+//	@Failure		409					{object}	types.HTTPError									"Status change is not allowed"
+//	@Failure		404					{object}	types.HTTPError									"Purchase order not found"
+//	@Failure		403					{object}	types.HTTPError									"Forbidden"
+//	@Failure		401					{object}	types.HTTPError									"Unauthorized"
+//	@Failure		400					{object}	types.HTTPError									"Invalid request payload"
+func (p PurchaseOrderController) UpdateStatus(c *fiber.Ctx) error {
+	request := new(model.UpdatePurchaseOrderStatusRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	order, err := p.orderUsecase.UpdateStatus(c.Context(), c.Params("purchase_order_id"), request)
+	if err != nil {
+		log.Println("Error updating purchase order status:", eris.ToString(err, true))
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to update purchase order status")
+	}
+
+	response := model.DataResponse[model.PurchaseOrderResponse]{
+		Data: order,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Delete menghapus purchase order yang masih berstatus draft
+//
+//	@Summary		Delete purchase order
+//	@Description	Delete a draft purchase order and its line items
+//	@Tags			purchase-orders
+//	@Router			/purchase-orders/{purchase_order_id} [delete]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			purchase_order_id	path		string			true	"Purchase order ID"
+//	@Success		204					{string}	string			"Purchase order deleted successfully"
+//	@Failure		500					{object}	types.HTTPError	"Internal server error"
+//	@Failure		409					{object}	types.HTTPError	"Purchase order is no longer a draft"
+//	@Failure		404					{object}	types.HTTPError	"Purchase order not found"
+//	@Failure		403					{object}	types.HTTPError	"Forbidden"
+//	@Failure		401					{object}	types.HTTPError	"Unauthorized"
+//	@Failure		400					{object}	types.HTTPError	"Invalid Purchase order ID format or Purchase order ID is required"
+func (p PurchaseOrderController) Delete(c *fiber.Ctx) error {
+	orderId := c.Params("purchase_order_id")
+	if orderId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Purchase order ID is required")
+	}
+
+	err := p.orderUsecase.Delete(c.Context(), orderId)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to delete purchase order")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
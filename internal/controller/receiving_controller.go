@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"log"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/middleware"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+)
+
+type ReceivingController struct {
+	receivingUsecase *usecase.ReceivingUsecase
+}
+
+func NewReceivingController(receivingUsecase *usecase.ReceivingUsecase) *ReceivingController {
+	return &ReceivingController{receivingUsecase}
+}
+
+// Receive mencatat barang yang diterima dari supplier untuk sebuah purchase order
+//
+//	@Summary		Receive goods against a purchase order
+//	@Description	Record a delivery for a sent or partially received purchase order. Each item is a scanned code (book ID, book QR code URL or ISBN) with an optional quantity (default 1). Stock is increased with receipt movements referencing the purchase order, received quantities are updated, and the purchase order moves to partially_received or received in the same database transaction. Over and short deliveries are reported per line item. Received copies are then allocated to waiting backorders and pre-orders at the location, oldest first, and each allocation is returned with its reservation.
+//	@Tags			purchase-orders
+//	@Router			/purchase-orders/{purchase_order_id}/receipts [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			purchase_order_id	path		string													true	"Purchase order ID"
+//	@Param			payload				body		model.ReceivePurchaseOrderRequest						true	"Request payload"
+//	@Success		200					{object}	model.DataResponse[model.ReceivePurchaseOrderResponse]	"Goods received successfully"
+//	@Failure		500					{object}	types.HTTPError											"Internal server error"
+//	@Failure		409					{object}	types.HTTPError											"Purchase order cannot receive goods or book is not on the purchase order"
+//	@Failure		404					{object}	types.HTTPError											"Purchase order or book not found"
+//	@Failure		403					{object}	types.HTTPError											"Forbidden"
+//	@Failure		401					{object}	types.HTTPError											"Unauthorized"
+//	@Failure		400					{object}	types.HTTPError											"Invalid request payload"
+func (r ReceivingController) Receive(c *fiber.Ctx) error {
+	request := new(model.ReceivePurchaseOrderRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	receipt, err := r.receivingUsecase.Receive(c.Context(), middleware.GetUserID(c), c.Params("purchase_order_id"), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error receiving purchase order:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to receive purchase order")
+	}
+
+	response := model.DataResponse[model.ReceivePurchaseOrderResponse]{
+		Data: receipt,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
@@ -0,0 +1,219 @@
+package controller
+
+import (
+	"log"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/middleware"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+)
+
+type ReservationController struct {
+	reservationUsecase *usecase.ReservationUsecase
+}
+
+func NewReservationController(reservationUsecase *usecase.ReservationUsecase) *ReservationController {
+	return &ReservationController{reservationUsecase}
+}
+
+// Create menahan stok buku untuk pelanggan
+//
+//	@Summary		Create a reservation
+//	@Description	Hold books for a customer at a location, or at the default location when location_id is omitted. Held copies stay in on_hand but are no longer available, so they cannot be sold to someone else or sent to another location. The hold is released automatically at expires_at, which defaults to the configured reservation TTL, and released copies are offered to waiting backorders first.
+//	@Tags			reservations
+//	@Router			/reservations [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		model.CreateReservationRequest					true	"Request payload"
+//	@Success		201		{object}	model.DataResponse[model.ReservationResponse]	"Reservation created successfully"
+//	@Failure		500		{object}	types.HTTPError									"Internal server error"
+//	@Failure		409		{object}	types.HTTPError									"Insufficient available stock"
+//	@Failure		404		{object}	types.HTTPError									"Location or book not found"
+//	@Failure		403		{object}	types.HTTPError									"Forbidden"
+//	@Failure		401		{object}	types.HTTPError									"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError									"Invalid request payload"
+func (r ReservationController) Create(c *fiber.Ctx) error {
+	request := new(model.CreateReservationRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	reservation, err := r.reservationUsecase.Create(c.Context(), middleware.GetUserID(c), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error creating reservation:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to create reservation")
+	}
+
+	response := model.DataResponse[model.ReservationResponse]{
+		Data: reservation,
+	}
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// GetByID mengambil reservasi beserta item-itemnya berdasarkan ID
+//
+//	@Summary		Get reservation by ID
+//	@Description	Get a reservation and its line items by ID
+//	@Tags			reservations
+//	@Router			/reservations/{reservation_id} [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			reservation_id	path		string											true	"Reservation ID"
+//	@Success		200				{object}	model.DataResponse[model.ReservationResponse]	"Reservation retrieved successfully"
+//	@Failure		500				{object}	types.HTTPError									"Internal server error"
+//	@Failure		404				{object}	types.HTTPError									"Reservation not found"
+//	@Failure		403				{object}	types.HTTPError									"Forbidden"
+//	@Failure		401				{object}	types.HTTPError									"Unauthorized"
+//	@Failure		400				{object}	types.HTTPError									"Invalid Reservation ID format or Reservation ID is required"
+func (r ReservationController) GetByID(c *fiber.Ctx) error {
+	reservationId := c.Params("reservation_id")
+	if reservationId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Reservation ID is required")
+	}
+
+	reservation, err := r.reservationUsecase.GetById(c.Context(), reservationId)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get reservation by ID")
+	}
+
+	response := model.DataResponse[model.ReservationResponse]{
+		Data: reservation,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetMany mengambil daftar reservasi dengan filter status, lokasi dan buku
+//
+//	@Summary		Get reservations with pagination
+//	@Description	Get a list of reservations, newest first, optionally filtered by status, location and a book held by the reservation. Line items are not included.
+//	@Tags			reservations
+//	@Router			/reservations [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			search	query		model.ReservationSearchRequest						false	"Filters"
+//	@Param			offset	query		int													false	"Page offset (default: 0)"
+//	@Param			limit	query		int													false	"Page limit (default: 10, max: 100)"
+//	@Success		200		{object}	model.PaginatedResponse[model.ReservationResponse]	"Reservations with pagination metadata and navigation links"
+//	@Failure		500		{object}	types.HTTPError										"Internal server error"
+//	@Failure		403		{object}	types.HTTPError										"Forbidden"
+//	@Failure		401		{object}	types.HTTPError										"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError										"Invalid query parameters"
+func (r ReservationController) GetMany(c *fiber.Ctx) error {
+	pagination, fe := parsePagination(c)
+	if fe != nil {
+		return newHTTPError(c, fe.Code, fe.Message)
+	}
+
+	search := new(model.ReservationSearchRequest)
+	if err := c.QueryParser(search); err != nil {
+		log.Println("Error parsing query parameters:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	reservations, total, err := r.reservationUsecase.GetMany(c.Context(), search, pagination.Offset, pagination.Limit)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get reservations")
+	}
+
+	response := newPaginatedResponse(c.BaseURL()+c.Route().Path, queryFilters(c), reservations, pagination, total)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Cancel membatalkan reservasi dan melepas stok yang ditahan
+//
+//	@Summary		Cancel a reservation
+//	@Description	Cancel an active reservation and release the held copies to waiting backorders first, then to sale. A backorder allocated to the reservation is cancelled as well.
+//	@Tags			reservations
+//	@Router			/reservations/{reservation_id}/cancel [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			reservation_id	path		string											true	"Reservation ID"
+//	@Success		200				{object}	model.DataResponse[model.ReservationResponse]	"Reservation cancelled successfully"
+//	@Failure		500				{object}	types.HTTPError									"Internal server error"
+//	@Failure		409				{object}	types.HTTPError									"Reservation is not active"
+//	@Failure		404				{object}	types.HTTPError									"Reservation not found"
+//	@Failure		403				{object}	types.HTTPError									"Forbidden"
+//	@Failure		401				{object}	types.HTTPError									"Unauthorized"
+//	@Failure		400				{object}	types.HTTPError									"Invalid Reservation ID"
+func (r ReservationController) Cancel(c *fiber.Ctx) error {
+	reservation, err := r.reservationUsecase.Cancel(c.Context(), c.Params("reservation_id"))
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to cancel reservation")
+	}
+
+	response := model.DataResponse[model.ReservationResponse]{
+		Data: reservation,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// CreateSale mengubah reservasi menjadi penjualan
+//
+//	@Summary		Sell a reservation
+//	@Description	Record the sale of every book held by an active reservation at the reservation location. The hold is released and the stock is decremented in the same database transaction, and the reservation moves to fulfilled with a reference to the sale. Prices use the current sale price and active promotions.
+//	@Tags			reservations
+//	@Router			/reservations/{reservation_id}/sale [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			reservation_id	path		string												true	"Reservation ID"
+//	@Param			payload			body		model.ReservationSaleRequest						true	"Request payload"
+//	@Success		201				{object}	model.DataResponse[model.ReservationSaleResponse]	"Reservation sold successfully"
+//	@Failure		500				{object}	types.HTTPError										"Internal server error"
+//	@Failure		409				{object}	types.HTTPError										"Reservation is not active or has expired, or insufficient stock"
+//	@Failure		404				{object}	types.HTTPError										"Reservation not found"
+//	@Failure		403				{object}	types.HTTPError										"Forbidden"
+//	@Failure		401				{object}	types.HTTPError										"Unauthorized"
+//	@Failure		400				{object}	types.HTTPError										"Invalid Reservation ID or request payload"
+func (r ReservationController) CreateSale(c *fiber.Ctx) error {
+	request := new(model.ReservationSaleRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	result, err := r.reservationUsecase.CreateSale(c.Context(), middleware.GetUserID(c), c.Params("reservation_id"), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error selling reservation:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to sell reservation")
+	}
+
+	response := model.DataResponse[model.ReservationSaleResponse]{
+		Data: result,
+	}
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
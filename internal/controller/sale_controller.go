@@ -0,0 +1,223 @@
+package controller
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/middleware"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+)
+
+type SaleController struct {
+	saleUsecase *usecase.SaleUsecase
+}
+
+func NewSaleController(saleUsecase *usecase.SaleUsecase) *SaleController {
+	return &SaleController{saleUsecase}
+}
+
+// SaleCreate mencatat transaksi penjualan baru
+//
+//	@Summary		Create a new sale
+//	@Description	Record a point-of-sale transaction. Stock of every line item is decremented in the same database transaction. Line items are always charged at the book sale price. Active promotions are applied automatically and listed in the response.
+//	@Tags			sales
+//	@Router			/sales [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		model.CreateSaleRequest					true	"Request payload"
+//	@Success		201		{object}	model.DataResponse[model.SaleResponse]	"Sale created successfully"
+//	@Failure		500		{object}	types.HTTPError							"Internal server error"
+//	@Failure		409		{object}	types.HTTPError							"Insufficient stock or book has no sale price"
+//	@Failure		404		{object}	types.HTTPError							"Book not found"
+//	@Failure		403		{object}	types.HTTPError							"Forbidden"
+//	@Failure		401		{object}	types.HTTPError							"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError							"Invalid request payload"
+func (s SaleController) SaleCreate(c *fiber.Ctx) error {
+	request := new(model.CreateSaleRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	sale, err := s.saleUsecase.Create(c.Context(), middleware.GetUserID(c), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error creating sale:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to create sale")
+	}
+
+	response := model.DataResponse[model.SaleResponse]{
+		Data: sale,
+	}
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// SalePreview menghitung harga keranjang beserta diskon promosi tanpa menyimpan penjualan
+//
+//	@Summary		Preview a sale price
+//	@Description	Price a cart with the currently active promotions without recording a sale or changing stock. The result matches what creating the sale would charge at the same moment.
+//	@Tags			sales
+//	@Router			/sales/preview [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		model.PreviewSaleRequest						true	"Request payload"
+//	@Success		200		{object}	model.DataResponse[model.SalePreviewResponse]	"Cart priced successfully"
+//	@Failure		500		{object}	types.HTTPError									"Internal server error"
+//	@Failure		409		{object}	types.HTTPError									"Book has no sale price"
+//	@Failure		404		{object}	types.HTTPError									"Book not found"
+//	@Failure		403		{object}	types.HTTPError									"Forbidden"
+//	@Failure		401		{object}	types.HTTPError									"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError									"Invalid request payload"
+func (s SaleController) SalePreview(c *fiber.Ctx) error {
+	request := new(model.PreviewSaleRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	preview, err := s.saleUsecase.Preview(c.Context(), request)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to preview sale")
+	}
+
+	response := model.DataResponse[model.SalePreviewResponse]{
+		Data: preview,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetSaleByID mengambil data penjualan beserta item-itemnya berdasarkan ID
+//
+//	@Summary		Get sale by ID
+//	@Description	Get a sale and its line items by ID
+//	@Tags			sales
+//	@Router			/sales/{sale_id} [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			sale_id	path		string									true	"Sale ID"
+//	@Success		200		{object}	model.DataResponse[model.SaleResponse]	"Sale information retrieved successfully"
+//	@Failure		500		{object}	types.HTTPError							"Internal server error"
+//	@Failure		404		{object}	types.HTTPError							"Sale not found"
+//	@Failure		403		{object}	types.HTTPError							"Forbidden"
+//	@Failure		401		{object}	types.HTTPError							"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError							"Invalid Sale ID format or Sale ID is required"
+func (s SaleController) GetSaleByID(c *fiber.Ctx) error {
+	saleId := c.Params("sale_id")
+	if saleId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Sale ID is required")
+	}
+
+	sale, err := s.saleUsecase.GetById(c.Context(), saleId)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get sale by ID")
+	}
+
+	response := model.DataResponse[model.SaleResponse]{
+		Data: sale,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetSaleReceipt membuat struk penjualan untuk dicetak
+//
+//	@Summary		Get sale receipt
+//	@Description	Render a printable receipt with the store header, line items, discounts, included tax, totals, payment method and a QR code linking to the sale. Use pdf for regular printers or sharing, escpos for raw ESC/POS bytes sent directly to a 58mm or 80mm thermal printer, and txt for plain text.
+//	@Tags			sales
+//	@Router			/sales/{sale_id}/receipt [get]
+//	@Security		BearerAuth
+//	@Produce		application/pdf
+//	@Produce		application/octet-stream
+//	@Produce		plain
+//	@Param			sale_id	path		string			true	"Sale ID"
+//	@Param			format	query		string			false	"Receipt format"				Enums(pdf, escpos, txt)	default(pdf)
+//	@Param			paper	query		int				false	"Paper width in millimeters"	Enums(58, 80)			default(58)
+//	@Success		200		{file}		file			"Sale receipt"
+//	@Failure		500		{object}	types.HTTPError	"Internal server error"
+//	@Failure		404		{object}	types.HTTPError	"Sale not found"
+//	@Failure		403		{object}	types.HTTPError	"Forbidden"
+//	@Failure		401		{object}	types.HTTPError	"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError	"Invalid Sale ID or query parameters"
+func (s SaleController) GetSaleReceipt(c *fiber.Ctx) error {
+	saleId := c.Params("sale_id")
+	if saleId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Sale ID is required")
+	}
+
+	request := new(model.SaleReceiptRequest)
+	if err := c.QueryParser(request); err != nil {
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	content, format, err := s.saleUsecase.GetReceipt(c.Context(), saleId, request, c.BaseURL())
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error generating receipt:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to generate receipt")
+	}
+
+	c.Set(fiber.HeaderContentType, format.ContentType())
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="receipt-%s.%s"`, saleId, format.Extension()))
+	return c.Status(fiber.StatusOK).Send(content)
+}
+
+// GetSales mengambil daftar penjualan dengan pagination
+//
+//	@Summary		Get sales with pagination
+//	@Description	Get a list of sales, newest first, with pagination support including navigation links
+//	@Tags			sales
+//	@Router			/sales [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			offset	query		int											false	"Page offset (default: 0)"
+//	@Param			limit	query		int											false	"Page limit (default: 10, max: 100)"
+//	@Success		200		{object}	model.PaginatedResponse[model.SaleResponse]	"Sales with pagination metadata and navigation links"
+//	@Failure		500		{object}	types.HTTPError								"Internal server error"
+//	@Failure		403		{object}	types.HTTPError								"Forbidden"
+//	@Failure		401		{object}	types.HTTPError								"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError								"Invalid query parameters"
+func (s SaleController) GetSales(c *fiber.Ctx) error {
+	pagination, fe := parsePagination(c)
+	if fe != nil {
+		return newHTTPError(c, fe.Code, fe.Message)
+	}
+
+	sales, total, err := s.saleUsecase.GetMany(c.Context(), pagination.Offset, pagination.Limit)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get sales")
+	}
+
+	response := newPaginatedResponse(c.BaseURL()+c.Route().Path, nil, sales, pagination, total)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"log"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/middleware"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+)
+
+type SaleReturnController struct {
+	returnUsecase *usecase.SaleReturnUsecase
+}
+
+func NewSaleReturnController(returnUsecase *usecase.SaleReturnUsecase) *SaleReturnController {
+	return &SaleReturnController{returnUsecase}
+}
+
+// Create mencatat retur barang dari sebuah penjualan
+//
+//	@Summary		Return items from a sale
+//	@Description	Record a return of line items from a sale. Returned quantities, including earlier returns, cannot exceed the sold quantity. The refund is taken from the discounted line total. Resellable items are put back into stock with return movements; damaged items are recorded as returned and written off with damage movements in the same database transaction. Resellable copies are allocated to waiting backorders and pre-orders at the location first and returned in allocations.
+//	@Tags			sales
+//	@Router			/sales/{sale_id}/returns [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			sale_id	path		string											true	"Sale ID"
+//	@Param			payload	body		model.CreateSaleReturnRequest					true	"Request payload"
+//	@Success		201		{object}	model.DataResponse[model.SaleReturnResponse]	"Sale return recorded successfully"
+//	@Failure		500		{object}	types.HTTPError									"Internal server error"
+//	@Failure		409		{object}	types.HTTPError									"Returned quantity exceeds sold quantity"
+//	@Failure		404		{object}	types.HTTPError									"Sale or sale item not found"
+//	@Failure		403		{object}	types.HTTPError									"Forbidden"
+//	@Failure		401		{object}	types.HTTPError									"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError									"Invalid request payload"
+func (s SaleReturnController) Create(c *fiber.Ctx) error {
+	request := new(model.CreateSaleReturnRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	saleReturn, err := s.returnUsecase.Create(c.Context(), middleware.GetUserID(c), c.Params("sale_id"), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error creating sale return:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to create sale return")
+	}
+
+	response := model.DataResponse[model.SaleReturnResponse]{
+		Data: saleReturn,
+	}
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// GetBySaleID mengambil semua retur dari sebuah penjualan
+//
+//	@Summary		Get returns of a sale
+//	@Description	Get all returns recorded for a sale, oldest first, with their line items and refund amounts
+//	@Tags			sales
+//	@Router			/sales/{sale_id}/returns [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			sale_id	path		string											true	"Sale ID"
+//	@Success		200		{object}	model.DataResponse[[]model.SaleReturnResponse]	"Sale returns retrieved successfully"
+//	@Failure		500		{object}	types.HTTPError									"Internal server error"
+//	@Failure		404		{object}	types.HTTPError									"Sale not found"
+//	@Failure		403		{object}	types.HTTPError									"Forbidden"
+//	@Failure		401		{object}	types.HTTPError									"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError									"Invalid sale ID"
+func (s SaleReturnController) GetBySaleID(c *fiber.Ctx) error {
+	returns, err := s.returnUsecase.GetBySaleId(c.Context(), c.Params("sale_id"))
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get sale returns")
+	}
+
+	response := model.DataResponse[[]model.SaleReturnResponse]{
+		Data: returns,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
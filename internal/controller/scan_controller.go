@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"log"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/middleware"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+)
+
+type ScanController struct {
+	scanUsecase *usecase.ScanUsecase
+}
+
+func NewScanController(scanUsecase *usecase.ScanUsecase) *ScanController {
+	return &ScanController{scanUsecase}
+}
+
+// Scan mencari buku dari hasil pindaian QR code atau barcode ISBN
+//
+//	@Summary		Resolve a scanned code
+//	@Description	Resolve a raw scanner payload (book ID, book QR code URL, ISBN-10 or ISBN-13) to a book. When action is set, a sale (sell) or receipt (receive) stock movement is recorded for the resolved book; sell requires the sales:create permission and receive requires the stock:adjust permission. Received copies are allocated to waiting backorders and pre-orders at the location first and returned in allocations.
+//	@Tags			scan
+//	@Router			/scan [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		model.ScanRequest						true	"Request payload"
+//	@Success		200		{object}	model.DataResponse[model.ScanResponse]	"Code resolved successfully"
+//	@Failure		500		{object}	types.HTTPError							"Internal server error"
+//	@Failure		409		{object}	types.HTTPError							"Insufficient stock"
+//	@Failure		404		{object}	types.HTTPError							"Book not found"
+//	@Failure		403		{object}	types.HTTPError							"Forbidden"
+//	@Failure		401		{object}	types.HTTPError							"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError							"Invalid request payload"
+func (s ScanController) Scan(c *fiber.Ctx) error {
+	request := new(model.ScanRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	result, err := s.scanUsecase.Scan(c.Context(), middleware.GetUserID(c), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error resolving scanned code:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to resolve scanned code")
+	}
+
+	response := model.DataResponse[model.ScanResponse]{
+		Data: result,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
@@ -0,0 +1,197 @@
+package controller
+
+import (
+	"log"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/middleware"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+)
+
+type StockController struct {
+	stockUsecase *usecase.StockUsecase
+}
+
+func NewStockController(stockUsecase *usecase.StockUsecase) *StockController {
+	return &StockController{stockUsecase}
+}
+
+// CreateMovement mencatat pergerakan stok baru untuk sebuah buku
+//
+//	@Summary		Record a stock movement
+//	@Description	Record a stock change (receipt, sale, return, adjustment, damage, transfer) and update the book stock in the same transaction. Quantity is a signed delta. Positive receipt and return movements are allocated to waiting backorders and pre-orders at the location first.
+//	@Tags			stock
+//	@Router			/books/{book_id}/stock/movements [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			book_id	path		string											true	"Book ID"
+//	@Param			payload	body		model.CreateStockMovementRequest				true	"Request payload"
+//	@Success		201		{object}	model.DataResponse[model.StockMovementResponse]	"Stock movement recorded successfully"
+//	@Failure		500		{object}	types.HTTPError									"Internal server error"
+//	@Failure		409		{object}	types.HTTPError									"Stock would become negative"
+//	@Failure		404		{object}	types.HTTPError									"Book not found"
+//	@Failure		403		{object}	types.HTTPError									"Forbidden"
+//	@Failure		401		{object}	types.HTTPError									"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError									"Invalid request payload"
+func (s StockController) CreateMovement(c *fiber.Ctx) error {
+	bookId := c.Params("book_id")
+	if bookId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Book ID is required")
+	}
+
+	request := new(model.CreateStockMovementRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	movement, err := s.stockUsecase.RecordMovement(c.Context(), middleware.GetUserID(c), bookId, request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error recording stock movement:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to record stock movement")
+	}
+
+	response := model.DataResponse[model.StockMovementResponse]{
+		Data: movement,
+	}
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// AdjustStock menambah atau mengurangi stok buku secara atomik
+//
+//	@Summary		Adjust book stock
+//	@Description	Atomically increment or decrement the book stock by a relative delta. The change is rejected when the stock would become negative.
+//	@Tags			stock
+//	@Router			/books/{book_id}/stock:adjust [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			book_id	path		string									true	"Book ID"
+//	@Param			payload	body		model.AdjustStockRequest				true	"Request payload"
+//	@Success		200		{object}	model.DataResponse[model.BookResponse]	"Stock adjusted successfully"
+//	@Failure		500		{object}	types.HTTPError							"Internal server error"
+//	@Failure		409		{object}	types.HTTPError							"Stock would become negative"
+//	@Failure		404		{object}	types.HTTPError							"Book not found"
+//	@Failure		403		{object}	types.HTTPError							"Forbidden"
+//	@Failure		401		{object}	types.HTTPError							"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError							"Invalid request payload"
+func (s StockController) AdjustStock(c *fiber.Ctx) error {
+	bookId := c.Params("book_id")
+	if bookId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Book ID is required")
+	}
+
+	request := new(model.AdjustStockRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	book, err := s.stockUsecase.AdjustStock(c.Context(), middleware.GetUserID(c), bookId, request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error adjusting stock:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to adjust stock")
+	}
+
+	response := model.DataResponse[model.BookResponse]{
+		Data: book,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetMovements mengambil riwayat pergerakan stok sebuah buku dengan pagination
+//
+//	@Summary		Get stock movements of a book
+//	@Description	Get the stock movement ledger of a book, newest first, with pagination support
+//	@Tags			stock
+//	@Router			/books/{book_id}/stock/movements [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			book_id	path		string													true	"Book ID"
+//	@Param			offset	query		int														false	"Page offset (default: 0)"
+//	@Param			limit	query		int														false	"Page limit (default: 10, max: 100)"
+//	@Success		200		{object}	model.PaginatedResponse[model.StockMovementResponse]	"Stock movements with pagination metadata and navigation links"
+//	@Failure		500		{object}	types.HTTPError											"Internal server error"
+//	@Failure		404		{object}	types.HTTPError											"Book not found"
+//	@Failure		403		{object}	types.HTTPError											"Forbidden"
+//	@Failure		401		{object}	types.HTTPError											"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError											"Invalid query parameters"
+func (s StockController) GetMovements(c *fiber.Ctx) error {
+	bookId := c.Params("book_id")
+	if bookId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Book ID is required")
+	}
+
+	pagination, fe := parsePagination(c)
+	if fe != nil {
+		return newHTTPError(c, fe.Code, fe.Message)
+	}
+
+	movements, total, err := s.stockUsecase.GetMovements(c.Context(), bookId, pagination.Offset, pagination.Limit)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error getting stock movements:", eris.ToString(err, true))
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get stock movements")
+	}
+
+	response := newPaginatedResponse(c.BaseURL()+c.Path(), nil, movements, pagination, total)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetLocationStock mengambil rincian stok sebuah buku di setiap lokasi
+//
+//	@Summary		Get stock of a book per location
+//	@Description	Get how many copies of a book are held at each location. stock is the total across all locations.
+//	@Tags			stock
+//	@Router			/books/{book_id}/stock/locations [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			book_id	path		string												true	"Book ID"
+//	@Success		200		{object}	model.DataResponse[model.BookLocationStockResponse]	"Stock per location retrieved successfully"
+//	@Failure		500		{object}	types.HTTPError										"Internal server error"
+//	@Failure		404		{object}	types.HTTPError										"Book not found"
+//	@Failure		403		{object}	types.HTTPError										"Forbidden"
+//	@Failure		401		{object}	types.HTTPError										"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError										"Invalid Book ID format or Book ID is required"
+func (s StockController) GetLocationStock(c *fiber.Ctx) error {
+	bookId := c.Params("book_id")
+	if bookId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Book ID is required")
+	}
+
+	stock, err := s.stockUsecase.GetLocationStock(c.Context(), bookId)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get stock per location")
+	}
+
+	response := model.DataResponse[model.BookLocationStockResponse]{
+		Data: stock,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
@@ -0,0 +1,309 @@
+package controller
+
+import (
+	"log"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/middleware"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+)
+
+type StockTakeController struct {
+	stockTakeUsecase *usecase.StockTakeUsecase
+}
+
+func NewStockTakeController(stockTakeUsecase *usecase.StockTakeUsecase) *StockTakeController {
+	return &StockTakeController{stockTakeUsecase}
+}
+
+// Create memulai stock take baru di sebuah lokasi
+//
+//	@Summary		Start a stock take
+//	@Description	Start a physical inventory count at a location, or at the default location when location_id is omitted. The current location stock of every book is snapshotted as the expected quantity, so sales and receipts during counting do not distort the variance. A location can only have one open stock take.
+//	@Tags			stock-takes
+//	@Router			/stock-takes [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		model.CreateStockTakeRequest				true	"Request payload"
+//	@Success		201		{object}	model.DataResponse[model.StockTakeResponse]	"Stock take started successfully"
+//	@Failure		500		{object}	types.HTTPError								"Internal server error"
+//	@Failure		409		{object}	types.HTTPError								"Location already has an open stock take"
+//	@Failure		404		{object}	types.HTTPError								"Location not found"
+//	@Failure		403		{object}	types.HTTPError								"Forbidden"
+//	@Failure		401		{object}	types.HTTPError								"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError								"Invalid request payload"
+func (s StockTakeController) Create(c *fiber.Ctx) error {
+	request := new(model.CreateStockTakeRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	stockTake, err := s.stockTakeUsecase.Create(c.Context(), middleware.GetUserID(c), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error creating stock take:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to create stock take")
+	}
+
+	response := model.DataResponse[model.StockTakeResponse]{
+		Data: stockTake,
+	}
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// GetByID mengambil stock take beserta ringkasan hasil hitungnya berdasarkan ID
+//
+//	@Summary		Get stock take by ID
+//	@Description	Get a stock take by ID with a summary of the books counted so far, the books whose count differs from the expected quantity and the net variance
+//	@Tags			stock-takes
+//	@Router			/stock-takes/{stock_take_id} [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			stock_take_id	path		string										true	"Stock take ID"
+//	@Success		200				{object}	model.DataResponse[model.StockTakeResponse]	"Stock take retrieved successfully"
+//	@Failure		500				{object}	types.HTTPError								"Internal server error"
+//	@Failure		404				{object}	types.HTTPError								"Stock take not found"
+//	@Failure		403				{object}	types.HTTPError								"Forbidden"
+//	@Failure		401				{object}	types.HTTPError								"Unauthorized"
+//	@Failure		400				{object}	types.HTTPError								"Invalid Stock take ID format or Stock take ID is required"
+func (s StockTakeController) GetByID(c *fiber.Ctx) error {
+	stockTakeId := c.Params("stock_take_id")
+	if stockTakeId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Stock take ID is required")
+	}
+
+	stockTake, err := s.stockTakeUsecase.GetById(c.Context(), stockTakeId)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get stock take by ID")
+	}
+
+	response := model.DataResponse[model.StockTakeResponse]{
+		Data: stockTake,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetMany mengambil daftar stock take dengan filter status dan lokasi
+//
+//	@Summary		Get stock takes with pagination
+//	@Description	Get a list of stock takes, newest first, optionally filtered by status and location. Summaries are not included.
+//	@Tags			stock-takes
+//	@Router			/stock-takes [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			search	query		model.StockTakeSearchRequest						false	"Filters"
+//	@Param			offset	query		int													false	"Page offset (default: 0)"
+//	@Param			limit	query		int													false	"Page limit (default: 10, max: 100)"
+//	@Success		200		{object}	model.PaginatedResponse[model.StockTakeResponse]	"Stock takes with pagination metadata and navigation links"
+//	@Failure		500		{object}	types.HTTPError										"Internal server error"
+//	@Failure		403		{object}	types.HTTPError										"Forbidden"
+//	@Failure		401		{object}	types.HTTPError										"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError										"Invalid query parameters"
+func (s StockTakeController) GetMany(c *fiber.Ctx) error {
+	pagination, fe := parsePagination(c)
+	if fe != nil {
+		return newHTTPError(c, fe.Code, fe.Message)
+	}
+
+	search := new(model.StockTakeSearchRequest)
+	if err := c.QueryParser(search); err != nil {
+		log.Println("Error parsing query parameters:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	stockTakes, total, err := s.stockTakeUsecase.GetMany(c.Context(), search, pagination.Offset, pagination.Limit)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get stock takes")
+	}
+
+	response := newPaginatedResponse(c.BaseURL()+c.Route().Path, queryFilters(c), stockTakes, pagination, total)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetLines mengambil laporan selisih stock take
+//
+//	@Summary		Get stock take discrepancy report
+//	@Description	Get the counted, expected and variance quantity of every book in a stock take, ordered by title. Use status=discrepancy for books whose count differs from the expected quantity and status=uncounted for books nobody has counted yet.
+//	@Tags			stock-takes
+//	@Router			/stock-takes/{stock_take_id}/lines [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			stock_take_id	path		string													true	"Stock take ID"
+//	@Param			search			query		model.StockTakeLineSearchRequest						false	"Filters"
+//	@Param			offset			query		int														false	"Page offset (default: 0)"
+//	@Param			limit			query		int														false	"Page limit (default: 10, max: 100)"
+//	@Success		200				{object}	model.PaginatedResponse[model.StockTakeLineResponse]	"Stock take lines with pagination metadata and navigation links"
+//	@Failure		500				{object}	types.HTTPError											"Internal server error"
+//	@Failure		404				{object}	types.HTTPError											"Stock take not found"
+//	@Failure		403				{object}	types.HTTPError											"Forbidden"
+//	@Failure		401				{object}	types.HTTPError											"Unauthorized"
+//	@Failure		400				{object}	types.HTTPError											"Invalid Stock take ID or query parameters"
+func (s StockTakeController) GetLines(c *fiber.Ctx) error {
+	pagination, fe := parsePagination(c)
+	if fe != nil {
+		return newHTTPError(c, fe.Code, fe.Message)
+	}
+
+	search := new(model.StockTakeLineSearchRequest)
+	if err := c.QueryParser(search); err != nil {
+		log.Println("Error parsing query parameters:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	lines, total, err := s.stockTakeUsecase.GetLines(c.Context(), c.Params("stock_take_id"), search, pagination.Offset, pagination.Limit)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get stock take lines")
+	}
+
+	response := newPaginatedResponse(c.BaseURL()+c.Path(), queryFilters(c), lines, pagination, total)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Count mencatat hasil pindaian ke stock take
+//
+//	@Summary		Submit stock take counts
+//	@Description	Record scanned books in an open stock take. Each code can be a book QR code, a book URL or an ISBN barcode, and quantity defaults to one copy. In add mode (the default) quantities are added to the previous count so every scan can be sent as it happens, in set mode the previous count is replaced. Books missing from the snapshot use their current location stock as the expected quantity. The updated lines are returned.
+//	@Tags			stock-takes
+//	@Router			/stock-takes/{stock_take_id}/counts [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			stock_take_id	path		string												true	"Stock take ID"
+//	@Param			payload			body		model.StockTakeCountRequest							true	"Request payload"
+//	@Success		200				{object}	model.DataResponse[[]model.StockTakeLineResponse]	"Counts recorded successfully"
+//	@Failure		500				{object}	types.HTTPError										"Internal server error"
+//	@Failure		409				{object}	types.HTTPError										"Stock take is not open"
+//	@Failure		404				{object}	types.HTTPError										"Stock take or book not found"
+//	@Failure		403				{object}	types.HTTPError										"Forbidden"
+//	@Failure		401				{object}	types.HTTPError										"Unauthorized"
+//	@Failure		400				{object}	types.HTTPError										"Invalid request payload or unrecognized code"
+func (s StockTakeController) Count(c *fiber.Ctx) error {
+	request := new(model.StockTakeCountRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	lines, err := s.stockTakeUsecase.Count(c.Context(), middleware.GetUserID(c), c.Params("stock_take_id"), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error recording stock take count:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to record stock take count")
+	}
+
+	response := model.DataResponse[[]model.StockTakeLineResponse]{
+		Data: lines,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Approve menyetujui stock take dan mencatat selisihnya sebagai penyesuaian stok
+//
+//	@Summary		Approve a stock take
+//	@Description	Close an open stock take and post the variance (counted minus expected) of every counted book as an adjustment movement at the stock take location, referencing the stock take, all in one database transaction. The variance is applied to the current stock, so sales made during counting are kept. Uncounted books are left unchanged unless zero_uncounted is true. The whole approval is refused if any book would go negative.
+//	@Tags			stock-takes
+//	@Router			/stock-takes/{stock_take_id}/approve [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			stock_take_id	path		string												true	"Stock take ID"
+//	@Param			payload			body		model.ApproveStockTakeRequest						false	"Request payload"
+//	@Success		200				{object}	model.DataResponse[model.StockTakeApproveResponse]	"Stock take approved successfully"
+//	@Failure		500				{object}	types.HTTPError										"Internal server error"
+//	@Failure		409				{object}	types.HTTPError										"Stock take is not open or insufficient stock"
+//	@Failure		404				{object}	types.HTTPError										"Stock take not found"
+//	@Failure		403				{object}	types.HTTPError										"Forbidden"
+//	@Failure		401				{object}	types.HTTPError										"Unauthorized"
+//	@Failure		400				{object}	types.HTTPError										"Invalid Stock take ID or request payload"
+func (s StockTakeController) Approve(c *fiber.Ctx) error {
+	request := new(model.ApproveStockTakeRequest)
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(request); err != nil {
+			log.Println("Error parsing request body:", err)
+			return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+		}
+	}
+
+	result, err := s.stockTakeUsecase.Approve(c.Context(), middleware.GetUserID(c), c.Params("stock_take_id"), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error approving stock take:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to approve stock take")
+	}
+
+	response := model.DataResponse[model.StockTakeApproveResponse]{
+		Data: result,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Cancel membatalkan stock take tanpa mengubah stok
+//
+//	@Summary		Cancel a stock take
+//	@Description	Cancel an open stock take. Stock is not changed and the counts are kept for reference.
+//	@Tags			stock-takes
+//	@Router			/stock-takes/{stock_take_id}/cancel [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			stock_take_id	path		string										true	"Stock take ID"
+//	@Success		200				{object}	model.DataResponse[model.StockTakeResponse]	"Stock take cancelled successfully"
+//	@Failure		500				{object}	types.HTTPError								"Internal server error"
+//	@Failure		409				{object}	types.HTTPError								"Stock take is not open"
+//	@Failure		404				{object}	types.HTTPError								"Stock take not found"
+//	@Failure		403				{object}	types.HTTPError								"Forbidden"
+//	@Failure		401				{object}	types.HTTPError								"Unauthorized"
+//	@Failure		400				{object}	types.HTTPError								"Invalid Stock take ID"
+func (s StockTakeController) Cancel(c *fiber.Ctx) error {
+	stockTake, err := s.stockTakeUsecase.Cancel(c.Context(), c.Params("stock_take_id"))
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to cancel stock take")
+	}
+
+	response := model.DataResponse[model.StockTakeResponse]{
+		Data: stockTake,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
@@ -0,0 +1,248 @@
+package controller
+
+import (
+	"log"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/middleware"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+)
+
+type StockTransferController struct {
+	transferUsecase *usecase.StockTransferUsecase
+}
+
+func NewStockTransferController(transferUsecase *usecase.StockTransferUsecase) *StockTransferController {
+	return &StockTransferController{transferUsecase}
+}
+
+// Create membuat permintaan transfer stok antar lokasi
+//
+//	@Summary		Request a stock transfer
+//	@Description	Request moving books from one location to another. The transfer starts as requested and does not change stock yet, but it is refused when the source location does not currently hold enough copies of every book.
+//	@Tags			transfers
+//	@Router			/transfers [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		model.CreateStockTransferRequest				true	"Request payload"
+//	@Success		201		{object}	model.DataResponse[model.StockTransferResponse]	"Stock transfer requested successfully"
+//	@Failure		500		{object}	types.HTTPError									"Internal server error"
+//	@Failure		409		{object}	types.HTTPError									"Insufficient stock at the source location"
+//	@Failure		404		{object}	types.HTTPError									"Location or book not found"
+//	@Failure		403		{object}	types.HTTPError									"Forbidden"
+//	@Failure		401		{object}	types.HTTPError									"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError									"Invalid request payload"
+func (s StockTransferController) Create(c *fiber.Ctx) error {
+	request := new(model.CreateStockTransferRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	transfer, err := s.transferUsecase.Create(c.Context(), middleware.GetUserID(c), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error creating stock transfer:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to create stock transfer")
+	}
+
+	response := model.DataResponse[model.StockTransferResponse]{
+		Data: transfer,
+	}
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// GetByID mengambil transfer beserta item-itemnya berdasarkan ID
+//
+//	@Summary		Get stock transfer by ID
+//	@Description	Get a stock transfer and its line items by ID
+//	@Tags			transfers
+//	@Router			/transfers/{transfer_id} [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			transfer_id	path		string											true	"Stock transfer ID"
+//	@Success		200			{object}	model.DataResponse[model.StockTransferResponse]	"Stock transfer retrieved successfully"
+//	@Failure		500			{object}	types.HTTPError									"Internal server error"
+//	@Failure		404			{object}	types.HTTPError									"Stock transfer not found"
+//	@Failure		403			{object}	types.HTTPError									"Forbidden"
+//	@Failure		401			{object}	types.HTTPError									"Unauthorized"
+//	@Failure		400			{object}	types.HTTPError									"Invalid Stock transfer ID format or Stock transfer ID is required"
+func (s StockTransferController) GetByID(c *fiber.Ctx) error {
+	transferId := c.Params("transfer_id")
+	if transferId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Stock transfer ID is required")
+	}
+
+	transfer, err := s.transferUsecase.GetById(c.Context(), transferId)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get stock transfer by ID")
+	}
+
+	response := model.DataResponse[model.StockTransferResponse]{
+		Data: transfer,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetMany mengambil daftar transfer dengan filter status dan lokasi
+//
+//	@Summary		Get stock transfers with pagination
+//	@Description	Get a list of stock transfers, newest first, optionally filtered by status and by a location that is either the source or the destination. Line items are not included.
+//	@Tags			transfers
+//	@Router			/transfers [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			search	query		model.StockTransferSearchRequest						false	"Filters"
+//	@Param			offset	query		int														false	"Page offset (default: 0)"
+//	@Param			limit	query		int														false	"Page limit (default: 10, max: 100)"
+//	@Success		200		{object}	model.PaginatedResponse[model.StockTransferResponse]	"Stock transfers with pagination metadata and navigation links"
+//	@Failure		500		{object}	types.HTTPError											"Internal server error"
+//	@Failure		403		{object}	types.HTTPError											"Forbidden"
+//	@Failure		401		{object}	types.HTTPError											"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError											"Invalid query parameters"
+func (s StockTransferController) GetMany(c *fiber.Ctx) error {
+	pagination, fe := parsePagination(c)
+	if fe != nil {
+		return newHTTPError(c, fe.Code, fe.Message)
+	}
+
+	search := new(model.StockTransferSearchRequest)
+	if err := c.QueryParser(search); err != nil {
+		log.Println("Error parsing query parameters:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	transfers, total, err := s.transferUsecase.GetMany(c.Context(), search, pagination.Offset, pagination.Limit)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get stock transfers")
+	}
+
+	response := newPaginatedResponse(c.BaseURL()+c.Route().Path, queryFilters(c), transfers, pagination, total)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Dispatch mengirim transfer dan mengurangi stok di lokasi asal
+//
+//	@Summary		Dispatch a stock transfer
+//	@Description	Send a requested transfer on its way. Every line item is taken out of the source location with a transfer movement referencing the transfer, and the transfer moves to in_transit, all in one database transaction. The whole dispatch is refused if any book would go negative at the source location. Copies in transit are not counted in the book stock until the transfer is received.
+//	@Tags			transfers
+//	@Router			/transfers/{transfer_id}/dispatch [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			transfer_id	path		string													true	"Stock transfer ID"
+//	@Success		200			{object}	model.DataResponse[model.StockTransferProcessResponse]	"Stock transfer dispatched successfully"
+//	@Failure		500			{object}	types.HTTPError											"Internal server error"
+//	@Failure		409			{object}	types.HTTPError											"Stock transfer is not requested or insufficient stock at the source location"
+//	@Failure		404			{object}	types.HTTPError											"Stock transfer not found"
+//	@Failure		403			{object}	types.HTTPError											"Forbidden"
+//	@Failure		401			{object}	types.HTTPError											"Unauthorized"
+//	@Failure		400			{object}	types.HTTPError											"Invalid Stock transfer ID"
+func (s StockTransferController) Dispatch(c *fiber.Ctx) error {
+	result, err := s.transferUsecase.Dispatch(c.Context(), middleware.GetUserID(c), c.Params("transfer_id"))
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error dispatching stock transfer:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to dispatch stock transfer")
+	}
+
+	response := model.DataResponse[model.StockTransferProcessResponse]{
+		Data: result,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Receive menerima transfer dan menambah stok di lokasi tujuan
+//
+//	@Summary		Receive a stock transfer
+//	@Description	Record the arrival of an in-transit transfer. Every line item is added to the destination location with a transfer movement referencing the transfer, and the transfer moves to received in the same database transaction. Received copies are then allocated to waiting backorders and pre-orders at the destination, oldest first, and each allocation is returned with its reservation.
+//	@Tags			transfers
+//	@Router			/transfers/{transfer_id}/receive [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			transfer_id	path		string													true	"Stock transfer ID"
+//	@Success		200			{object}	model.DataResponse[model.StockTransferProcessResponse]	"Stock transfer received successfully"
+//	@Failure		500			{object}	types.HTTPError											"Internal server error"
+//	@Failure		409			{object}	types.HTTPError											"Stock transfer is not in transit"
+//	@Failure		404			{object}	types.HTTPError											"Stock transfer not found"
+//	@Failure		403			{object}	types.HTTPError											"Forbidden"
+//	@Failure		401			{object}	types.HTTPError											"Unauthorized"
+//	@Failure		400			{object}	types.HTTPError											"Invalid Stock transfer ID"
+func (s StockTransferController) Receive(c *fiber.Ctx) error {
+	result, err := s.transferUsecase.Receive(c.Context(), middleware.GetUserID(c), c.Params("transfer_id"))
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error receiving stock transfer:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to receive stock transfer")
+	}
+
+	response := model.DataResponse[model.StockTransferProcessResponse]{
+		Data: result,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Cancel membatalkan transfer yang belum dikirim
+//
+//	@Summary		Cancel a stock transfer
+//	@Description	Cancel a transfer that is still requested. Dispatched transfers cannot be cancelled, receive them and request a transfer back instead.
+//	@Tags			transfers
+//	@Router			/transfers/{transfer_id}/cancel [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			transfer_id	path		string											true	"Stock transfer ID"
+//	@Success		200			{object}	model.DataResponse[model.StockTransferResponse]	"Stock transfer cancelled successfully"
+//	@Failure		500			{object}	types.HTTPError									"Internal server error"
+//	@Failure		409			{object}	types.HTTPError									"Stock transfer is not requested"
+//	@Failure		404			{object}	types.HTTPError									"Stock transfer not found"
+//	@Failure		403			{object}	types.HTTPError									"Forbidden"
+//	@Failure		401			{object}	types.HTTPError									"Unauthorized"
+//	@Failure		400			{object}	types.HTTPError									"Invalid Stock transfer ID"
+func (s StockTransferController) Cancel(c *fiber.Ctx) error {
+	transfer, err := s.transferUsecase.Cancel(c.Context(), c.Params("transfer_id"))
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to cancel stock transfer")
+	}
+
+	response := model.DataResponse[model.StockTransferResponse]{
+		Data: transfer,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
@@ -0,0 +1,218 @@
+package controller
+
+import (
+	"log"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+)
+
+type SupplierController struct {
+	supplierUsecase *usecase.SupplierUsecase
+}
+
+func NewSupplierController(supplierUsecase *usecase.SupplierUsecase) *SupplierController {
+	return &SupplierController{supplierUsecase}
+}
+
+// Create menambahkan supplier baru
+//
+//	@Summary		Create a new supplier
+//	@Description	Create a new supplier with contact information and lead time in days
+//	@Tags			suppliers
+//	@Router			/suppliers [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		model.CreateSupplierRequest					true	"Request payload"
+//	@Success		201		{object}	model.DataResponse[model.SupplierResponse]	"Supplier created successfully"
+//	@Failure		500		{object}	types.HTTPError								"Internal server error"
+//	@Failure		409		{object}	types.HTTPError								"Supplier with the same name already exists"
+//	@Failure		403		{object}	types.HTTPError								"Forbidden"
+//	@Failure		401		{object}	types.HTTPError								"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError								"Invalid request payload"
+func (s SupplierController) Create(c *fiber.Ctx) error {
+	request := new(model.CreateSupplierRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	supplier, err := s.supplierUsecase.Create(c.Context(), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error creating supplier:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to create supplier")
+	}
+
+	response := model.DataResponse[model.SupplierResponse]{
+		Data: supplier,
+	}
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// GetByID mengambil data supplier berdasarkan ID
+//
+//	@Summary		Get supplier by ID
+//	@Description	Get supplier information by ID
+//	@Tags			suppliers
+//	@Router			/suppliers/{supplier_id} [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			supplier_id	path		string										true	"Supplier ID"
+//	@Success		200			{object}	model.DataResponse[model.SupplierResponse]	"Supplier information retrieved successfully"
+//	@Failure		500			{object}	types.HTTPError								"Internal server error"
+//	@Failure		404			{object}	types.HTTPError								"Supplier not found"
+//	@Failure		403			{object}	types.HTTPError								"Forbidden"
+//	@Failure		401			{object}	types.HTTPError								"Unauthorized"
+//	@Failure		400			{object}	types.HTTPError								"Invalid Supplier ID format or Supplier ID is required"
+func (s SupplierController) GetByID(c *fiber.Ctx) error {
+	supplierId := c.Params("supplier_id")
+	if supplierId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Supplier ID is required")
+	}
+
+	supplier, err := s.supplierUsecase.GetById(c.Context(), supplierId)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get supplier by ID")
+	}
+
+	response := model.DataResponse[model.SupplierResponse]{
+		Data: supplier,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetMany mengambil daftar supplier dengan pagination
+//
+//	@Summary		Get suppliers with pagination
+//	@Description	Get a list of suppliers ordered by name with pagination support including navigation links
+//	@Tags			suppliers
+//	@Router			/suppliers [get]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			offset	query		int												false	"Page offset (default: 0)"
+//	@Param			limit	query		int												false	"Page limit (default: 10, max: 100)"
+//	@Success		200		{object}	model.PaginatedResponse[model.SupplierResponse]	"Suppliers with pagination metadata and navigation links"
+//	@Failure		500		{object}	types.HTTPError									"Internal server error"
+//	@Failure		403		{object}	types.HTTPError									"Forbidden"
+//	@Failure		401		{object}	types.HTTPError									"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError									"Invalid query parameters"
+func (s SupplierController) GetMany(c *fiber.Ctx) error {
+	pagination, fe := parsePagination(c)
+	if fe != nil {
+		return newHTTPError(c, fe.Code, fe.Message)
+	}
+
+	suppliers, total, err := s.supplierUsecase.GetMany(c.Context(), pagination.Offset, pagination.Limit)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to get suppliers")
+	}
+
+	response := newPaginatedResponse(c.BaseURL()+c.Route().Path, nil, suppliers, pagination, total)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Update memperbarui sebagian data supplier
+//
+//	@Summary		Update supplier
+//	@Description	Update supplier information partially. Fields that are not sent are left unchanged.
+//	@Tags			suppliers
+//	@Router			/suppliers/{supplier_id} [patch]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			supplier_id	path		string										true	"Supplier ID"
+//	@Param			payload		body		model.UpdateSupplierRequest					true	"Request payload"
+//	@Success		200			{object}	model.DataResponse[model.SupplierResponse]	"Supplier updated successfully"
+//	@Failure		500			{object}	types.HTTPError								"Internal server error"
+//	@Failure		409			{object}	types.HTTPError								"Supplier with the same name already exists"
+//	@Failure		404			{object}	types.HTTPError								"Supplier not found"
+//	@Failure		403			{object}	types.HTTPError								"Forbidden"
+//	@Failure		401			{object}	types.HTTPError								"Unauthorized"
+//	@Failure		400			{object}	types.HTTPError								"Invalid request payload"
+func (s SupplierController) Update(c *fiber.Ctx) error {
+	supplierId, err := uuid.Parse(c.Params("supplier_id"))
+	if err != nil {
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid supplier ID")
+	}
+
+	request := new(model.UpdateSupplierRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+	request.SupplierID = supplierId
+
+	supplier, err := s.supplierUsecase.Update(c.Context(), request)
+	if err != nil {
+		log.Println("Error updating supplier:", eris.ToString(err, true))
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to update supplier")
+	}
+
+	response := model.DataResponse[model.SupplierResponse]{
+		Data: supplier,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Delete menghapus supplier yang belum memiliki purchase order
+//
+//	@Summary		Delete supplier
+//	@Description	Delete supplier by ID. Suppliers that still have purchase orders cannot be deleted.
+//	@Tags			suppliers
+//	@Router			/suppliers/{supplier_id} [delete]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			supplier_id	path		string			true	"Supplier ID"
+//	@Success		204			{string}	string			"Supplier deleted successfully"
+//	@Failure		500			{object}	types.HTTPError	"Internal server error"
+//	@Failure		409			{object}	types.HTTPError	"Supplier has purchase orders"
+//	@Failure		404			{object}	types.HTTPError	"Supplier not found"
+//	@Failure		403			{object}	types.HTTPError	"Forbidden"
+//	@Failure		401			{object}	types.HTTPError	"Unauthorized"
+//	@Failure		400			{object}	types.HTTPError	"Invalid Supplier ID format or Supplier ID is required"
+func (s SupplierController) Delete(c *fiber.Ctx) error {
+	supplierId := c.Params("supplier_id")
+	if supplierId == "" {
+		return newHTTPError(c, fiber.StatusBadRequest, "Supplier ID is required")
+	}
+
+	err := s.supplierUsecase.Delete(c.Context(), supplierId)
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to delete supplier")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"log"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+)
+
+type UserController struct {
+	userUsecase *usecase.UserUsecase
+}
+
+func NewUserController(userUsecase *usecase.UserUsecase) *UserController {
+	return &UserController{userUsecase}
+}
+
+// UserCreate membuat akun user baru
+//
+//	@Summary		Create a new user
+//	@Description	Create a new user account that can login to the API
+//	@Tags			users
+//	@Router			/users [post]
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		model.CreateUserRequest					true	"Request payload"
+//	@Success		201		{object}	model.DataResponse[model.UserResponse]	"User created successfully"
+//	@Failure		500		{object}	types.HTTPError							"Internal server error"
+//	@Failure		409		{object}	types.HTTPError							"Username already exists"
+//	@Failure		403		{object}	types.HTTPError							"Forbidden"
+//	@Failure		401		{object}	types.HTTPError							"Unauthorized"
+//	@Failure		400		{object}	types.HTTPError							"Invalid request payload"
+func (u UserController) UserCreate(c *fiber.Ctx) error {
+	request := new(model.CreateUserRequest)
+	if err := c.BodyParser(request); err != nil {
+		log.Println("Error parsing request body:", err)
+		return newHTTPError(c, fiber.StatusBadRequest, "Invalid request payload")
+	}
+
+	user, err := u.userUsecase.Create(c.Context(), request)
+	if err != nil {
+		var fe *fiber.Error
+		log.Println("Error creating user:", eris.ToString(err, true))
+
+		if eris.As(err, &fe) {
+			return newHTTPError(c, fe.Code, fe.Message)
+		}
+
+		return newHTTPError(c, fiber.StatusInternalServerError, "Failed to create user")
+	}
+
+	response := model.DataResponse[model.UserResponse]{
+		Data: user,
+	}
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
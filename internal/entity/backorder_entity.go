@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BackorderKind membedakan pesanan untuk buku yang belum terbit (preorder) dan buku yang
+// stoknya sedang habis (backorder)
+type BackorderKind string
+
+const (
+	BackorderKindBackorder BackorderKind = "backorder"
+	BackorderKindPreorder  BackorderKind = "preorder"
+)
+
+type BackorderStatus string
+
+const (
+	BackorderWaiting   BackorderStatus = "waiting"
+	BackorderAllocated BackorderStatus = "allocated"
+	BackorderCancelled BackorderStatus = "cancelled"
+)
+
+type Backorder struct {
+	BackorderId     uuid.UUID       `json:"backorder_id" db:"backorder_id"`
+	BookId          uuid.UUID       `json:"book_id" db:"book_id"`
+	LocationId      uuid.UUID       `json:"location_id" db:"location_id"`
+	Kind            BackorderKind   `json:"kind" db:"kind"`
+	Status          BackorderStatus `json:"status" db:"status"`
+	Quantity        int64           `json:"quantity" db:"quantity"`
+	CustomerName    string          `json:"customer_name" db:"customer_name"`
+	CustomerContact string          `json:"customer_contact" db:"customer_contact"`
+	Notes           string          `json:"notes" db:"notes"`
+	// ReservationId adalah reservasi yang menahan stok untuk pesanan ini setelah dialokasikan
+	ReservationId uuid.NullUUID `json:"reservation_id" db:"reservation_id"`
+	CreatedBy     uuid.NullUUID `json:"created_by" db:"created_by"`
+	AllocatedAt   sql.NullTime  `json:"allocated_at" db:"allocated_at"`
+	CreatedAt     time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at" db:"updated_at"`
+	// QueuePosition adalah urutan pesanan di antrean buku dan lokasinya, dimulai dari 1.
+	// Hanya terisi untuk pesanan yang masih waiting
+	QueuePosition sql.NullInt64 `json:"queue_position" db:"queue_position"`
+}
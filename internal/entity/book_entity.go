@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 type Book struct {
@@ -14,6 +15,23 @@ type Book struct {
 	Publisher   string    `json:"publisher" db:"publisher"`
 	PublishedAt time.Time `json:"published_at" db:"published_at"`
 	Stock       int64     `json:"stock" db:"stock"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	// Reserved adalah bagian dari Stock yang ditahan oleh reservasi aktif dan tidak bisa dijual
+	Reserved int64 `json:"reserved" db:"reserved"`
+	// ReorderQuantity 0 berarti buku tidak pernah masuk daftar stok menipis
+	ReorderPoint        int64         `json:"reorder_point" db:"reorder_point"`
+	ReorderQuantity     int64         `json:"reorder_quantity" db:"reorder_quantity"`
+	PreferredSupplierId uuid.NullUUID `json:"preferred_supplier_id" db:"preferred_supplier_id"`
+	// Currency kosong pada pembaruan berarti tidak diubah
+	CostPrice decimal.Decimal `json:"cost_price" db:"cost_price"`
+	SalePrice decimal.Decimal `json:"sale_price" db:"sale_price"`
+	Currency  string          `json:"currency" db:"currency"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// LowStockBook adalah buku yang stoknya sudah mencapai reorder point beserta jumlah
+// yang masih dipesan di purchase order yang belum selesai
+type LowStockBook struct {
+	Book
+	OnOrder int64 `json:"on_order" db:"on_order"`
 }
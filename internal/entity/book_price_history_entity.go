@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// BookPriceHistory adalah harga buku yang berlaku dari EffectiveFrom sampai EffectiveTo.
+// EffectiveTo yang tidak Valid berarti harga masih berlaku
+type BookPriceHistory struct {
+	PriceHistoryId uuid.UUID       `json:"price_history_id" db:"price_history_id"`
+	BookId         uuid.UUID       `json:"book_id" db:"book_id"`
+	CostPrice      decimal.Decimal `json:"cost_price" db:"cost_price"`
+	SalePrice      decimal.Decimal `json:"sale_price" db:"sale_price"`
+	Currency       string          `json:"currency" db:"currency"`
+	EffectiveFrom  time.Time       `json:"effective_from" db:"effective_from"`
+	EffectiveTo    sql.NullTime    `json:"effective_to" db:"effective_to"`
+	ChangedBy      uuid.NullUUID   `json:"changed_by" db:"changed_by"`
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Location struct {
+	LocationId uuid.UUID `json:"location_id" db:"location_id"`
+	Code       string    `json:"code" db:"code"`
+	Name       string    `json:"name" db:"name"`
+	Address    string    `json:"address" db:"address"`
+	IsDefault  bool      `json:"is_default" db:"is_default"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// LocationStock adalah stok satu buku di satu lokasi. Code dan Name diisi dari tabel locations
+// saat dibaca bersama daftar lokasi
+type LocationStock struct {
+	BookId     uuid.UUID `json:"book_id" db:"book_id"`
+	LocationId uuid.UUID `json:"location_id" db:"location_id"`
+	Code       string    `json:"code" db:"code"`
+	Name       string    `json:"name" db:"name"`
+	Quantity   int64     `json:"quantity" db:"quantity"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type PromotionRuleType string
+
+const (
+	PromotionPercentOff       PromotionRuleType = "percent_off"
+	PromotionBuyXGetY         PromotionRuleType = "buy_x_get_y"
+	PromotionFixedPriceBundle PromotionRuleType = "fixed_price_bundle"
+)
+
+type PromotionScope string
+
+const (
+	PromotionScopeAll       PromotionScope = "all"
+	PromotionScopePublisher PromotionScope = "publisher"
+	PromotionScopeAuthor    PromotionScope = "author"
+	PromotionScopeBooks     PromotionScope = "books"
+)
+
+type Promotion struct {
+	PromotionId    uuid.UUID         `json:"promotion_id" db:"promotion_id"`
+	Name           string            `json:"name" db:"name"`
+	RuleType       PromotionRuleType `json:"rule_type" db:"rule_type"`
+	Scope          PromotionScope    `json:"scope" db:"scope"`
+	ScopeValue     string            `json:"scope_value" db:"scope_value"`
+	Percent        decimal.Decimal   `json:"percent" db:"percent"`
+	BuyQuantity    int64             `json:"buy_quantity" db:"buy_quantity"`
+	FreeQuantity   int64             `json:"free_quantity" db:"free_quantity"`
+	BundleQuantity int64             `json:"bundle_quantity" db:"bundle_quantity"`
+	BundlePrice    decimal.Decimal   `json:"bundle_price" db:"bundle_price"`
+	MinSubtotal    decimal.Decimal   `json:"min_subtotal" db:"min_subtotal"`
+	Priority       int32             `json:"priority" db:"priority"`
+	Stackable      bool              `json:"stackable" db:"stackable"`
+	Active         bool              `json:"active" db:"active"`
+	StartsAt       time.Time         `json:"starts_at" db:"starts_at"`
+	EndsAt         sql.NullTime      `json:"ends_at" db:"ends_at"`
+	CreatedBy      uuid.NullUUID     `json:"created_by" db:"created_by"`
+	CreatedAt      time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+type PromotionBook struct {
+	PromotionId uuid.UUID `json:"promotion_id" db:"promotion_id"`
+	BookId      uuid.UUID `json:"book_id" db:"book_id"`
+}
+
+// SalePromotion mencatat total diskon yang diberikan oleh satu promosi pada sebuah penjualan
+type SalePromotion struct {
+	SalePromotionId uuid.UUID       `json:"sale_promotion_id" db:"sale_promotion_id"`
+	SaleId          uuid.UUID       `json:"sale_id" db:"sale_id"`
+	PromotionId     uuid.NullUUID   `json:"promotion_id" db:"promotion_id"`
+	Name            string          `json:"name" db:"name"`
+	Discount        decimal.Decimal `json:"discount" db:"discount"`
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderDraft             PurchaseOrderStatus = "draft"
+	PurchaseOrderSent              PurchaseOrderStatus = "sent"
+	PurchaseOrderPartiallyReceived PurchaseOrderStatus = "partially_received"
+	PurchaseOrderReceived          PurchaseOrderStatus = "received"
+	PurchaseOrderCancelled         PurchaseOrderStatus = "cancelled"
+)
+
+type PurchaseOrder struct {
+	PurchaseOrderId uuid.UUID           `json:"purchase_order_id" db:"purchase_order_id"`
+	SupplierId      uuid.UUID           `json:"supplier_id" db:"supplier_id"`
+	Status          PurchaseOrderStatus `json:"status" db:"status"`
+	Notes           string              `json:"notes" db:"notes"`
+	ExpectedAt      sql.NullTime        `json:"expected_at" db:"expected_at"`
+	TotalCost       decimal.Decimal     `json:"total_cost" db:"total_cost"`
+	CreatedBy       uuid.NullUUID       `json:"created_by" db:"created_by"`
+	CreatedAt       time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+type PurchaseOrderItem struct {
+	PurchaseOrderItemId uuid.UUID       `json:"purchase_order_item_id" db:"purchase_order_item_id"`
+	PurchaseOrderId     uuid.UUID       `json:"purchase_order_id" db:"purchase_order_id"`
+	BookId              uuid.UUID       `json:"book_id" db:"book_id"`
+	QuantityOrdered     int64           `json:"quantity_ordered" db:"quantity_ordered"`
+	QuantityReceived    int64           `json:"quantity_received" db:"quantity_received"`
+	UnitCost            decimal.Decimal `json:"unit_cost" db:"unit_cost"`
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReservationStatus string
+
+const (
+	ReservationActive    ReservationStatus = "active"
+	ReservationFulfilled ReservationStatus = "fulfilled"
+	ReservationCancelled ReservationStatus = "cancelled"
+	ReservationExpired   ReservationStatus = "expired"
+)
+
+// ReservationChannel adalah asal reservasi: pesanan lewat telepon, pesanan online yang menunggu
+// pembayaran, titipan di toko, atau alokasi otomatis untuk antrean backorder
+type ReservationChannel string
+
+const (
+	ReservationPhone     ReservationChannel = "phone"
+	ReservationOnline    ReservationChannel = "online"
+	ReservationStore     ReservationChannel = "store"
+	ReservationBackorder ReservationChannel = "backorder"
+)
+
+type Reservation struct {
+	ReservationId   uuid.UUID          `json:"reservation_id" db:"reservation_id"`
+	LocationId      uuid.UUID          `json:"location_id" db:"location_id"`
+	Status          ReservationStatus  `json:"status" db:"status"`
+	Channel         ReservationChannel `json:"channel" db:"channel"`
+	CustomerName    string             `json:"customer_name" db:"customer_name"`
+	CustomerContact string             `json:"customer_contact" db:"customer_contact"`
+	Notes           string             `json:"notes" db:"notes"`
+	ExpiresAt       time.Time          `json:"expires_at" db:"expires_at"`
+	SaleId          uuid.NullUUID      `json:"sale_id" db:"sale_id"`
+	CreatedBy       uuid.NullUUID      `json:"created_by" db:"created_by"`
+	CreatedAt       time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+type ReservationItem struct {
+	ReservationItemId uuid.UUID `json:"reservation_item_id" db:"reservation_item_id"`
+	ReservationId     uuid.UUID `json:"reservation_id" db:"reservation_id"`
+	BookId            uuid.UUID `json:"book_id" db:"book_id"`
+	Quantity          int64     `json:"quantity" db:"quantity"`
+}
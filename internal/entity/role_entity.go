@@ -0,0 +1,48 @@
+package entity
+
+type Role struct {
+	RoleId      int64  `json:"role_id" db:"role_id"`
+	Name        string `json:"name" db:"name"`
+	Description string `json:"description" db:"description"`
+}
+
+// nama role bawaan yang dibuat oleh migration
+const (
+	RoleAdmin   = "admin"
+	RoleManager = "manager"
+	RoleCashier = "cashier"
+)
+
+// nama permission yang dicek oleh middleware pada setiap route
+const (
+	PermissionBooksRead   = "books:read"
+	PermissionBooksWrite  = "books:write"
+	PermissionStockRead   = "stock:read"
+	PermissionStockAdjust = "stock:adjust"
+	PermissionSalesCreate = "sales:create"
+	PermissionSalesRead   = "sales:read"
+	PermissionSalesReturn = "sales:return"
+	PermissionUsersManage = "users:manage"
+
+	PermissionPurchasingRead  = "purchasing:read"
+	PermissionPurchasingWrite = "purchasing:write"
+
+	PermissionPromotionsRead  = "promotions:read"
+	PermissionPromotionsWrite = "promotions:write"
+
+	PermissionLocationsRead  = "locations:read"
+	PermissionLocationsWrite = "locations:write"
+
+	PermissionTransfersRead  = "transfers:read"
+	PermissionTransfersWrite = "transfers:write"
+
+	PermissionStockTakesRead   = "stocktakes:read"
+	PermissionStockTakesCount  = "stocktakes:count"
+	PermissionStockTakesManage = "stocktakes:manage"
+
+	PermissionReservationsRead  = "reservations:read"
+	PermissionReservationsWrite = "reservations:write"
+
+	PermissionBackordersRead  = "backorders:read"
+	PermissionBackordersWrite = "backorders:write"
+)
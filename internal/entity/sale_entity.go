@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type PaymentMethod string
+
+const (
+	PaymentCash     PaymentMethod = "cash"
+	PaymentCard     PaymentMethod = "card"
+	PaymentQRIS     PaymentMethod = "qris"
+	PaymentTransfer PaymentMethod = "transfer"
+)
+
+type Sale struct {
+	SaleId        uuid.UUID       `json:"sale_id" db:"sale_id"`
+	CashierId     uuid.NullUUID   `json:"cashier_id" db:"cashier_id"`
+	PaymentMethod PaymentMethod   `json:"payment_method" db:"payment_method"`
+	Subtotal      decimal.Decimal `json:"subtotal" db:"subtotal"`
+	DiscountTotal decimal.Decimal `json:"discount_total" db:"discount_total"`
+	Total         decimal.Decimal `json:"total" db:"total"`
+	AmountPaid    decimal.Decimal `json:"amount_paid" db:"amount_paid"`
+	ChangeDue     decimal.Decimal `json:"change_due" db:"change_due"`
+	Notes         string          `json:"notes" db:"notes"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+}
+
+type SaleItem struct {
+	SaleItemId uuid.UUID       `json:"sale_item_id" db:"sale_item_id"`
+	SaleId     uuid.UUID       `json:"sale_id" db:"sale_id"`
+	BookId     uuid.NullUUID   `json:"book_id" db:"book_id"`
+	ISBN       string          `json:"isbn" db:"isbn"`
+	Title      string          `json:"title" db:"title"`
+	Quantity   int64           `json:"quantity" db:"quantity"`
+	UnitPrice  decimal.Decimal `json:"unit_price" db:"unit_price"`
+	Discount   decimal.Decimal `json:"discount" db:"discount"`
+	LineTotal  decimal.Decimal `json:"line_total" db:"line_total"`
+}
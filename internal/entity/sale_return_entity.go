@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type ReturnCondition string
+
+const (
+	ReturnResellable ReturnCondition = "resellable"
+	ReturnDamaged    ReturnCondition = "damaged"
+)
+
+type SaleReturn struct {
+	SaleReturnId uuid.UUID       `json:"sale_return_id" db:"sale_return_id"`
+	SaleId       uuid.UUID       `json:"sale_id" db:"sale_id"`
+	Reason       string          `json:"reason" db:"reason"`
+	RefundTotal  decimal.Decimal `json:"refund_total" db:"refund_total"`
+	CreatedBy    uuid.NullUUID   `json:"created_by" db:"created_by"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+}
+
+type SaleReturnItem struct {
+	SaleReturnItemId uuid.UUID       `json:"sale_return_item_id" db:"sale_return_item_id"`
+	SaleReturnId     uuid.UUID       `json:"sale_return_id" db:"sale_return_id"`
+	SaleItemId       uuid.UUID       `json:"sale_item_id" db:"sale_item_id"`
+	BookId           uuid.NullUUID   `json:"book_id" db:"book_id"`
+	Quantity         int64           `json:"quantity" db:"quantity"`
+	Condition        ReturnCondition `json:"condition" db:"condition"`
+	RefundAmount     decimal.Decimal `json:"refund_amount" db:"refund_amount"`
+}
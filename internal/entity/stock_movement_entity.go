@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StockMovementType string
+
+const (
+	StockMovementReceipt    StockMovementType = "receipt"
+	StockMovementSale       StockMovementType = "sale"
+	StockMovementReturn     StockMovementType = "return"
+	StockMovementAdjustment StockMovementType = "adjustment"
+	StockMovementDamage     StockMovementType = "damage"
+	StockMovementTransfer   StockMovementType = "transfer"
+)
+
+type StockMovement struct {
+	MovementId   uuid.UUID         `json:"movement_id" db:"movement_id"`
+	BookId       uuid.UUID         `json:"book_id" db:"book_id"`
+	LocationId   uuid.NullUUID     `json:"location_id" db:"location_id"`
+	MovementType StockMovementType `json:"movement_type" db:"movement_type"`
+	Quantity     int64             `json:"quantity" db:"quantity"`
+	StockAfter   int64             `json:"stock_after" db:"stock_after"`
+	Reason       string            `json:"reason" db:"reason"`
+	Reference    string            `json:"reference" db:"reference"`
+	CreatedBy    uuid.NullUUID     `json:"created_by" db:"created_by"`
+	CreatedAt    time.Time         `json:"created_at" db:"created_at"`
+}
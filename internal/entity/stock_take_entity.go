@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StockTakeStatus string
+
+const (
+	StockTakeOpen      StockTakeStatus = "open"
+	StockTakeApproved  StockTakeStatus = "approved"
+	StockTakeCancelled StockTakeStatus = "cancelled"
+)
+
+type StockTake struct {
+	StockTakeId uuid.UUID       `json:"stock_take_id" db:"stock_take_id"`
+	LocationId  uuid.UUID       `json:"location_id" db:"location_id"`
+	Status      StockTakeStatus `json:"status" db:"status"`
+	Notes       string          `json:"notes" db:"notes"`
+	CreatedBy   uuid.NullUUID   `json:"created_by" db:"created_by"`
+	ApprovedBy  uuid.NullUUID   `json:"approved_by" db:"approved_by"`
+	ApprovedAt  sql.NullTime    `json:"approved_at" db:"approved_at"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// StockTakeLine adalah hasil hitung satu buku. Expected adalah stok saat stock take dimulai,
+// Counted yang tidak Valid berarti buku belum dihitung. Title dan ISBN diisi dari tabel books
+// saat dibaca untuk laporan
+type StockTakeLine struct {
+	StockTakeId uuid.UUID     `json:"stock_take_id" db:"stock_take_id"`
+	BookId      uuid.UUID     `json:"book_id" db:"book_id"`
+	Title       string        `json:"title" db:"title"`
+	ISBN        string        `json:"isbn" db:"isbn"`
+	Expected    int64         `json:"expected" db:"expected"`
+	Counted     sql.NullInt64 `json:"counted" db:"counted"`
+	CountedBy   uuid.NullUUID `json:"counted_by" db:"counted_by"`
+	CountedAt   sql.NullTime  `json:"counted_at" db:"counted_at"`
+}
+
+// StockTakeSummary adalah ringkasan hasil hitung sebuah stock take
+type StockTakeSummary struct {
+	TotalBooks       int64 `json:"total_books" db:"total_books"`
+	CountedBooks     int64 `json:"counted_books" db:"counted_books"`
+	DiscrepancyBooks int64 `json:"discrepancy_books" db:"discrepancy_books"`
+	NetVariance      int64 `json:"net_variance" db:"net_variance"`
+}
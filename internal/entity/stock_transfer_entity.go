@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StockTransferStatus string
+
+const (
+	StockTransferRequested StockTransferStatus = "requested"
+	StockTransferInTransit StockTransferStatus = "in_transit"
+	StockTransferReceived  StockTransferStatus = "received"
+	StockTransferCancelled StockTransferStatus = "cancelled"
+)
+
+type StockTransfer struct {
+	TransferId     uuid.UUID           `json:"transfer_id" db:"transfer_id"`
+	FromLocationId uuid.UUID           `json:"from_location_id" db:"from_location_id"`
+	ToLocationId   uuid.UUID           `json:"to_location_id" db:"to_location_id"`
+	Status         StockTransferStatus `json:"status" db:"status"`
+	Notes          string              `json:"notes" db:"notes"`
+	RequestedBy    uuid.NullUUID       `json:"requested_by" db:"requested_by"`
+	DispatchedBy   uuid.NullUUID       `json:"dispatched_by" db:"dispatched_by"`
+	DispatchedAt   sql.NullTime        `json:"dispatched_at" db:"dispatched_at"`
+	ReceivedBy     uuid.NullUUID       `json:"received_by" db:"received_by"`
+	ReceivedAt     sql.NullTime        `json:"received_at" db:"received_at"`
+	CreatedAt      time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+type StockTransferItem struct {
+	TransferItemId uuid.UUID `json:"transfer_item_id" db:"transfer_item_id"`
+	TransferId     uuid.UUID `json:"transfer_id" db:"transfer_id"`
+	BookId         uuid.UUID `json:"book_id" db:"book_id"`
+	Quantity       int64     `json:"quantity" db:"quantity"`
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Supplier struct {
+	SupplierId   uuid.UUID `json:"supplier_id" db:"supplier_id"`
+	Name         string    `json:"name" db:"name"`
+	ContactName  string    `json:"contact_name" db:"contact_name"`
+	Email        string    `json:"email" db:"email"`
+	Phone        string    `json:"phone" db:"phone"`
+	Address      string    `json:"address" db:"address"`
+	LeadTimeDays int32     `json:"lead_time_days" db:"lead_time_days"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type User struct {
+	UserId       uuid.UUID `json:"user_id" db:"user_id"`
+	Username     string    `json:"username" db:"username"`
+	FullName     string    `json:"full_name" db:"full_name"`
+	PasswordHash string    `json:"-" db:"password_hash"`
+	RoleId       int64     `json:"role_id" db:"role_id"`
+	IsActive     bool      `json:"is_active" db:"is_active"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type RefreshToken struct {
+	TokenId    uuid.UUID     `json:"token_id" db:"token_id"`
+	UserId     uuid.UUID     `json:"user_id" db:"user_id"`
+	ExpiresAt  time.Time     `json:"expires_at" db:"expires_at"`
+	RevokedAt  sql.NullTime  `json:"revoked_at" db:"revoked_at"`
+	ReplacedBy uuid.NullUUID `json:"replaced_by" db:"replaced_by"`
+	CreatedAt  time.Time     `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,246 @@
+// Package export menulis data tabular baris per baris dalam format CSV, JSON Lines dan XLSX
+// sehingga data dapat di-stream tanpa memuat seluruh isi tabel ke memori
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/xuri/excelize/v2"
+)
+
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+	FormatXLSX  Format = "xlsx"
+)
+
+// ContentType mengembalikan MIME type untuk format export
+func (f Format) ContentType() string {
+	switch f {
+	case FormatJSONL:
+		return "application/x-ndjson"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	return "text/csv; charset=utf-8"
+}
+
+// Writer menulis satu baris data setiap kali Write dipanggil. Urutan nilai dalam row
+// harus sama dengan urutan columns yang diberikan ke NewWriter. Close wajib dipanggil
+// agar sisa data ditulis ke io.Writer tujuan
+type Writer interface {
+	Write(row []any) error
+	Close() error
+}
+
+// NewWriter membuat Writer sesuai format. Nilai yang didukung adalah string, bilangan,
+// bool, time.Time dan tipe yang mengimplementasikan fmt.Stringer
+func NewWriter(format Format, w io.Writer, columns []string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, columns)
+	case FormatJSONL:
+		return &jsonlWriter{w: w, columns: columns}, nil
+	case FormatXLSX:
+		return newXLSXWriter(w, columns)
+	}
+
+	return nil, fmt.Errorf("unsupported export format %q", format)
+}
+
+// formatValue mengubah nilai menjadi teks untuk format yang tidak memiliki tipe data
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case time.Time:
+		if isDateOnly(v) {
+			return v.Format(time.DateOnly)
+		}
+		return v.Format(time.RFC3339)
+	case fmt.Stringer:
+		return v.String()
+	}
+
+	return fmt.Sprint(v)
+}
+
+// isDateOnly mengecek apakah waktu hanya berisi tanggal, seperti kolom bertipe DATE
+func isDateOnly(t time.Time) bool {
+	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, columns []string) (*csvWriter, error) {
+	cw := &csvWriter{csv.NewWriter(w)}
+	if err := cw.w.Write(columns); err != nil {
+		return nil, err
+	}
+
+	return cw, nil
+}
+
+func (c *csvWriter) Write(row []any) error {
+	record := make([]string, len(row))
+	for i, v := range row {
+		record[i] = formatValue(v)
+	}
+
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type jsonlWriter struct {
+	w       io.Writer
+	columns []string
+	buf     bytes.Buffer
+}
+
+// Write menulis satu objek JSON per baris dengan urutan key sesuai columns
+func (j *jsonlWriter) Write(row []any) error {
+	j.buf.Reset()
+	j.buf.WriteByte('{')
+
+	for i, v := range row {
+		if i > 0 {
+			j.buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(j.columns[i])
+		if err != nil {
+			return err
+		}
+
+		switch t := v.(type) {
+		case time.Time:
+			v = formatValue(t)
+		case fmt.Stringer:
+			v = t.String()
+		}
+
+		value, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		j.buf.Write(key)
+		j.buf.WriteByte(':')
+		j.buf.Write(value)
+	}
+
+	j.buf.WriteString("}\n")
+	_, err := j.w.Write(j.buf.Bytes())
+	return err
+}
+
+func (j *jsonlWriter) Close() error {
+	return nil
+}
+
+// xlsxWriter memakai StreamWriter excelize yang menyimpan baris ke file sementara,
+// sehingga pemakaian memori tetap kecil untuk katalog yang besar
+type xlsxWriter struct {
+	w             io.Writer
+	file          *excelize.File
+	stream        *excelize.StreamWriter
+	dateStyle     int
+	datetimeStyle int
+	row           int
+}
+
+func newXLSXWriter(w io.Writer, columns []string) (*xlsxWriter, error) {
+	x := &xlsxWriter{w: w, file: excelize.NewFile(), row: 1}
+	if err := x.init(columns); err != nil {
+		x.file.Close()
+		return nil, err
+	}
+
+	return x, nil
+}
+
+func (x *xlsxWriter) init(columns []string) error {
+	err := x.file.SetSheetName("Sheet1", "Export")
+	if err != nil {
+		return err
+	}
+
+	x.stream, err = x.file.NewStreamWriter("Export")
+	if err != nil {
+		return err
+	}
+
+	dateFormat, datetimeFormat := "yyyy-mm-dd", "yyyy-mm-dd hh:mm:ss"
+	x.dateStyle, err = x.file.NewStyle(&excelize.Style{CustomNumFmt: &dateFormat})
+	if err != nil {
+		return err
+	}
+
+	x.datetimeStyle, err = x.file.NewStyle(&excelize.Style{CustomNumFmt: &datetimeFormat})
+	if err != nil {
+		return err
+	}
+
+	header := make([]any, len(columns))
+	for i, column := range columns {
+		header[i] = column
+	}
+
+	return x.stream.SetRow("A1", header)
+}
+
+func (x *xlsxWriter) Write(row []any) error {
+	cells := make([]any, len(row))
+	for i, v := range row {
+		switch v := v.(type) {
+		case time.Time:
+			style := x.datetimeStyle
+			if isDateOnly(v) {
+				style = x.dateStyle
+			}
+			cells[i] = excelize.Cell{StyleID: style, Value: v}
+		case fmt.Stringer:
+			cells[i] = v.String()
+		default:
+			cells[i] = v
+		}
+	}
+
+	x.row++
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+
+	return x.stream.SetRow(cell, cells)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+
+	_, err := x.file.WriteTo(x.w)
+	return err
+}
@@ -0,0 +1,104 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+)
+
+var (
+	testColumns = []string{"book_id", "title", "stock", "price", "published_at", "created_at"}
+	testRow     = []any{
+		uuid.MustParse("0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"),
+		`Laskar "Pelangi", Edisi 2`,
+		int64(12),
+		decimal.RequireFromString("89000.50"),
+		time.Date(2005, 9, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 7, 1, 8, 30, 15, 0, time.UTC),
+	}
+)
+
+func writeAll(t *testing.T, format Format) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(format, &buf, testColumns)
+	if err != nil {
+		t.Fatalf("NewWriter(%s) unexpected error: %v", format, err)
+	}
+
+	if err := writer.Write(testRow); err != nil {
+		t.Fatalf("Write unexpected error: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close unexpected error: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestTextWriters(t *testing.T) {
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{
+			format: FormatCSV,
+			want: "book_id,title,stock,price,published_at,created_at\n" +
+				`0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b,"Laskar ""Pelangi"", Edisi 2",12,89000.5,2005-09-01,2025-07-01T08:30:15Z` + "\n",
+		},
+		{
+			format: FormatJSONL,
+			want: `{"book_id":"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b","title":"Laskar \"Pelangi\", Edisi 2","stock":12,` +
+				`"price":"89000.5","published_at":"2005-09-01","created_at":"2025-07-01T08:30:15Z"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			got := string(writeAll(t, tt.format))
+			if got != tt.want {
+				t.Errorf("output mismatch\n got: %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXLSXWriter(t *testing.T) {
+	file, err := excelize.OpenReader(bytes.NewReader(writeAll(t, FormatXLSX)))
+	if err != nil {
+		t.Fatalf("output is not a valid xlsx file: %v", err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows("Export")
+	if err != nil {
+		t.Fatalf("GetRows unexpected error: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want header and one data row", len(rows))
+	}
+
+	for i, column := range testColumns {
+		if rows[0][i] != column {
+			t.Errorf("header %d = %q, want %q", i, rows[0][i], column)
+		}
+	}
+
+	want := []string{"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b", `Laskar "Pelangi", Edisi 2`, "12", "89000.5", "2005-09-01", "2025-07-01 08:30:15"}
+	for i, value := range want {
+		if rows[1][i] != value {
+			t.Errorf("cell %d = %q, want %q", i, rows[1][i], value)
+		}
+	}
+}
+
+func TestNewWriterUnsupportedFormat(t *testing.T) {
+	if _, err := NewWriter("xml", &bytes.Buffer{}, testColumns); err == nil {
+		t.Error("NewWriter(xml) expected an error")
+	}
+}
@@ -3,6 +3,8 @@ package handler
 import (
 	"github.com/crazydw4rf/book-stock-manager/internal/config"
 	"github.com/crazydw4rf/book-stock-manager/internal/controller"
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/middleware"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -13,15 +15,207 @@ const (
 	BOOK_GETMANY_ROUTE   = config.BASE_API_HTTP_PATH + "/books"
 	BOOK_UPDATE_ROUTE    = config.BASE_API_HTTP_PATH + "/books"
 	BOOK_DELETE_ROUTE    = config.BASE_API_HTTP_PATH + "/books/:book_id"
+	BOOK_QRCODE_ROUTE    = config.BASE_API_HTTP_PATH + "/books/:book_id/qr"
+	BOOK_LABELS_ROUTE    = config.BASE_API_HTTP_PATH + "/books/labels"
+	BOOK_IMPORT_ROUTE    = config.BASE_API_HTTP_PATH + "/books/import"
+	BOOK_EXPORT_ROUTE    = config.BASE_API_HTTP_PATH + "/books/export"
+	BOOK_LOW_STOCK_ROUTE = config.BASE_API_HTTP_PATH + "/books/low-stock"
+	BOOK_PRICES_ROUTE    = config.BASE_API_HTTP_PATH + "/books/:book_id/prices"
+
+	STOCK_MOVEMENT_CREATE_ROUTE  = config.BASE_API_HTTP_PATH + "/books/:book_id/stock/movements"
+	STOCK_MOVEMENT_GETMANY_ROUTE = config.BASE_API_HTTP_PATH + "/books/:book_id/stock/movements"
+	STOCK_ADJUST_ROUTE           = config.BASE_API_HTTP_PATH + "/books/:book_id/stock\\:adjust"
+	STOCK_LOCATIONS_ROUTE        = config.BASE_API_HTTP_PATH + "/books/:book_id/stock/locations"
+
+	LOCATION_CREATE_ROUTE  = config.BASE_API_HTTP_PATH + "/locations"
+	LOCATION_GETBYID_ROUTE = config.BASE_API_HTTP_PATH + "/locations/:location_id"
+	LOCATION_GETMANY_ROUTE = config.BASE_API_HTTP_PATH + "/locations"
+	LOCATION_UPDATE_ROUTE  = config.BASE_API_HTTP_PATH + "/locations/:location_id"
+	LOCATION_DELETE_ROUTE  = config.BASE_API_HTTP_PATH + "/locations/:location_id"
+
+	TRANSFER_CREATE_ROUTE   = config.BASE_API_HTTP_PATH + "/transfers"
+	TRANSFER_GETBYID_ROUTE  = config.BASE_API_HTTP_PATH + "/transfers/:transfer_id"
+	TRANSFER_GETMANY_ROUTE  = config.BASE_API_HTTP_PATH + "/transfers"
+	TRANSFER_DISPATCH_ROUTE = config.BASE_API_HTTP_PATH + "/transfers/:transfer_id/dispatch"
+	TRANSFER_RECEIVE_ROUTE  = config.BASE_API_HTTP_PATH + "/transfers/:transfer_id/receive"
+	TRANSFER_CANCEL_ROUTE   = config.BASE_API_HTTP_PATH + "/transfers/:transfer_id/cancel"
+
+	STOCK_TAKE_CREATE_ROUTE  = config.BASE_API_HTTP_PATH + "/stock-takes"
+	STOCK_TAKE_GETBYID_ROUTE = config.BASE_API_HTTP_PATH + "/stock-takes/:stock_take_id"
+	STOCK_TAKE_GETMANY_ROUTE = config.BASE_API_HTTP_PATH + "/stock-takes"
+	STOCK_TAKE_LINES_ROUTE   = config.BASE_API_HTTP_PATH + "/stock-takes/:stock_take_id/lines"
+	STOCK_TAKE_COUNT_ROUTE   = config.BASE_API_HTTP_PATH + "/stock-takes/:stock_take_id/counts"
+	STOCK_TAKE_APPROVE_ROUTE = config.BASE_API_HTTP_PATH + "/stock-takes/:stock_take_id/approve"
+	STOCK_TAKE_CANCEL_ROUTE  = config.BASE_API_HTTP_PATH + "/stock-takes/:stock_take_id/cancel"
+
+	RESERVATION_CREATE_ROUTE  = config.BASE_API_HTTP_PATH + "/reservations"
+	RESERVATION_GETBYID_ROUTE = config.BASE_API_HTTP_PATH + "/reservations/:reservation_id"
+	RESERVATION_GETMANY_ROUTE = config.BASE_API_HTTP_PATH + "/reservations"
+	RESERVATION_CANCEL_ROUTE  = config.BASE_API_HTTP_PATH + "/reservations/:reservation_id/cancel"
+	RESERVATION_SALE_ROUTE    = config.BASE_API_HTTP_PATH + "/reservations/:reservation_id/sale"
+
+	BACKORDER_CREATE_ROUTE   = config.BASE_API_HTTP_PATH + "/backorders"
+	BACKORDER_GETBYID_ROUTE  = config.BASE_API_HTTP_PATH + "/backorders/:backorder_id"
+	BACKORDER_GETMANY_ROUTE  = config.BASE_API_HTTP_PATH + "/backorders"
+	BACKORDER_CANCEL_ROUTE   = config.BASE_API_HTTP_PATH + "/backorders/:backorder_id/cancel"
+	BACKORDER_ALLOCATE_ROUTE = config.BASE_API_HTTP_PATH + "/backorders/allocate"
+
+	AUTH_LOGIN_ROUTE   = config.BASE_API_HTTP_PATH + "/auth/login"
+	AUTH_REFRESH_ROUTE = config.BASE_API_HTTP_PATH + "/auth/refresh"
+	AUTH_LOGOUT_ROUTE  = config.BASE_API_HTTP_PATH + "/auth/logout"
+
+	USER_CREATE_ROUTE = config.BASE_API_HTTP_PATH + "/users"
+
+	SALE_CREATE_ROUTE  = config.BASE_API_HTTP_PATH + "/sales"
+	SALE_GETBYID_ROUTE = config.BASE_API_HTTP_PATH + "/sales/:sale_id"
+	SALE_GETMANY_ROUTE = config.BASE_API_HTTP_PATH + "/sales"
+	SALE_PREVIEW_ROUTE = config.BASE_API_HTTP_PATH + "/sales/preview"
+	SALE_RETURN_ROUTE  = config.BASE_API_HTTP_PATH + "/sales/:sale_id/returns"
+	SALE_RECEIPT_ROUTE = config.BASE_API_HTTP_PATH + "/sales/:sale_id/receipt"
+
+	SCAN_ROUTE = config.BASE_API_HTTP_PATH + "/scan"
+
+	SUPPLIER_CREATE_ROUTE  = config.BASE_API_HTTP_PATH + "/suppliers"
+	SUPPLIER_GETBYID_ROUTE = config.BASE_API_HTTP_PATH + "/suppliers/:supplier_id"
+	SUPPLIER_GETMANY_ROUTE = config.BASE_API_HTTP_PATH + "/suppliers"
+	SUPPLIER_UPDATE_ROUTE  = config.BASE_API_HTTP_PATH + "/suppliers/:supplier_id"
+	SUPPLIER_DELETE_ROUTE  = config.BASE_API_HTTP_PATH + "/suppliers/:supplier_id"
+
+	PURCHASE_ORDER_CREATE_ROUTE        = config.BASE_API_HTTP_PATH + "/purchase-orders"
+	PURCHASE_ORDER_GETBYID_ROUTE       = config.BASE_API_HTTP_PATH + "/purchase-orders/:purchase_order_id"
+	PURCHASE_ORDER_GETMANY_ROUTE       = config.BASE_API_HTTP_PATH + "/purchase-orders"
+	PURCHASE_ORDER_UPDATE_ROUTE        = config.BASE_API_HTTP_PATH + "/purchase-orders/:purchase_order_id"
+	PURCHASE_ORDER_UPDATE_STATUS_ROUTE = config.BASE_API_HTTP_PATH + "/purchase-orders/:purchase_order_id/status"
+	PURCHASE_ORDER_DELETE_ROUTE        = config.BASE_API_HTTP_PATH + "/purchase-orders/:purchase_order_id"
+	PURCHASE_ORDER_RECEIVE_ROUTE       = config.BASE_API_HTTP_PATH + "/purchase-orders/:purchase_order_id/receipts"
+	PURCHASE_ORDER_REORDER_ROUTE       = config.BASE_API_HTTP_PATH + "/purchase-orders/reorder"
+
+	PROMOTION_CREATE_ROUTE  = config.BASE_API_HTTP_PATH + "/promotions"
+	PROMOTION_GETBYID_ROUTE = config.BASE_API_HTTP_PATH + "/promotions/:promotion_id"
+	PROMOTION_GETMANY_ROUTE = config.BASE_API_HTTP_PATH + "/promotions"
+	PROMOTION_UPDATE_ROUTE  = config.BASE_API_HTTP_PATH + "/promotions/:promotion_id"
+	PROMOTION_DELETE_ROUTE  = config.BASE_API_HTTP_PATH + "/promotions/:promotion_id"
 )
 
-func SetupBookHandler(app *fiber.App, ctrl *controller.BookController) *fiber.App {
-	app.Post(BOOK_CREATE_ROUTE, ctrl.BookCreate)
-	app.Get(BOOK_GETBYID_ROUTE, ctrl.GetBookByID)
-	app.Get(BOOK_GETBYISBN_ROUTE, ctrl.GetBookByISBN)
-	app.Get(BOOK_GETMANY_ROUTE, ctrl.GetBooks)
-	app.Patch(BOOK_UPDATE_ROUTE, ctrl.Update)
-	app.Delete(BOOK_DELETE_ROUTE, ctrl.Delete)
+func SetupBookHandler(app *fiber.App, ctrl *controller.BookController, auth *middleware.AuthMiddleware) *fiber.App {
+	app.Post(BOOK_CREATE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionBooksWrite), ctrl.BookCreate)
+	// rute statis harus didaftarkan sebelum /books/:book_id
+	app.Get(BOOK_EXPORT_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionBooksRead), ctrl.ExportBooks)
+	app.Get(BOOK_LOW_STOCK_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionBooksRead), ctrl.GetLowStockBooks)
+	app.Get(BOOK_GETBYID_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionBooksRead), ctrl.GetBookByID)
+	app.Get(BOOK_GETBYISBN_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionBooksRead), ctrl.GetBookByISBN)
+	app.Get(BOOK_GETMANY_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionBooksRead), ctrl.GetBooks)
+	app.Patch(BOOK_UPDATE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionBooksWrite), ctrl.Update)
+	app.Delete(BOOK_DELETE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionBooksWrite), ctrl.Delete)
+	app.Get(BOOK_QRCODE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionBooksRead), ctrl.GetBookQRCode)
+	app.Get(BOOK_PRICES_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionBooksRead), ctrl.GetBookPriceHistory)
+	app.Post(BOOK_LABELS_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionBooksRead), ctrl.GetLabelSheet)
+	app.Post(BOOK_IMPORT_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionBooksWrite), ctrl.ImportBooks)
 
 	return app
 }
+
+func SetupStockHandler(app *fiber.App, ctrl *controller.StockController, auth *middleware.AuthMiddleware) {
+	app.Post(STOCK_MOVEMENT_CREATE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionStockAdjust), ctrl.CreateMovement)
+	app.Get(STOCK_MOVEMENT_GETMANY_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionStockRead), ctrl.GetMovements)
+	app.Post(STOCK_ADJUST_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionStockAdjust), ctrl.AdjustStock)
+	app.Get(STOCK_LOCATIONS_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionStockRead), ctrl.GetLocationStock)
+}
+
+func SetupLocationHandler(app *fiber.App, ctrl *controller.LocationController, auth *middleware.AuthMiddleware) {
+	app.Post(LOCATION_CREATE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionLocationsWrite), ctrl.Create)
+	app.Get(LOCATION_GETBYID_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionLocationsRead), ctrl.GetByID)
+	app.Get(LOCATION_GETMANY_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionLocationsRead), ctrl.GetMany)
+	app.Patch(LOCATION_UPDATE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionLocationsWrite), ctrl.Update)
+	app.Delete(LOCATION_DELETE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionLocationsWrite), ctrl.Delete)
+}
+
+func SetupTransferHandler(app *fiber.App, ctrl *controller.StockTransferController, auth *middleware.AuthMiddleware) {
+	app.Post(TRANSFER_CREATE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionTransfersWrite), ctrl.Create)
+	app.Get(TRANSFER_GETBYID_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionTransfersRead), ctrl.GetByID)
+	app.Get(TRANSFER_GETMANY_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionTransfersRead), ctrl.GetMany)
+	// pengiriman dan penerimaan mengubah stok sehingga memakai izin yang sama dengan pencatatan stok
+	app.Post(TRANSFER_DISPATCH_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionStockAdjust), ctrl.Dispatch)
+	app.Post(TRANSFER_RECEIVE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionStockAdjust), ctrl.Receive)
+	app.Post(TRANSFER_CANCEL_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionTransfersWrite), ctrl.Cancel)
+}
+
+func SetupStockTakeHandler(app *fiber.App, ctrl *controller.StockTakeController, auth *middleware.AuthMiddleware) {
+	app.Post(STOCK_TAKE_CREATE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionStockTakesManage), ctrl.Create)
+	app.Get(STOCK_TAKE_GETBYID_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionStockTakesRead), ctrl.GetByID)
+	app.Get(STOCK_TAKE_GETMANY_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionStockTakesRead), ctrl.GetMany)
+	app.Get(STOCK_TAKE_LINES_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionStockTakesRead), ctrl.GetLines)
+	app.Post(STOCK_TAKE_COUNT_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionStockTakesCount), ctrl.Count)
+	app.Post(STOCK_TAKE_APPROVE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionStockTakesManage), ctrl.Approve)
+	app.Post(STOCK_TAKE_CANCEL_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionStockTakesManage), ctrl.Cancel)
+}
+
+func SetupReservationHandler(app *fiber.App, ctrl *controller.ReservationController, auth *middleware.AuthMiddleware) {
+	app.Post(RESERVATION_CREATE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionReservationsWrite), ctrl.Create)
+	app.Get(RESERVATION_GETBYID_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionReservationsRead), ctrl.GetByID)
+	app.Get(RESERVATION_GETMANY_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionReservationsRead), ctrl.GetMany)
+	app.Post(RESERVATION_CANCEL_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionReservationsWrite), ctrl.Cancel)
+	app.Post(RESERVATION_SALE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionSalesCreate), ctrl.CreateSale)
+}
+
+func SetupBackorderHandler(app *fiber.App, ctrl *controller.BackorderController, auth *middleware.AuthMiddleware) {
+	app.Post(BACKORDER_CREATE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionBackordersWrite), ctrl.Create)
+	app.Post(BACKORDER_ALLOCATE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionBackordersWrite), ctrl.Allocate)
+	app.Get(BACKORDER_GETBYID_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionBackordersRead), ctrl.GetByID)
+	app.Get(BACKORDER_GETMANY_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionBackordersRead), ctrl.GetMany)
+	app.Post(BACKORDER_CANCEL_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionBackordersWrite), ctrl.Cancel)
+}
+
+func SetupAuthHandler(app *fiber.App, authCtrl *controller.AuthController, userCtrl *controller.UserController, auth *middleware.AuthMiddleware) {
+	app.Post(AUTH_LOGIN_ROUTE, authCtrl.Login)
+	app.Post(AUTH_REFRESH_ROUTE, authCtrl.Refresh)
+	app.Post(AUTH_LOGOUT_ROUTE, authCtrl.Logout)
+
+	app.Post(USER_CREATE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionUsersManage), userCtrl.UserCreate)
+}
+
+func SetupSaleHandler(app *fiber.App, ctrl *controller.SaleController, returnCtrl *controller.SaleReturnController, auth *middleware.AuthMiddleware) {
+	app.Post(SALE_CREATE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionSalesCreate), ctrl.SaleCreate)
+	app.Post(SALE_PREVIEW_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionSalesCreate), ctrl.SalePreview)
+	app.Get(SALE_GETBYID_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionSalesRead), ctrl.GetSaleByID)
+	app.Get(SALE_GETMANY_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionSalesRead), ctrl.GetSales)
+	app.Get(SALE_RECEIPT_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionSalesRead), ctrl.GetSaleReceipt)
+	app.Post(SALE_RETURN_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionSalesReturn), returnCtrl.Create)
+	app.Get(SALE_RETURN_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionSalesRead), returnCtrl.GetBySaleID)
+}
+
+func SetupScanHandler(app *fiber.App, ctrl *controller.ScanController, auth *middleware.AuthMiddleware) {
+	app.Post(SCAN_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionBooksRead), ctrl.Scan)
+}
+
+func SetupPurchasingHandler(
+	app *fiber.App,
+	supplierCtrl *controller.SupplierController,
+	orderCtrl *controller.PurchaseOrderController,
+	receivingCtrl *controller.ReceivingController,
+	auth *middleware.AuthMiddleware,
+) {
+	app.Post(SUPPLIER_CREATE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionPurchasingWrite), supplierCtrl.Create)
+	app.Get(SUPPLIER_GETBYID_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionPurchasingRead), supplierCtrl.GetByID)
+	app.Get(SUPPLIER_GETMANY_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionPurchasingRead), supplierCtrl.GetMany)
+	app.Patch(SUPPLIER_UPDATE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionPurchasingWrite), supplierCtrl.Update)
+	app.Delete(SUPPLIER_DELETE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionPurchasingWrite), supplierCtrl.Delete)
+
+	app.Post(PURCHASE_ORDER_REORDER_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionPurchasingWrite), orderCtrl.Reorder)
+	app.Post(PURCHASE_ORDER_CREATE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionPurchasingWrite), orderCtrl.Create)
+	app.Get(PURCHASE_ORDER_GETBYID_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionPurchasingRead), orderCtrl.GetByID)
+	app.Get(PURCHASE_ORDER_GETMANY_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionPurchasingRead), orderCtrl.GetMany)
+	app.Put(PURCHASE_ORDER_UPDATE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionPurchasingWrite), orderCtrl.Update)
+	app.Patch(PURCHASE_ORDER_UPDATE_STATUS_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionPurchasingWrite), orderCtrl.UpdateStatus)
+	app.Delete(PURCHASE_ORDER_DELETE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionPurchasingWrite), orderCtrl.Delete)
+	// penerimaan barang mengubah stok sehingga memakai izin yang sama dengan pencatatan stok masuk
+	app.Post(PURCHASE_ORDER_RECEIVE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionStockAdjust), receivingCtrl.Receive)
+}
+
+func SetupPromotionHandler(app *fiber.App, ctrl *controller.PromotionController, auth *middleware.AuthMiddleware) {
+	app.Post(PROMOTION_CREATE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionPromotionsWrite), ctrl.Create)
+	app.Get(PROMOTION_GETBYID_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionPromotionsRead), ctrl.GetByID)
+	app.Get(PROMOTION_GETMANY_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionPromotionsRead), ctrl.GetMany)
+	app.Put(PROMOTION_UPDATE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionPromotionsWrite), ctrl.Update)
+	app.Delete(PROMOTION_DELETE_ROUTE, auth.Authenticate, auth.RequirePermission(entity.PermissionPromotionsWrite), ctrl.Delete)
+}
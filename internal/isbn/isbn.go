@@ -0,0 +1,94 @@
+// Package isbn berisi fungsi bantu untuk membersihkan dan memvalidasi ISBN-10 dan ISBN-13
+package isbn
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidISBN dikembalikan Normalize jika input bukan ISBN-10 atau ISBN-13 yang valid
+var ErrInvalidISBN = errors.New("invalid ISBN")
+
+// Clean menghapus tanda hubung dan spasi dari ISBN, serta menyeragamkan check digit X menjadi huruf besar
+func Clean(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.NewReplacer("-", "", " ", "").Replace(s)
+	return strings.ToUpper(s)
+}
+
+// IsValidISBN10 mengecek panjang dan check digit ISBN-10 yang sudah dibersihkan
+func IsValidISBN10(s string) bool {
+	if len(s) != 10 {
+		return false
+	}
+
+	sum := 0
+	for i := range 10 {
+		var digit int
+		switch {
+		case s[i] >= '0' && s[i] <= '9':
+			digit = int(s[i] - '0')
+		case s[i] == 'X' && i == 9:
+			digit = 10
+		default:
+			return false
+		}
+
+		sum += digit * (10 - i)
+	}
+
+	return sum%11 == 0
+}
+
+// IsValidISBN13 mengecek panjang, prefix EAN bookland (978/979) dan check digit ISBN-13 yang sudah dibersihkan
+func IsValidISBN13(s string) bool {
+	if len(s) != 13 || !(strings.HasPrefix(s, "978") || strings.HasPrefix(s, "979")) {
+		return false
+	}
+
+	sum := 0
+	for i := range 13 {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+
+		digit := int(s[i] - '0')
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	return sum%10 == 0
+}
+
+// Normalize mengubah ISBN-10 atau ISBN-13 dengan format apapun menjadi ISBN-13 kanonik tanpa tanda hubung,
+// misalnya "0-306-40615-2" dan "978-0-306-40615-7" sama-sama menjadi "9780306406157"
+func Normalize(s string) (string, error) {
+	s = Clean(s)
+
+	switch {
+	case IsValidISBN13(s):
+		return s, nil
+	case IsValidISBN10(s):
+		return toISBN13(s), nil
+	}
+
+	return "", ErrInvalidISBN
+}
+
+// toISBN13 mengkonversi ISBN-10 yang valid menjadi ISBN-13 dengan prefix 978 dan check digit baru
+func toISBN13(s string) string {
+	prefix := "978" + s[:9]
+
+	sum := 0
+	for i := range 12 {
+		digit := int(prefix[i] - '0')
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	return prefix + string(rune('0'+(10-sum%10)%10))
+}
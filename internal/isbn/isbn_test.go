@@ -0,0 +1,43 @@
+package isbn
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "isbn-13 with hyphens", input: "978-0-306-40615-7", want: "9780306406157"},
+		{name: "isbn-13 with spaces", input: " 978 0 306 40615 7 ", want: "9780306406157"},
+		{name: "isbn-10 converted", input: "0-306-40615-2", want: "9780306406157"},
+		{name: "isbn-10 with lowercase x check digit", input: "0-8044-2957-x", want: "9780804429573"},
+		{name: "979 prefix", input: "979-10-90636-07-1", want: "9791090636071"},
+		{name: "isbn-13 wrong check digit", input: "9780306406158", wantErr: true},
+		{name: "isbn-10 wrong check digit", input: "0306406153", wantErr: true},
+		{name: "ean without bookland prefix", input: "4006381333931", wantErr: true},
+		{name: "x not in check digit position", input: "03064X6152", wantErr: true},
+		{name: "wrong length", input: "978030640615", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.input)
+			if tt.wantErr {
+				if err != ErrInvalidISBN {
+					t.Fatalf("Normalize(%q) error = %v, want ErrInvalidISBN", tt.input, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Normalize(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Package label membuat lembar label rak buku berukuran A4 dalam format PDF.
+// Setiap label berisi QR code, judul, penulis, harga dan ISBN buku
+package label
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/qrcode"
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	DefaultColumns = 3
+	DefaultRows    = 8
+	MaxColumns     = 6
+	MaxRows        = 20
+
+	pageWidth  = 210.0
+	pageHeight = 297.0
+	pageMargin = 8.0
+	cellPad    = 2.0
+)
+
+type Label struct {
+	Title     string
+	Author    string
+	ISBN      string
+	Price     string
+	QRContent string
+}
+
+// Layout menentukan jumlah kolom dan baris label dalam satu halaman A4
+type Layout struct {
+	Columns int
+	Rows    int
+}
+
+// RenderSheet menggambar label ke dalam satu atau beberapa halaman A4 lalu menulis PDF ke w
+func RenderSheet(w io.Writer, labels []Label, layout Layout) error {
+	if layout.Columns == 0 {
+		layout.Columns = DefaultColumns
+	}
+	if layout.Rows == 0 {
+		layout.Rows = DefaultRows
+	}
+
+	if layout.Columns < 1 || layout.Columns > MaxColumns || layout.Rows < 1 || layout.Rows > MaxRows {
+		return fmt.Errorf("layout must be between 1x1 and %dx%d", MaxColumns, MaxRows)
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pageMargin, pageMargin, pageMargin)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetTitle("Book labels", true)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	cellWidth := (pageWidth - 2*pageMargin) / float64(layout.Columns)
+	cellHeight := (pageHeight - 2*pageMargin) / float64(layout.Rows)
+	perPage := layout.Columns * layout.Rows
+
+	for i, lbl := range labels {
+		if i%perPage == 0 {
+			pdf.AddPage()
+		}
+
+		pos := i % perPage
+		x := pageMargin + float64(pos%layout.Columns)*cellWidth
+		y := pageMargin + float64(pos/layout.Columns)*cellHeight
+
+		err := drawLabel(pdf, tr, lbl, x, y, cellWidth, cellHeight)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(labels) == 0 {
+		pdf.AddPage()
+	}
+
+	return pdf.Output(w)
+}
+
+func drawLabel(pdf *fpdf.Fpdf, tr func(string) string, lbl Label, x, y, width, height float64) error {
+	// garis potong tipis di sekeliling label
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.SetLineWidth(0.1)
+	pdf.Rect(x, y, width, height, "D")
+
+	qrSide := min(height-2*cellPad, width*0.45)
+	err := qrcode.DrawPDF(pdf, lbl.QRContent, x+cellPad, y+(height-qrSide)/2, qrSide)
+	if err != nil {
+		return err
+	}
+
+	textX := x + 2*cellPad + qrSide
+	textWidth := width - qrSide - 3*cellPad
+	lineHeight := min(4.0, (height-2*cellPad)/4)
+	fontSize := lineHeight * 2
+
+	lines := []struct {
+		text  string
+		style string
+	}{
+		{lbl.Title, "B"},
+		{lbl.Author, ""},
+		{lbl.Price, "B"},
+		{lbl.ISBN, ""},
+	}
+
+	textY := y + cellPad
+	for _, line := range lines {
+		if line.text == "" {
+			continue
+		}
+
+		pdf.SetFont("Helvetica", line.style, fontSize)
+		pdf.SetXY(textX, textY)
+		pdf.CellFormat(textWidth, lineHeight, truncate(pdf, tr(line.text), textWidth), "", 0, "L", false, 0, "")
+		textY += lineHeight
+	}
+
+	return nil
+}
+
+// truncate memotong teks dengan elipsis agar muat di dalam lebar yang tersedia.
+// Teks sudah diterjemahkan ke cp1252 sehingga setiap karakter tepat satu byte
+func truncate(pdf *fpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+
+	for len(text) > 0 && pdf.GetStringWidth(text+"...") > width {
+		text = text[:len(text)-1]
+	}
+
+	return text + "..."
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/config"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// userClaimsKey adalah key fiber.Ctx.Locals untuk menyimpan claims user yang sedang login
+const userClaimsKey = "user_claims"
+
+type AuthMiddleware struct {
+	authUsecase *usecase.AuthUsecase
+}
+
+func NewAuthMiddleware(authUsecase *usecase.AuthUsecase) *AuthMiddleware {
+	return &AuthMiddleware{authUsecase}
+}
+
+// Authenticate memastikan request membawa access token yang valid, baik dari header
+// Authorization dengan skema Bearer maupun dari cookie access token
+func (m AuthMiddleware) Authenticate(c *fiber.Ctx) error {
+	accessToken := c.Cookies(config.ACCESS_TOKEN_COOKIE_NAME)
+
+	header := c.Get(config.ACCESS_TOKEN_HEADER_NAME)
+	if header != "" {
+		scheme, token, found := strings.Cut(header, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") {
+			return fiber.NewError(fiber.StatusUnauthorized, "Invalid authorization header format")
+		}
+
+		accessToken = strings.TrimSpace(token)
+	}
+
+	if accessToken == "" {
+		return fiber.NewError(fiber.StatusUnauthorized, "Access token is required")
+	}
+
+	claims, err := m.authUsecase.VerifyAccessToken(accessToken)
+	if err != nil {
+		return err
+	}
+
+	c.Locals(userClaimsKey, claims)
+
+	return c.Next()
+}
+
+// RequirePermission membatasi route hanya untuk user yang role-nya memiliki permission tertentu.
+// Middleware ini harus dipasang setelah Authenticate
+func (m AuthMiddleware) RequirePermission(permission string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userId := GetUserID(c)
+		if userId == uuid.Nil {
+			return fiber.NewError(fiber.StatusUnauthorized, "Access token is required")
+		}
+
+		err := m.authUsecase.Authorize(c.Context(), userId, permission)
+		if err != nil {
+			return err
+		}
+
+		return c.Next()
+	}
+}
+
+// GetClaims mengembalikan claims user yang sudah diautentikasi oleh Authenticate,
+// atau nil jika route tidak dilindungi middleware tersebut
+func GetClaims(c *fiber.Ctx) *model.AccessTokenClaims {
+	claims, _ := c.Locals(userClaimsKey).(*model.AccessTokenClaims)
+	return claims
+}
+
+// GetUserID mengembalikan ID user yang sedang login, atau uuid.Nil jika tidak ada
+func GetUserID(c *fiber.Ctx) uuid.UUID {
+	claims := GetClaims(c)
+	if claims == nil {
+		return uuid.Nil
+	}
+
+	userId, _ := uuid.Parse(claims.Subject)
+	return userId
+}
@@ -0,0 +1,28 @@
+package model
+
+import "github.com/golang-jwt/jwt/v5"
+
+type LoginRequest struct {
+	Username string `json:"username" validate:"required" example:"admin"`
+	Password string `json:"password" validate:"required" example:"s3cr3t-p4ssw0rd"`
+}
+
+// RefreshTokenRequest dipakai oleh klien non-browser yang tidak menyimpan cookie.
+// Jika refresh_token kosong, token diambil dari cookie refresh token
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
+
+type TokenResponse struct {
+	AccessToken  string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string `json:"refresh_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresIn    int64  `json:"expires_in" example:"900"`
+}
+
+// AccessTokenClaims merepresentasikan isi access token JWT milik user yang sedang login
+type AccessTokenClaims struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	jwt.RegisteredClaims
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/google/uuid"
+)
+
+type BackorderResponse struct {
+	BackorderID     uuid.UUID `json:"backorder_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	BookID          uuid.UUID `json:"book_id" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	LocationID      uuid.UUID `json:"location_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	Kind            string    `json:"kind" example:"preorder"`
+	Status          string    `json:"status" example:"waiting"`
+	Quantity        int64     `json:"quantity" example:"1"`
+	CustomerName    string    `json:"customer_name" example:"Budi Santoso"`
+	CustomerContact string    `json:"customer_contact" example:"0812-3456-7890"`
+	Notes           string    `json:"notes" example:"Signed edition if available"`
+	// QueuePosition hanya terisi selama pesanan masih waiting, 1 berarti pesanan berikutnya yang dilayani
+	QueuePosition *int64     `json:"queue_position,omitempty" example:"3"`
+	ReservationID *uuid.UUID `json:"reservation_id,omitempty" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	CreatedBy     *uuid.UUID `json:"created_by,omitempty" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	AllocatedAt   *time.Time `json:"allocated_at,omitempty" example:"2025-09-25T04:10:00Z"`
+	CreatedAt     time.Time  `json:"created_at" example:"2025-09-22T01:47:05Z"`
+	UpdatedAt     time.Time  `json:"updated_at" example:"2025-09-22T01:47:05Z"`
+}
+
+type CreateBackorderRequest struct {
+	BookID uuid.UUID `json:"book_id" validate:"required" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	// LocationID adalah lokasi tempat buku akan diambil, kosong berarti lokasi default
+	LocationID      *uuid.UUID `json:"location_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	Quantity        int64      `json:"quantity" validate:"required,gt=0,lte=1000" example:"1"`
+	CustomerName    string     `json:"customer_name" validate:"required,max=200" example:"Budi Santoso"`
+	CustomerContact string     `json:"customer_contact" validate:"required,max=200" example:"0812-3456-7890"`
+	Notes           string     `json:"notes" validate:"max=1000" example:"Signed edition if available"`
+}
+
+// BackorderSearchRequest merepresentasikan filter daftar backorder
+type BackorderSearchRequest struct {
+	Status     string `query:"status" validate:"omitempty,oneof=waiting allocated cancelled" example:"waiting"`
+	Kind       string `query:"kind" validate:"omitempty,oneof=backorder preorder" example:"preorder"`
+	BookID     string `query:"book_id" validate:"omitempty,uuid" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	LocationID string `query:"location_id" validate:"omitempty,uuid" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+}
+
+// AllocateBackordersRequest meminta alokasi ulang stok yang tersedia ke antrean sebuah buku,
+// misalnya setelah reservasi hasil alokasi sebelumnya kedaluwarsa
+type AllocateBackordersRequest struct {
+	BookID uuid.UUID `json:"book_id" validate:"required" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	// LocationID kosong berarti lokasi default
+	LocationID *uuid.UUID `json:"location_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+}
+
+// BackorderToResponse mengkonversi entity.Backorder menjadi model BackorderResponse
+func BackorderToResponse(backorder *entity.Backorder) BackorderResponse {
+	response := BackorderResponse{
+		BackorderID:     backorder.BackorderId,
+		BookID:          backorder.BookId,
+		LocationID:      backorder.LocationId,
+		Kind:            string(backorder.Kind),
+		Status:          string(backorder.Status),
+		Quantity:        backorder.Quantity,
+		CustomerName:    backorder.CustomerName,
+		CustomerContact: backorder.CustomerContact,
+		Notes:           backorder.Notes,
+		CreatedAt:       backorder.CreatedAt,
+		UpdatedAt:       backorder.UpdatedAt,
+	}
+
+	if backorder.QueuePosition.Valid {
+		response.QueuePosition = &backorder.QueuePosition.Int64
+	}
+	if backorder.ReservationId.Valid {
+		response.ReservationID = &backorder.ReservationId.UUID
+	}
+	if backorder.CreatedBy.Valid {
+		response.CreatedBy = &backorder.CreatedBy.UUID
+	}
+	if backorder.AllocatedAt.Valid {
+		response.AllocatedAt = &backorder.AllocatedAt.Time
+	}
+
+	return response
+}
+
+// BackordersToResponse mengkonversi daftar entity.Backorder menjadi daftar model BackorderResponse
+func BackordersToResponse(backorders []*entity.Backorder) []BackorderResponse {
+	response := make([]BackorderResponse, len(backorders))
+	for i, backorder := range backorders {
+		response[i] = BackorderToResponse(backorder)
+	}
+
+	return response
+}
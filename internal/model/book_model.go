@@ -4,16 +4,30 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 type BookResponse struct {
 	BookID      uuid.UUID `json:"book_id" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
-	ISBN        string    `json:"isbn" example:"978-3-16-148410-0"`
+	ISBN        string    `json:"isbn" example:"9783161484100"`
 	Title       string    `json:"title" example:"Hujan"`
 	Author      string    `json:"author" example:"Tere Liye"`
 	Publisher   string    `json:"publisher" example:"Gramedia"`
 	PublishedAt time.Time `json:"published_at" example:"2016-01-28"`
-	Stock       int64     `json:"stock" example:"200"`
+	// Stock sama dengan OnHand, dipertahankan untuk klien lama
+	Stock int64 `json:"stock" example:"200"`
+	// OnHand adalah jumlah fisik, Reserved adalah bagian yang ditahan reservasi aktif dan Available
+	// adalah jumlah yang masih bisa dijual
+	OnHand    int64 `json:"on_hand" example:"200"`
+	Reserved  int64 `json:"reserved" example:"3"`
+	Available int64 `json:"available" example:"197"`
+	// buku perlu dipesan ulang jika reorder_quantity > 0 dan stock <= reorder_point
+	ReorderPoint        int64           `json:"reorder_point" example:"20"`
+	ReorderQuantity     int64           `json:"reorder_quantity" example:"50"`
+	PreferredSupplierID *uuid.UUID      `json:"preferred_supplier_id,omitempty" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	CostPrice           decimal.Decimal `json:"cost_price" swaggertype:"string" example:"62000.00"`
+	SalePrice           decimal.Decimal `json:"sale_price" swaggertype:"string" example:"89000.00"`
+	Currency            string          `json:"currency" example:"IDR"`
 }
 
 type CreateBookRequest struct {
@@ -23,6 +37,14 @@ type CreateBookRequest struct {
 	Publisher   string    `json:"publisher" validate:"required" example:"Gramedia"`
 	PublishedAt time.Time `json:"published_at" validate:"required" example:"2016-01-28"`
 	Stock       int64     `json:"stock" validate:"required,gte=0" example:"200"`
+	// ReorderQuantity 0 berarti buku tidak pernah masuk daftar stok menipis
+	ReorderPoint        int64           `json:"reorder_point" validate:"gte=0" example:"20"`
+	ReorderQuantity     int64           `json:"reorder_quantity" validate:"gte=0" example:"50"`
+	PreferredSupplierID *uuid.UUID      `json:"preferred_supplier_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	CostPrice           decimal.Decimal `json:"cost_price" swaggertype:"string" example:"62000.00"`
+	SalePrice           decimal.Decimal `json:"sale_price" swaggertype:"string" example:"89000.00"`
+	// Currency adalah kode mata uang ISO 4217, default IDR
+	Currency string `json:"currency" validate:"omitempty,iso4217" example:"IDR"`
 }
 
 type UpdateBookRequest struct {
@@ -33,4 +55,90 @@ type UpdateBookRequest struct {
 	Publisher   string    `json:"publisher" validate:"omitempty" example:"Gramedia"`
 	PublishedAt time.Time `json:"published_at" validate:"omitempty" example:"2016-01-28"`
 	Stock       int64     `json:"stock" validate:"omitempty,gte=-1" example:"200"`
+	// LocationID adalah lokasi yang stoknya ditetapkan menjadi Stock, kosong berarti lokasi default
+	LocationID *uuid.UUID `json:"location_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	// field pemesanan ulang yang tidak dikirim tidak diubah. ClearPreferredSupplier menghapus
+	// supplier dan tidak boleh dikirim bersama PreferredSupplierID
+	ReorderPoint           *int64           `json:"reorder_point" validate:"omitempty,gte=0" example:"20"`
+	ReorderQuantity        *int64           `json:"reorder_quantity" validate:"omitempty,gte=0" example:"50"`
+	PreferredSupplierID    *uuid.UUID       `json:"preferred_supplier_id" validate:"excluded_with=ClearPreferredSupplier" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	ClearPreferredSupplier bool             `json:"clear_preferred_supplier" example:"false"`
+	CostPrice              *decimal.Decimal `json:"cost_price" swaggertype:"string" example:"62000.00"`
+	SalePrice              *decimal.Decimal `json:"sale_price" swaggertype:"string" example:"89000.00"`
+	Currency               string           `json:"currency" validate:"omitempty,iso4217" example:"IDR"`
+}
+
+// LowStockBookResponse adalah buku yang stoknya sudah mencapai reorder point. OnOrder adalah
+// jumlah yang masih menunggu di purchase order, SuggestedQuantity adalah jumlah yang disarankan
+// untuk dipesan (0 jika pesanan yang berjalan sudah cukup)
+type LowStockBookResponse struct {
+	BookResponse
+	OnOrder           int64 `json:"on_order" example:"0"`
+	SuggestedQuantity int64 `json:"suggested_quantity" example:"50"`
+}
+
+// LowStockSearchRequest merepresentasikan filter daftar buku dengan stok menipis
+type LowStockSearchRequest struct {
+	SupplierID string `query:"supplier_id" validate:"omitempty,uuid" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+}
+
+// BookSearchRequest merepresentasikan parameter kueri untuk pencarian dan filter daftar buku
+type BookSearchRequest struct {
+	Query         string `query:"q" validate:"max=200" example:"tere liye hujan"`
+	Publisher     string `query:"publisher" example:"Gramedia"`
+	Author        string `query:"author" example:"Tere Liye"`
+	PublishedFrom string `query:"published_from" validate:"omitempty,datetime=2006-01-02" example:"2015-01-01"`
+	PublishedTo   string `query:"published_to" validate:"omitempty,datetime=2006-01-02" example:"2020-12-31"`
+	StockMin      *int64 `query:"stock_min" validate:"omitempty,min=0" example:"0"`
+	StockMax      *int64 `query:"stock_max" validate:"omitempty,min=0" example:"10"`
+	Sort          string `query:"sort" validate:"omitempty,oneof=relevance title author publisher published_at stock created_at updated_at" example:"title"`
+	Order         string `query:"order" validate:"omitempty,oneof=asc desc" example:"asc"`
+	// Location berisi ID atau kode lokasi. Jika diisi, stock berisi stok di lokasi tersebut
+	Location string `query:"location" validate:"max=64" example:"main"`
+}
+
+// BookQRCodeRequest merepresentasikan parameter kueri untuk membuat QR code buku
+type BookQRCodeRequest struct {
+	Format  string `query:"format" validate:"omitempty,oneof=png svg" example:"png"`
+	Size    int    `query:"size" validate:"omitempty,min=64,max=2048" example:"256"`
+	Level   string `query:"level" validate:"omitempty,oneof=L M Q H" example:"M"`
+	Content string `query:"content" validate:"omitempty,oneof=id url" example:"id"`
+}
+
+// BookLabelRequest merepresentasikan permintaan lembar label PDF.
+// Buku dipilih berdasarkan book_ids, atau berdasarkan filter publisher/author jika book_ids kosong
+type BookLabelRequest struct {
+	BookIDs   []uuid.UUID `json:"book_ids" validate:"max=1000" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	Publisher string      `json:"publisher" example:"Gramedia"`
+	Author    string      `json:"author" example:"Tere Liye"`
+	Columns   int         `json:"columns" validate:"omitempty,min=1,max=6" example:"3"`
+	Rows      int         `json:"rows" validate:"omitempty,min=1,max=20" example:"8"`
+	Content   string      `json:"content" validate:"omitempty,oneof=id url" example:"id"`
+}
+
+// BookImportRequest merepresentasikan opsi impor buku dari file CSV (multipart form).
+// Mapping berisi objek JSON dari nama field ke nama kolom di header CSV, misalnya {"title":"Judul"}
+type BookImportRequest struct {
+	DryRun  bool   `form:"dry_run" example:"true"`
+	Upsert  bool   `form:"upsert" example:"false"`
+	Mapping string `form:"mapping" example:"{\"title\":\"Judul\",\"stock\":\"Jumlah\"}"`
+}
+
+// BookImportRowResult merepresentasikan hasil impor satu baris CSV.
+// Row adalah nomor baris di file, baris header dihitung sebagai baris 1
+type BookImportRowResult struct {
+	Row    int        `json:"row" example:"2"`
+	ISBN   string     `json:"isbn,omitempty" example:"9783161484100"`
+	Status string     `json:"status" example:"created"`
+	BookID *uuid.UUID `json:"book_id,omitempty" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	Error  string     `json:"error,omitempty" example:"ISBN already exists"`
+}
+
+type BookImportResponse struct {
+	DryRun  bool                  `json:"dry_run" example:"false"`
+	Total   int                   `json:"total" example:"3"`
+	Created int                   `json:"created" example:"1"`
+	Updated int                   `json:"updated" example:"1"`
+	Failed  int                   `json:"failed" example:"1"`
+	Rows    []BookImportRowResult `json:"rows"`
 }
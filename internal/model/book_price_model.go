@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// BookPriceHistoryResponse adalah harga buku pada satu periode. EffectiveTo kosong berarti
+// harga tersebut masih berlaku
+type BookPriceHistoryResponse struct {
+	PriceHistoryID uuid.UUID       `json:"price_history_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	BookID         uuid.UUID       `json:"book_id" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	CostPrice      decimal.Decimal `json:"cost_price" swaggertype:"string" example:"62000.00"`
+	SalePrice      decimal.Decimal `json:"sale_price" swaggertype:"string" example:"89000.00"`
+	Currency       string          `json:"currency" example:"IDR"`
+	EffectiveFrom  time.Time       `json:"effective_from" example:"2025-08-04T03:12:10Z"`
+	EffectiveTo    *time.Time      `json:"effective_to,omitempty" example:"2025-09-01T00:00:00Z"`
+	ChangedBy      *uuid.UUID      `json:"changed_by,omitempty" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+}
+
+// BookPriceHistoryToResponse mengkonversi entity.BookPriceHistory menjadi model BookPriceHistoryResponse
+func BookPriceHistoryToResponse(history *entity.BookPriceHistory) BookPriceHistoryResponse {
+	response := BookPriceHistoryResponse{
+		PriceHistoryID: history.PriceHistoryId,
+		BookID:         history.BookId,
+		CostPrice:      history.CostPrice,
+		SalePrice:      history.SalePrice,
+		Currency:       history.Currency,
+		EffectiveFrom:  history.EffectiveFrom,
+	}
+
+	if history.EffectiveTo.Valid {
+		response.EffectiveTo = &history.EffectiveTo.Time
+	}
+
+	if history.ChangedBy.Valid {
+		response.ChangedBy = &history.ChangedBy.UUID
+	}
+
+	return response
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/google/uuid"
+)
+
+type LocationResponse struct {
+	LocationID uuid.UUID `json:"location_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	Code       string    `json:"code" example:"campus"`
+	Name       string    `json:"name" example:"Campus booth"`
+	Address    string    `json:"address" example:"Gedung Student Center Lt. 1"`
+	IsDefault  bool      `json:"is_default" example:"false"`
+	CreatedAt  time.Time `json:"created_at" example:"2025-08-25T02:11:40Z"`
+	UpdatedAt  time.Time `json:"updated_at" example:"2025-08-25T02:11:40Z"`
+}
+
+type CreateLocationRequest struct {
+	Code    string `json:"code" validate:"required,max=32" example:"campus"`
+	Name    string `json:"name" validate:"required,max=200" example:"Campus booth"`
+	Address string `json:"address" validate:"max=500" example:"Gedung Student Center Lt. 1"`
+	// IsDefault menjadikan lokasi ini lokasi default menggantikan lokasi default sebelumnya
+	IsDefault bool `json:"is_default" example:"false"`
+}
+
+// UpdateLocationRequest merepresentasikan perubahan sebagian data lokasi. Field yang tidak
+// dikirim tidak diubah. is_default hanya bisa bernilai true, status default dipindahkan dengan
+// menjadikan lokasi lain sebagai default
+type UpdateLocationRequest struct {
+	LocationID uuid.UUID `json:"-"`
+	Code       *string   `json:"code" validate:"omitempty,min=1,max=32" example:"campus"`
+	Name       *string   `json:"name" validate:"omitempty,min=1,max=200" example:"Campus booth"`
+	Address    *string   `json:"address" validate:"omitempty,max=500" example:"Gedung Student Center Lt. 1"`
+	IsDefault  *bool     `json:"is_default" example:"true"`
+}
+
+type LocationStockResponse struct {
+	LocationID uuid.UUID `json:"location_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	Code       string    `json:"code" example:"campus"`
+	Name       string    `json:"name" example:"Campus booth"`
+	Quantity   int64     `json:"quantity" example:"12"`
+}
+
+// BookLocationStockResponse adalah rincian stok satu buku per lokasi. Stock adalah total stok
+// di semua lokasi
+type BookLocationStockResponse struct {
+	BookID    uuid.UUID               `json:"book_id" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	Stock     int64                   `json:"stock" example:"200"`
+	Locations []LocationStockResponse `json:"locations"`
+}
+
+// LocationToResponse mengkonversi entity.Location menjadi model LocationResponse
+func LocationToResponse(location *entity.Location) LocationResponse {
+	return LocationResponse{
+		LocationID: location.LocationId,
+		Code:       location.Code,
+		Name:       location.Name,
+		Address:    location.Address,
+		IsDefault:  location.IsDefault,
+		CreatedAt:  location.CreatedAt,
+		UpdatedAt:  location.UpdatedAt,
+	}
+}
+
+// LocationStockToResponse mengkonversi entity.LocationStock menjadi model LocationStockResponse
+func LocationStockToResponse(stock *entity.LocationStock) LocationStockResponse {
+	return LocationStockResponse{
+		LocationID: stock.LocationId,
+		Code:       stock.Code,
+		Name:       stock.Name,
+		Quantity:   stock.Quantity,
+	}
+}
@@ -1,9 +1,12 @@
 package model
 
 import (
-	"strings"
+	"encoding/base64"
+	"errors"
 
 	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
 )
 
 type DataResponse[T any] struct {
@@ -31,20 +34,71 @@ type PaginatedResponse[T any] struct {
 
 // PaginationLinks menyediakan tautan navigasi yang sesuai dengan HATEOAS untuk paginasi
 // Tautan-tautan ini memungkinkan klien untuk menavigasi koleksi tanpa membangun URL secara manual
+// Pada paginasi cursor, Last tidak diisi dan NextCursor/PrevCursor berisi cursor mentah untuk parameter cursor
 type PaginationLinks struct {
-	Self  string `json:"self" example:"/api/v1/books?offset=0&limit=10"`
-	Next  string `json:"next,omitempty" example:"/api/v1/books?offset=10&limit=10"`
-	Prev  string `json:"prev,omitempty" example:"/api/v1/books?offset=0&limit=10"`
-	First string `json:"first" example:"/api/v1/books?offset=0&limit=10"`
-	Last  string `json:"last" example:"/api/v1/books?offset=90&limit=10"`
+	Self       string `json:"self" example:"/api/v1/books?offset=0&limit=10"`
+	Next       string `json:"next,omitempty" example:"/api/v1/books?offset=10&limit=10"`
+	Prev       string `json:"prev,omitempty" example:"/api/v1/books?offset=0&limit=10"`
+	First      string `json:"first" example:"/api/v1/books?offset=0&limit=10"`
+	Last       string `json:"last,omitempty" example:"/api/v1/books?offset=90&limit=10"`
+	NextCursor string `json:"next_cursor,omitempty" example:"eyJpZCI6IjAxOTZmMWEyLTdjM2QtN2U0Zi04YTliLTBjMWQyZTNmNGE1YiIsImJhY2t3YXJkIjpmYWxzZX0"`
+	PrevCursor string `json:"prev_cursor,omitempty" example:"eyJpZCI6IjAxOTZmMWEyLTdjM2QtN2U0Zi04YTliLTBjMWQyZTNmNGE1YiIsImJhY2t3YXJkIjp0cnVlfQ"`
+}
+
+// CursorPaginationMeta merepresentasikan metadata paginasi cursor. Total tidak dihitung
+// agar halaman yang dalam tetap cepat
+type CursorPaginationMeta struct {
+	Limit   int64 `json:"limit" example:"10"`
+	HasNext bool  `json:"has_next" example:"true"`
+	HasPrev bool  `json:"has_prev" example:"false"`
+}
+
+type CursorPaginatedResponse[T any] struct {
+	Data  []T                  `json:"data"`
+	Meta  CursorPaginationMeta `json:"meta"`
+	Links PaginationLinks      `json:"links"`
+}
+
+// CursorPage berisi cursor opaque untuk halaman berikutnya dan sebelumnya.
+// Cursor kosong berarti tidak ada halaman ke arah tersebut
+type CursorPage struct {
+	Next string
+	Prev string
+}
+
+// Cursor adalah posisi keyset pada daftar yang diurutkan berdasarkan ID (UUIDv7).
+// Backward berarti halaman diambil mundur sebelum ID tersebut
+type Cursor struct {
+	ID       uuid.UUID `json:"id"`
+	Backward bool      `json:"backward"`
+}
+
+// Encode mengubah cursor menjadi string opaque yang aman dipakai di URL
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor membaca cursor yang dibuat oleh Cursor.Encode
+func DecodeCursor(s string) (Cursor, error) {
+	var cursor Cursor
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor, err
+	}
+
+	err = json.Unmarshal(b, &cursor)
+	if err == nil && cursor.ID == uuid.Nil {
+		err = errors.New("cursor has no id")
+	}
+
+	return cursor, err
 }
 
 // BookToResponse mengkonversi entity.Book menjadi model BookResponse
-// Fungsi ini menangani semua transformasi data yang diperlukan seperti memotong spasi dari ISBN
 func BookToResponse(book *entity.Book) BookResponse {
-	book.ISBN = strings.TrimSpace(book.ISBN)
-
-	return BookResponse{
+	response := BookResponse{
 		BookID:      book.BookId,
 		ISBN:        book.ISBN,
 		Title:       book.Title,
@@ -52,5 +106,20 @@ func BookToResponse(book *entity.Book) BookResponse {
 		Publisher:   book.Publisher,
 		PublishedAt: book.PublishedAt,
 		Stock:       book.Stock,
+		OnHand:      book.Stock,
+		Reserved:    book.Reserved,
+		// stok fisik bisa lebih kecil dari yang ditahan, misalnya setelah pencatatan buku rusak
+		Available:       max(book.Stock-book.Reserved, 0),
+		ReorderPoint:    book.ReorderPoint,
+		ReorderQuantity: book.ReorderQuantity,
+		CostPrice:       book.CostPrice,
+		SalePrice:       book.SalePrice,
+		Currency:        book.Currency,
 	}
+
+	if book.PreferredSupplierId.Valid {
+		response.PreferredSupplierID = &book.PreferredSupplierId.UUID
+	}
+
+	return response
 }
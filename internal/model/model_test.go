@@ -0,0 +1,45 @@
+package model
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	id := uuid.MustParse("0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b")
+
+	for _, cursor := range []Cursor{{ID: id}, {ID: id, Backward: true}} {
+		encoded := cursor.Encode()
+
+		decoded, err := DecodeCursor(encoded)
+		if err != nil {
+			t.Fatalf("DecodeCursor(%q) unexpected error: %v", encoded, err)
+		}
+		if decoded != cursor {
+			t.Errorf("DecodeCursor(%q) = %+v, want %+v", encoded, decoded, cursor)
+		}
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ""},
+		{name: "not base64", input: "not a cursor!"},
+		{name: "not json", input: base64.RawURLEncoding.EncodeToString([]byte("hello"))},
+		{name: "invalid id", input: base64.RawURLEncoding.EncodeToString([]byte(`{"id":"123"}`))},
+		{name: "missing id", input: base64.RawURLEncoding.EncodeToString([]byte(`{"backward":true}`))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.input); err == nil {
+				t.Errorf("DecodeCursor(%q) expected an error", tt.input)
+			}
+		})
+	}
+}
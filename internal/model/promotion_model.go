@@ -0,0 +1,91 @@
+package model
+
+import (
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type PromotionResponse struct {
+	PromotionID    uuid.UUID       `json:"promotion_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	Name           string          `json:"name" example:"Diskon 10% Gramedia"`
+	RuleType       string          `json:"rule_type" example:"percent_off"`
+	Scope          string          `json:"scope" example:"publisher"`
+	ScopeValue     string          `json:"scope_value" example:"Gramedia Pustaka Utama"`
+	BookIDs        []uuid.UUID     `json:"book_ids,omitempty"`
+	Percent        decimal.Decimal `json:"percent" swaggertype:"string" example:"10.00"`
+	BuyQuantity    int64           `json:"buy_quantity" example:"0"`
+	FreeQuantity   int64           `json:"free_quantity" example:"0"`
+	BundleQuantity int64           `json:"bundle_quantity" example:"0"`
+	BundlePrice    decimal.Decimal `json:"bundle_price" swaggertype:"string" example:"0.00"`
+	MinSubtotal    decimal.Decimal `json:"min_subtotal" swaggertype:"string" example:"0.00"`
+	Priority       int32           `json:"priority" example:"10"`
+	Stackable      bool            `json:"stackable" example:"false"`
+	Active         bool            `json:"active" example:"true"`
+	StartsAt       time.Time       `json:"starts_at" example:"2025-08-11T00:00:00Z"`
+	EndsAt         *time.Time      `json:"ends_at,omitempty" example:"2025-08-31T00:00:00Z"`
+	CreatedAt      time.Time       `json:"created_at" example:"2025-08-11T02:20:45Z"`
+	UpdatedAt      time.Time       `json:"updated_at" example:"2025-08-11T02:20:45Z"`
+}
+
+// PromotionRequest dipakai untuk membuat dan mengganti promosi.
+//
+// Field aturan yang dipakai bergantung pada rule_type: percent untuk percent_off,
+// buy_quantity dan free_quantity untuk buy_x_get_y, bundle_quantity dan bundle_price untuk
+// fixed_price_bundle. scope_value wajib untuk scope publisher dan author, book_ids wajib untuk
+// scope books. starts_at kosong berarti mulai sekarang, ends_at kosong berarti tanpa batas waktu
+type PromotionRequest struct {
+	Name           string          `json:"name" validate:"required,max=200" example:"Diskon 10% Gramedia"`
+	RuleType       string          `json:"rule_type" validate:"required,oneof=percent_off buy_x_get_y fixed_price_bundle" example:"percent_off"`
+	Scope          string          `json:"scope" validate:"required,oneof=all publisher author books" example:"publisher"`
+	ScopeValue     string          `json:"scope_value" validate:"max=200" example:"Gramedia Pustaka Utama"`
+	BookIDs        []uuid.UUID     `json:"book_ids" validate:"max=500"`
+	Percent        decimal.Decimal `json:"percent" swaggertype:"string" example:"10"`
+	BuyQuantity    int64           `json:"buy_quantity" validate:"min=0,max=1000" example:"0"`
+	FreeQuantity   int64           `json:"free_quantity" validate:"min=0,max=1000" example:"0"`
+	BundleQuantity int64           `json:"bundle_quantity" validate:"min=0,max=1000" example:"0"`
+	BundlePrice    decimal.Decimal `json:"bundle_price" swaggertype:"string" example:"0"`
+	MinSubtotal    decimal.Decimal `json:"min_subtotal" swaggertype:"string" example:"0"`
+	Priority       int32           `json:"priority" validate:"min=0,max=1000" example:"10"`
+	Stackable      bool            `json:"stackable" example:"false"`
+	// Active bernilai true jika tidak dikirim
+	Active   *bool      `json:"active" example:"true"`
+	StartsAt *time.Time `json:"starts_at" example:"2025-08-11T00:00:00Z"`
+	EndsAt   *time.Time `json:"ends_at" example:"2025-08-31T00:00:00Z"`
+}
+
+// PromotionToResponse mengkonversi entity.Promotion beserta daftar bukunya menjadi model PromotionResponse.
+// Parameter books boleh nil untuk respons daftar promosi
+func PromotionToResponse(promotion *entity.Promotion, books []*entity.PromotionBook) PromotionResponse {
+	response := PromotionResponse{
+		PromotionID:    promotion.PromotionId,
+		Name:           promotion.Name,
+		RuleType:       string(promotion.RuleType),
+		Scope:          string(promotion.Scope),
+		ScopeValue:     promotion.ScopeValue,
+		Percent:        promotion.Percent,
+		BuyQuantity:    promotion.BuyQuantity,
+		FreeQuantity:   promotion.FreeQuantity,
+		BundleQuantity: promotion.BundleQuantity,
+		BundlePrice:    promotion.BundlePrice,
+		MinSubtotal:    promotion.MinSubtotal,
+		Priority:       promotion.Priority,
+		Stackable:      promotion.Stackable,
+		Active:         promotion.Active,
+		StartsAt:       promotion.StartsAt,
+		CreatedAt:      promotion.CreatedAt,
+		UpdatedAt:      promotion.UpdatedAt,
+	}
+
+	if promotion.EndsAt.Valid {
+		response.EndsAt = &promotion.EndsAt.Time
+	}
+
+	for _, book := range books {
+		response.BookIDs = append(response.BookIDs, book.BookId)
+	}
+
+	return response
+}
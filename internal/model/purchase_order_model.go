@@ -0,0 +1,138 @@
+package model
+
+import (
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type PurchaseOrderItemResponse struct {
+	PurchaseOrderItemID uuid.UUID `json:"purchase_order_item_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	BookID              uuid.UUID `json:"book_id" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	QuantityOrdered     int64     `json:"quantity_ordered" example:"20"`
+	QuantityReceived    int64     `json:"quantity_received" example:"0"`
+	// QuantityShort adalah jumlah yang belum diterima, QuantityOver adalah kelebihan kiriman
+	QuantityShort int64           `json:"quantity_short" example:"20"`
+	QuantityOver  int64           `json:"quantity_over" example:"0"`
+	UnitCost      decimal.Decimal `json:"unit_cost" swaggertype:"string" example:"62000.00"`
+	LineTotal     decimal.Decimal `json:"line_total" swaggertype:"string" example:"1240000.00"`
+}
+
+type PurchaseOrderResponse struct {
+	PurchaseOrderID uuid.UUID                   `json:"purchase_order_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	SupplierID      uuid.UUID                   `json:"supplier_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	Status          string                      `json:"status" example:"draft"`
+	Notes           string                      `json:"notes" example:""`
+	ExpectedAt      *time.Time                  `json:"expected_at,omitempty" example:"2025-07-28T00:00:00Z"`
+	TotalCost       decimal.Decimal             `json:"total_cost" swaggertype:"string" example:"1240000.00"`
+	CreatedBy       *uuid.UUID                  `json:"created_by,omitempty" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	CreatedAt       time.Time                   `json:"created_at" example:"2025-07-21T03:45:12Z"`
+	UpdatedAt       time.Time                   `json:"updated_at" example:"2025-07-21T03:45:12Z"`
+	Items           []PurchaseOrderItemResponse `json:"items,omitempty"`
+}
+
+type PurchaseOrderItemRequest struct {
+	BookID   uuid.UUID       `json:"book_id" validate:"required" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	Quantity int64           `json:"quantity" validate:"required,gt=0" example:"20"`
+	UnitCost decimal.Decimal `json:"unit_cost" swaggertype:"string" example:"62000.00"`
+}
+
+// PurchaseOrderRequest dipakai untuk membuat purchase order baru maupun mengganti isi
+// purchase order yang masih berstatus draft
+type PurchaseOrderRequest struct {
+	SupplierID uuid.UUID                  `json:"supplier_id" validate:"required" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	ExpectedAt string                     `json:"expected_at" validate:"omitempty,datetime=2006-01-02" example:"2025-07-28"`
+	Notes      string                     `json:"notes" validate:"max=1000" example:""`
+	Items      []PurchaseOrderItemRequest `json:"items" validate:"required,min=1,max=500,dive"`
+}
+
+// UpdatePurchaseOrderStatusRequest merepresentasikan perubahan status manual.
+// Status partially_received dan received hanya diubah lewat penerimaan barang
+type UpdatePurchaseOrderStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=sent cancelled" example:"sent"`
+}
+
+// ReceiptItemRequest adalah satu hasil pindaian barang yang diterima. Code berisi book_id,
+// URL QR code buku atau ISBN
+type ReceiptItemRequest struct {
+	Code     string `json:"code" validate:"required,max=2048" example:"9786020324784"`
+	Quantity int64  `json:"quantity" validate:"omitempty,gt=0" example:"20"`
+}
+
+// ReceivePurchaseOrderRequest merepresentasikan satu pengiriman barang dari supplier
+type ReceivePurchaseOrderRequest struct {
+	// Reference biasanya berisi nomor surat jalan dari supplier
+	Reference string               `json:"reference" validate:"max=255" example:"SJ-2025-0712"`
+	Items     []ReceiptItemRequest `json:"items" validate:"required,min=1,max=500,dive"`
+	// LocationID adalah lokasi penyimpanan barang yang diterima, kosong berarti lokasi default
+	LocationID *uuid.UUID `json:"location_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+}
+
+type ReceivePurchaseOrderResponse struct {
+	PurchaseOrder PurchaseOrderResponse   `json:"purchase_order"`
+	Movements     []StockMovementResponse `json:"movements"`
+	// Allocations berisi backorder yang langsung mendapat stok dari barang yang diterima
+	Allocations []BackorderResponse `json:"allocations,omitempty"`
+}
+
+// ReorderRequest merepresentasikan permintaan pembuatan purchase order draft dari buku
+// dengan stok menipis. SupplierID kosong berarti semua supplier
+type ReorderRequest struct {
+	SupplierID *uuid.UUID `json:"supplier_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+}
+
+// ReorderSkippedBook adalah buku stok menipis yang tidak dimasukkan ke purchase order
+type ReorderSkippedBook struct {
+	BookID uuid.UUID `json:"book_id" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	Reason string    `json:"reason" example:"no preferred supplier"`
+}
+
+type ReorderResponse struct {
+	PurchaseOrders []PurchaseOrderResponse `json:"purchase_orders"`
+	Skipped        []ReorderSkippedBook    `json:"skipped"`
+}
+
+// PurchaseOrderSearchRequest merepresentasikan filter daftar purchase order
+type PurchaseOrderSearchRequest struct {
+	Status     string `query:"status" validate:"omitempty,oneof=draft sent partially_received received cancelled" example:"sent"`
+	SupplierID string `query:"supplier_id" validate:"omitempty,uuid" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+}
+
+// PurchaseOrderToResponse mengkonversi entity.PurchaseOrder beserta item-itemnya menjadi
+// model PurchaseOrderResponse. Parameter items boleh nil untuk respons daftar purchase order
+func PurchaseOrderToResponse(order *entity.PurchaseOrder, items []*entity.PurchaseOrderItem) PurchaseOrderResponse {
+	response := PurchaseOrderResponse{
+		PurchaseOrderID: order.PurchaseOrderId,
+		SupplierID:      order.SupplierId,
+		Status:          string(order.Status),
+		Notes:           order.Notes,
+		TotalCost:       order.TotalCost,
+		CreatedAt:       order.CreatedAt,
+		UpdatedAt:       order.UpdatedAt,
+	}
+
+	if order.ExpectedAt.Valid {
+		response.ExpectedAt = &order.ExpectedAt.Time
+	}
+
+	if order.CreatedBy.Valid {
+		response.CreatedBy = &order.CreatedBy.UUID
+	}
+
+	for _, item := range items {
+		response.Items = append(response.Items, PurchaseOrderItemResponse{
+			PurchaseOrderItemID: item.PurchaseOrderItemId,
+			BookID:              item.BookId,
+			QuantityOrdered:     item.QuantityOrdered,
+			QuantityReceived:    item.QuantityReceived,
+			QuantityShort:       max(item.QuantityOrdered-item.QuantityReceived, 0),
+			QuantityOver:        max(item.QuantityReceived-item.QuantityOrdered, 0),
+			UnitCost:            item.UnitCost,
+			LineTotal:           item.UnitCost.Mul(decimal.NewFromInt(item.QuantityOrdered)).Round(2),
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type ReservationItemResponse struct {
+	ReservationItemID uuid.UUID `json:"reservation_item_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	BookID            uuid.UUID `json:"book_id" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	Quantity          int64     `json:"quantity" example:"1"`
+}
+
+type ReservationResponse struct {
+	ReservationID   uuid.UUID                 `json:"reservation_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	LocationID      uuid.UUID                 `json:"location_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	Status          string                    `json:"status" example:"active"`
+	Channel         string                    `json:"channel" example:"phone"`
+	CustomerName    string                    `json:"customer_name" example:"Budi Santoso"`
+	CustomerContact string                    `json:"customer_contact" example:"0812-3456-7890"`
+	Notes           string                    `json:"notes" example:"Picks up after work"`
+	ExpiresAt       time.Time                 `json:"expires_at" example:"2025-09-17T03:18:22Z"`
+	SaleID          *uuid.UUID                `json:"sale_id,omitempty" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	CreatedBy       *uuid.UUID                `json:"created_by,omitempty" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	CreatedAt       time.Time                 `json:"created_at" example:"2025-09-15T03:18:22Z"`
+	UpdatedAt       time.Time                 `json:"updated_at" example:"2025-09-15T03:18:22Z"`
+	Items           []ReservationItemResponse `json:"items,omitempty"`
+}
+
+type ReservationItemRequest struct {
+	BookID   uuid.UUID `json:"book_id" validate:"required" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	Quantity int64     `json:"quantity" validate:"required,gt=0" example:"1"`
+}
+
+type CreateReservationRequest struct {
+	// LocationID adalah lokasi tempat buku ditahan dan diambil, kosong berarti lokasi default
+	LocationID      *uuid.UUID `json:"location_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	Channel         string     `json:"channel" validate:"required,oneof=phone online store" example:"phone"`
+	CustomerName    string     `json:"customer_name" validate:"required,max=200" example:"Budi Santoso"`
+	CustomerContact string     `json:"customer_contact" validate:"max=200" example:"0812-3456-7890"`
+	Notes           string     `json:"notes" validate:"max=1000" example:"Picks up after work"`
+	// ExpiresAt kosong berarti reservasi ditahan selama RESERVATION_TTL
+	ExpiresAt *time.Time               `json:"expires_at" example:"2025-09-17T03:18:22Z"`
+	Items     []ReservationItemRequest `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+// ReservationSearchRequest merepresentasikan filter daftar reservasi. BookID mencocokkan
+// reservasi yang memuat buku tersebut
+type ReservationSearchRequest struct {
+	Status     string `query:"status" validate:"omitempty,oneof=active fulfilled cancelled expired" example:"active"`
+	LocationID string `query:"location_id" validate:"omitempty,uuid" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	BookID     string `query:"book_id" validate:"omitempty,uuid" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+}
+
+// ReservationSaleRequest berisi pembayaran saat reservasi diambil. Buku, jumlah dan lokasi
+// diambil dari reservasi, harga memakai harga jual dan promosi yang berlaku saat ini
+type ReservationSaleRequest struct {
+	PaymentMethod string          `json:"payment_method" validate:"required,oneof=cash card qris transfer" example:"cash"`
+	AmountPaid    decimal.Decimal `json:"amount_paid" swaggertype:"string" example:"100000.00"`
+	Notes         string          `json:"notes" validate:"max=500" example:""`
+}
+
+// ReservationSaleResponse adalah reservasi yang sudah diambil beserta penjualannya
+type ReservationSaleResponse struct {
+	Reservation ReservationResponse `json:"reservation"`
+	Sale        SaleResponse        `json:"sale"`
+}
+
+// ReservationToResponse mengkonversi entity.Reservation beserta item-itemnya menjadi model
+// ReservationResponse. Parameter items boleh nil untuk respons daftar reservasi
+func ReservationToResponse(reservation *entity.Reservation, items []*entity.ReservationItem) ReservationResponse {
+	response := ReservationResponse{
+		ReservationID:   reservation.ReservationId,
+		LocationID:      reservation.LocationId,
+		Status:          string(reservation.Status),
+		Channel:         string(reservation.Channel),
+		CustomerName:    reservation.CustomerName,
+		CustomerContact: reservation.CustomerContact,
+		Notes:           reservation.Notes,
+		ExpiresAt:       reservation.ExpiresAt,
+		CreatedAt:       reservation.CreatedAt,
+		UpdatedAt:       reservation.UpdatedAt,
+	}
+
+	if reservation.SaleId.Valid {
+		response.SaleID = &reservation.SaleId.UUID
+	}
+	if reservation.CreatedBy.Valid {
+		response.CreatedBy = &reservation.CreatedBy.UUID
+	}
+
+	for _, item := range items {
+		response.Items = append(response.Items, ReservationItemResponse{
+			ReservationItemID: item.ReservationItemId,
+			BookID:            item.BookId,
+			Quantity:          item.Quantity,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,149 @@
+package model
+
+import (
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type SaleItemResponse struct {
+	SaleItemID uuid.UUID       `json:"sale_item_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	BookID     *uuid.UUID      `json:"book_id,omitempty" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	ISBN       string          `json:"isbn" example:"9783161484100"`
+	Title      string          `json:"title" example:"Hujan"`
+	Quantity   int64           `json:"quantity" example:"2"`
+	UnitPrice  decimal.Decimal `json:"unit_price" swaggertype:"string" example:"89000.00"`
+	Discount   decimal.Decimal `json:"discount" swaggertype:"string" example:"0.00"`
+	LineTotal  decimal.Decimal `json:"line_total" swaggertype:"string" example:"178000.00"`
+}
+
+type SaleResponse struct {
+	SaleID        uuid.UUID          `json:"sale_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	CashierID     *uuid.UUID         `json:"cashier_id,omitempty" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	PaymentMethod string             `json:"payment_method" example:"cash"`
+	Subtotal      decimal.Decimal    `json:"subtotal" swaggertype:"string" example:"178000.00"`
+	DiscountTotal decimal.Decimal    `json:"discount_total" swaggertype:"string" example:"0.00"`
+	Total         decimal.Decimal    `json:"total" swaggertype:"string" example:"178000.00"`
+	AmountPaid    decimal.Decimal    `json:"amount_paid" swaggertype:"string" example:"200000.00"`
+	ChangeDue     decimal.Decimal    `json:"change_due" swaggertype:"string" example:"22000.00"`
+	Notes         string             `json:"notes" example:""`
+	CreatedAt     time.Time          `json:"created_at" example:"2025-07-02T03:15:07Z"`
+	Items         []SaleItemResponse `json:"items,omitempty"`
+	// Promotions berisi promosi yang memberi diskon pada penjualan ini
+	Promotions []SalePromotionResponse `json:"promotions,omitempty"`
+}
+
+// SalePromotionResponse adalah total diskon dari satu promosi. PromotionID kosong jika promosinya sudah dihapus
+type SalePromotionResponse struct {
+	PromotionID *uuid.UUID      `json:"promotion_id,omitempty" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	Name        string          `json:"name" example:"Diskon 10% Gramedia"`
+	Discount    decimal.Decimal `json:"discount" swaggertype:"string" example:"17800.00"`
+}
+
+// SaleItemRequest adalah satu baris penjualan. Harga satuan selalu diambil dari harga jual buku
+type SaleItemRequest struct {
+	BookID   uuid.UUID `json:"book_id" validate:"required" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	Quantity int64     `json:"quantity" validate:"required,gt=0" example:"2"`
+}
+
+// CreateSaleRequest merepresentasikan satu transaksi penjualan di kasir.
+// Jika amount_paid kosong, pembayaran dianggap pas sesuai total
+type CreateSaleRequest struct {
+	PaymentMethod string            `json:"payment_method" validate:"required,oneof=cash card qris transfer" example:"cash"`
+	AmountPaid    decimal.Decimal   `json:"amount_paid" swaggertype:"string" example:"200000.00"`
+	Notes         string            `json:"notes" validate:"max=500" example:""`
+	Items         []SaleItemRequest `json:"items" validate:"required,min=1,max=100,dive"`
+	// LocationID adalah lokasi asal barang yang dijual, kosong berarti lokasi default
+	LocationID *uuid.UUID `json:"location_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+}
+
+// PreviewSaleRequest berisi isi keranjang yang akan dihitung harganya tanpa disimpan
+type PreviewSaleRequest struct {
+	Items []SaleItemRequest `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+type SalePreviewItemResponse struct {
+	BookID       uuid.UUID       `json:"book_id" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	ISBN         string          `json:"isbn" example:"9783161484100"`
+	Title        string          `json:"title" example:"Hujan"`
+	Quantity     int64           `json:"quantity" example:"2"`
+	UnitPrice    decimal.Decimal `json:"unit_price" swaggertype:"string" example:"89000.00"`
+	Discount     decimal.Decimal `json:"discount" swaggertype:"string" example:"17800.00"`
+	LineTotal    decimal.Decimal `json:"line_total" swaggertype:"string" example:"160200.00"`
+	PromotionIDs []uuid.UUID     `json:"promotion_ids"`
+}
+
+// SalePreviewResponse adalah hasil perhitungan harga keranjang dengan promosi yang sedang berlaku
+type SalePreviewResponse struct {
+	Subtotal      decimal.Decimal           `json:"subtotal" swaggertype:"string" example:"178000.00"`
+	DiscountTotal decimal.Decimal           `json:"discount_total" swaggertype:"string" example:"17800.00"`
+	Total         decimal.Decimal           `json:"total" swaggertype:"string" example:"160200.00"`
+	Items         []SalePreviewItemResponse `json:"items"`
+	Promotions    []SalePromotionResponse   `json:"promotions"`
+}
+
+// SaleReceiptRequest merepresentasikan parameter kueri untuk mencetak struk penjualan
+type SaleReceiptRequest struct {
+	Format string `query:"format" validate:"omitempty,oneof=pdf escpos txt" example:"pdf"`
+	Paper  int    `query:"paper" validate:"omitempty,oneof=58 80" example:"58"`
+}
+
+// SaleToResponse mengkonversi entity.Sale beserta item-itemnya menjadi model SaleResponse.
+// Parameter items dan promotions boleh nil untuk respons daftar penjualan
+func SaleToResponse(sale *entity.Sale, items []*entity.SaleItem, promotions []*entity.SalePromotion) SaleResponse {
+	response := SaleResponse{
+		SaleID:        sale.SaleId,
+		PaymentMethod: string(sale.PaymentMethod),
+		Subtotal:      sale.Subtotal,
+		DiscountTotal: sale.DiscountTotal,
+		Total:         sale.Total,
+		AmountPaid:    sale.AmountPaid,
+		ChangeDue:     sale.ChangeDue,
+		Notes:         sale.Notes,
+		CreatedAt:     sale.CreatedAt,
+	}
+
+	if sale.CashierId.Valid {
+		response.CashierID = &sale.CashierId.UUID
+	}
+
+	for _, item := range items {
+		itemResp := SaleItemResponse{
+			SaleItemID: item.SaleItemId,
+			ISBN:       item.ISBN,
+			Title:      item.Title,
+			Quantity:   item.Quantity,
+			UnitPrice:  item.UnitPrice,
+			Discount:   item.Discount,
+			LineTotal:  item.LineTotal,
+		}
+
+		if item.BookId.Valid {
+			itemResp.BookID = &item.BookId.UUID
+		}
+
+		response.Items = append(response.Items, itemResp)
+	}
+
+	for _, promotion := range promotions {
+		response.Promotions = append(response.Promotions, SalePromotionToResponse(promotion))
+	}
+
+	return response
+}
+
+// SalePromotionToResponse mengkonversi entity.SalePromotion menjadi model SalePromotionResponse
+func SalePromotionToResponse(promotion *entity.SalePromotion) SalePromotionResponse {
+	response := SalePromotionResponse{
+		Name:     promotion.Name,
+		Discount: promotion.Discount,
+	}
+
+	if promotion.PromotionId.Valid {
+		response.PromotionID = &promotion.PromotionId.UUID
+	}
+
+	return response
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type SaleReturnItemResponse struct {
+	SaleReturnItemID uuid.UUID       `json:"sale_return_item_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	SaleItemID       uuid.UUID       `json:"sale_item_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	BookID           *uuid.UUID      `json:"book_id,omitempty" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	Quantity         int64           `json:"quantity" example:"1"`
+	Condition        string          `json:"condition" example:"resellable"`
+	RefundAmount     decimal.Decimal `json:"refund_amount" swaggertype:"string" example:"80100.00"`
+}
+
+type SaleReturnResponse struct {
+	SaleReturnID uuid.UUID                `json:"sale_return_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	SaleID       uuid.UUID                `json:"sale_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	Reason       string                   `json:"reason" example:"Halaman terbalik"`
+	RefundTotal  decimal.Decimal          `json:"refund_total" swaggertype:"string" example:"80100.00"`
+	CreatedBy    *uuid.UUID               `json:"created_by,omitempty" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	CreatedAt    time.Time                `json:"created_at" example:"2025-08-18T03:04:15Z"`
+	Items        []SaleReturnItemResponse `json:"items"`
+	// Allocations berisi backorder yang mendapat stok dari barang retur, hanya diisi saat retur dibuat
+	Allocations []BackorderResponse `json:"allocations,omitempty"`
+}
+
+// SaleReturnItemRequest adalah satu baris retur. Condition kosong berarti barang masih layak jual
+type SaleReturnItemRequest struct {
+	SaleItemID uuid.UUID `json:"sale_item_id" validate:"required" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	Quantity   int64     `json:"quantity" validate:"required,gt=0" example:"1"`
+	Condition  string    `json:"condition" validate:"omitempty,oneof=resellable damaged" example:"resellable"`
+}
+
+type CreateSaleReturnRequest struct {
+	Reason string                  `json:"reason" validate:"max=500" example:"Halaman terbalik"`
+	Items  []SaleReturnItemRequest `json:"items" validate:"required,min=1,max=100,dive"`
+	// LocationID adalah lokasi tujuan barang yang dikembalikan, kosong berarti lokasi default
+	LocationID *uuid.UUID `json:"location_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+}
+
+// SaleReturnToResponse mengkonversi entity.SaleReturn beserta item-itemnya menjadi model SaleReturnResponse
+func SaleReturnToResponse(saleReturn *entity.SaleReturn, items []*entity.SaleReturnItem) SaleReturnResponse {
+	response := SaleReturnResponse{
+		SaleReturnID: saleReturn.SaleReturnId,
+		SaleID:       saleReturn.SaleId,
+		Reason:       saleReturn.Reason,
+		RefundTotal:  saleReturn.RefundTotal,
+		CreatedAt:    saleReturn.CreatedAt,
+		Items:        make([]SaleReturnItemResponse, 0, len(items)),
+	}
+
+	if saleReturn.CreatedBy.Valid {
+		response.CreatedBy = &saleReturn.CreatedBy.UUID
+	}
+
+	for _, item := range items {
+		itemResp := SaleReturnItemResponse{
+			SaleReturnItemID: item.SaleReturnItemId,
+			SaleItemID:       item.SaleItemId,
+			Quantity:         item.Quantity,
+			Condition:        string(item.Condition),
+			RefundAmount:     item.RefundAmount,
+		}
+
+		if item.BookId.Valid {
+			itemResp.BookID = &item.BookId.UUID
+		}
+
+		response.Items = append(response.Items, itemResp)
+	}
+
+	return response
+}
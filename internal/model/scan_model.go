@@ -0,0 +1,22 @@
+package model
+
+import "github.com/google/uuid"
+
+// ScanRequest merepresentasikan hasil pindaian scanner, bisa berupa payload QR code
+// buku (book_id atau URL) maupun barcode ISBN-10/ISBN-13 dari sampul buku
+type ScanRequest struct {
+	Code      string `json:"code" validate:"required,max=512" example:"978-3-16-148410-0"`
+	Action    string `json:"action" validate:"omitempty,oneof=sell receive" example:"sell"`
+	Quantity  int64  `json:"quantity" validate:"omitempty,min=1,max=10000" example:"1"`
+	Reference string `json:"reference" validate:"max=255" example:"TILL-2"`
+	// LocationID kosong berarti lokasi default
+	LocationID *uuid.UUID `json:"location_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+}
+
+type ScanResponse struct {
+	CodeType string                 `json:"code_type" example:"isbn13"`
+	Book     BookResponse           `json:"book"`
+	Movement *StockMovementResponse `json:"movement,omitempty"`
+	// Allocations berisi backorder yang mendapat stok dari barang yang diterima
+	Allocations []BackorderResponse `json:"allocations,omitempty"`
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/google/uuid"
+)
+
+type StockMovementResponse struct {
+	MovementID   uuid.UUID  `json:"movement_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	BookID       uuid.UUID  `json:"book_id" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	LocationID   *uuid.UUID `json:"location_id,omitempty" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	MovementType string     `json:"movement_type" example:"receipt"`
+	Quantity     int64      `json:"quantity" example:"20"`
+	StockAfter   int64      `json:"stock_after" example:"220"`
+	Reason       string     `json:"reason" example:"Restock from supplier"`
+	Reference    string     `json:"reference" example:"INV-2025-0001"`
+	CreatedBy    *uuid.UUID `json:"created_by,omitempty" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	CreatedAt    time.Time  `json:"created_at" example:"2025-06-08T04:12:56Z"`
+}
+
+// CreateStockMovementRequest merepresentasikan satu perubahan stok.
+// Quantity adalah selisih stok: positif untuk penambahan dan negatif untuk pengurangan
+type CreateStockMovementRequest struct {
+	MovementType string `json:"movement_type" validate:"required,oneof=receipt sale return adjustment damage transfer" example:"receipt"`
+	Quantity     int64  `json:"quantity" validate:"required,ne=0" example:"20"`
+	Reason       string `json:"reason" validate:"max=255" example:"Restock from supplier"`
+	Reference    string `json:"reference" validate:"max=255" example:"INV-2025-0001"`
+	// LocationID kosong berarti lokasi default
+	LocationID *uuid.UUID `json:"location_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+}
+
+// StockMovementToResponse mengkonversi entity.StockMovement menjadi model StockMovementResponse
+func StockMovementToResponse(movement *entity.StockMovement) StockMovementResponse {
+	response := StockMovementResponse{
+		MovementID:   movement.MovementId,
+		BookID:       movement.BookId,
+		MovementType: string(movement.MovementType),
+		Quantity:     movement.Quantity,
+		StockAfter:   movement.StockAfter,
+		Reason:       movement.Reason,
+		Reference:    movement.Reference,
+		CreatedAt:    movement.CreatedAt,
+	}
+
+	if movement.LocationId.Valid {
+		response.LocationID = &movement.LocationId.UUID
+	}
+
+	if movement.CreatedBy.Valid {
+		response.CreatedBy = &movement.CreatedBy.UUID
+	}
+
+	return response
+}
+
+// AdjustStockRequest merepresentasikan perubahan stok relatif.
+// Delta positif menambah stok dan delta negatif mengurangi stok
+type AdjustStockRequest struct {
+	Delta     int64  `json:"delta" validate:"required,ne=0" example:"-1"`
+	Reason    string `json:"reason" validate:"max=255" example:"Sold at till 2"`
+	Reference string `json:"reference" validate:"max=255" example:"TILL-2"`
+	// LocationID kosong berarti lokasi default
+	LocationID *uuid.UUID `json:"location_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+}
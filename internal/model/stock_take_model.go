@@ -0,0 +1,145 @@
+package model
+
+import (
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/google/uuid"
+)
+
+type StockTakeSummaryResponse struct {
+	TotalBooks       int64 `json:"total_books" example:"1250"`
+	CountedBooks     int64 `json:"counted_books" example:"1180"`
+	DiscrepancyBooks int64 `json:"discrepancy_books" example:"14"`
+	// NetVariance adalah total selisih hasil hitung terhadap jumlah yang diharapkan untuk buku
+	// yang sudah dihitung. Nilai negatif berarti ada buku yang hilang
+	NetVariance int64 `json:"net_variance" example:"-9"`
+}
+
+type StockTakeResponse struct {
+	StockTakeID uuid.UUID                 `json:"stock_take_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	LocationID  uuid.UUID                 `json:"location_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	Status      string                    `json:"status" example:"open"`
+	Notes       string                    `json:"notes" example:"Odd semester count"`
+	CreatedBy   *uuid.UUID                `json:"created_by,omitempty" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	ApprovedBy  *uuid.UUID                `json:"approved_by,omitempty" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	ApprovedAt  *time.Time                `json:"approved_at,omitempty" example:"2025-09-08T09:30:00Z"`
+	CreatedAt   time.Time                 `json:"created_at" example:"2025-09-08T02:45:30Z"`
+	UpdatedAt   time.Time                 `json:"updated_at" example:"2025-09-08T02:45:30Z"`
+	Summary     *StockTakeSummaryResponse `json:"summary,omitempty"`
+}
+
+// StockTakeLineResponse adalah hasil hitung satu buku. Counted dan Variance kosong berarti buku
+// belum dihitung
+type StockTakeLineResponse struct {
+	BookID    uuid.UUID  `json:"book_id" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	Title     string     `json:"title,omitempty" example:"Laskar Pelangi"`
+	ISBN      string     `json:"isbn,omitempty" example:"9789793062792"`
+	Expected  int64      `json:"expected" example:"12"`
+	Counted   *int64     `json:"counted" example:"10"`
+	Variance  *int64     `json:"variance" example:"-2"`
+	CountedBy *uuid.UUID `json:"counted_by,omitempty" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	CountedAt *time.Time `json:"counted_at,omitempty" example:"2025-09-08T03:10:12Z"`
+}
+
+type CreateStockTakeRequest struct {
+	// LocationID kosong berarti lokasi default
+	LocationID *uuid.UUID `json:"location_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	Notes      string     `json:"notes" validate:"max=1000" example:"Odd semester count"`
+}
+
+// StockTakeCountItemRequest adalah satu hasil pindaian. Quantity kosong berarti satu eksemplar
+type StockTakeCountItemRequest struct {
+	Code     string `json:"code" validate:"required,max=2048" example:"9789793062792"`
+	Quantity *int64 `json:"quantity" validate:"omitempty,gte=0" example:"1"`
+}
+
+// StockTakeCountRequest merepresentasikan kiriman hasil hitung. Mode add menambahkan quantity ke
+// hasil hitung sebelumnya sehingga setiap pindaian bisa dikirim satu per satu, mode set mengganti
+// hasil hitung sebelumnya untuk koreksi
+type StockTakeCountRequest struct {
+	Mode  string                      `json:"mode" validate:"omitempty,oneof=add set" example:"add"`
+	Items []StockTakeCountItemRequest `json:"items" validate:"required,min=1,max=500,dive"`
+}
+
+// StockTakeSearchRequest merepresentasikan filter daftar stock take
+type StockTakeSearchRequest struct {
+	Status     string `query:"status" validate:"omitempty,oneof=open approved cancelled" example:"open"`
+	LocationID string `query:"location_id" validate:"omitempty,uuid" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+}
+
+// StockTakeLineSearchRequest merepresentasikan filter laporan selisih stock take
+type StockTakeLineSearchRequest struct {
+	Status string `query:"status" validate:"omitempty,oneof=counted uncounted discrepancy" example:"discrepancy"`
+}
+
+type ApproveStockTakeRequest struct {
+	// ZeroUncounted menganggap buku yang belum dihitung tidak ada di rak sehingga stoknya dijadikan
+	// nol. Jika false, stok buku yang belum dihitung tidak diubah
+	ZeroUncounted bool `json:"zero_uncounted" example:"false"`
+}
+
+// StockTakeApproveResponse adalah hasil approval stock take beserta pergerakan stok yang dicatat
+type StockTakeApproveResponse struct {
+	StockTake StockTakeResponse       `json:"stock_take"`
+	Movements []StockMovementResponse `json:"movements"`
+}
+
+// StockTakeToResponse mengkonversi entity.StockTake menjadi model StockTakeResponse.
+// Parameter summary boleh nil untuk respons daftar stock take
+func StockTakeToResponse(stockTake *entity.StockTake, summary *entity.StockTakeSummary) StockTakeResponse {
+	response := StockTakeResponse{
+		StockTakeID: stockTake.StockTakeId,
+		LocationID:  stockTake.LocationId,
+		Status:      string(stockTake.Status),
+		Notes:       stockTake.Notes,
+		CreatedAt:   stockTake.CreatedAt,
+		UpdatedAt:   stockTake.UpdatedAt,
+	}
+
+	if stockTake.CreatedBy.Valid {
+		response.CreatedBy = &stockTake.CreatedBy.UUID
+	}
+	if stockTake.ApprovedBy.Valid {
+		response.ApprovedBy = &stockTake.ApprovedBy.UUID
+	}
+	if stockTake.ApprovedAt.Valid {
+		response.ApprovedAt = &stockTake.ApprovedAt.Time
+	}
+
+	if summary != nil {
+		response.Summary = &StockTakeSummaryResponse{
+			TotalBooks:       summary.TotalBooks,
+			CountedBooks:     summary.CountedBooks,
+			DiscrepancyBooks: summary.DiscrepancyBooks,
+			NetVariance:      summary.NetVariance,
+		}
+	}
+
+	return response
+}
+
+// StockTakeLineToResponse mengkonversi entity.StockTakeLine menjadi model StockTakeLineResponse
+func StockTakeLineToResponse(line *entity.StockTakeLine) StockTakeLineResponse {
+	response := StockTakeLineResponse{
+		BookID:   line.BookId,
+		Title:    line.Title,
+		ISBN:     line.ISBN,
+		Expected: line.Expected,
+	}
+
+	if line.Counted.Valid {
+		counted := line.Counted.Int64
+		variance := counted - line.Expected
+		response.Counted = &counted
+		response.Variance = &variance
+	}
+	if line.CountedBy.Valid {
+		response.CountedBy = &line.CountedBy.UUID
+	}
+	if line.CountedAt.Valid {
+		response.CountedAt = &line.CountedAt.Time
+	}
+
+	return response
+}
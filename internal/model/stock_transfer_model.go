@@ -0,0 +1,98 @@
+package model
+
+import (
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/google/uuid"
+)
+
+type StockTransferItemResponse struct {
+	TransferItemID uuid.UUID `json:"transfer_item_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	BookID         uuid.UUID `json:"book_id" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	Quantity       int64     `json:"quantity" example:"10"`
+}
+
+type StockTransferResponse struct {
+	TransferID     uuid.UUID                   `json:"transfer_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	FromLocationID uuid.UUID                   `json:"from_location_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	ToLocationID   uuid.UUID                   `json:"to_location_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5c"`
+	Status         string                      `json:"status" example:"requested"`
+	Notes          string                      `json:"notes" example:"Stock for the new semester"`
+	RequestedBy    *uuid.UUID                  `json:"requested_by,omitempty" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	DispatchedBy   *uuid.UUID                  `json:"dispatched_by,omitempty" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	DispatchedAt   *time.Time                  `json:"dispatched_at,omitempty" example:"2025-09-01T02:34:15Z"`
+	ReceivedBy     *uuid.UUID                  `json:"received_by,omitempty" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	ReceivedAt     *time.Time                  `json:"received_at,omitempty" example:"2025-09-01T05:12:40Z"`
+	CreatedAt      time.Time                   `json:"created_at" example:"2025-09-01T02:34:15Z"`
+	UpdatedAt      time.Time                   `json:"updated_at" example:"2025-09-01T02:34:15Z"`
+	Items          []StockTransferItemResponse `json:"items,omitempty"`
+}
+
+type StockTransferItemRequest struct {
+	BookID   uuid.UUID `json:"book_id" validate:"required" example:"b2a0f3c4-5d8e-4c1b-9f7e-2d3f4e5a6b7c"`
+	Quantity int64     `json:"quantity" validate:"required,gt=0" example:"10"`
+}
+
+type CreateStockTransferRequest struct {
+	FromLocationID uuid.UUID                  `json:"from_location_id" validate:"required" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	ToLocationID   uuid.UUID                  `json:"to_location_id" validate:"required" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5c"`
+	Notes          string                     `json:"notes" validate:"max=1000" example:"Stock for the new semester"`
+	Items          []StockTransferItemRequest `json:"items" validate:"required,min=1,max=500,dive"`
+}
+
+// StockTransferSearchRequest merepresentasikan filter daftar transfer. LocationID mencocokkan
+// lokasi asal maupun tujuan
+type StockTransferSearchRequest struct {
+	Status     string `query:"status" validate:"omitempty,oneof=requested in_transit received cancelled" example:"in_transit"`
+	LocationID string `query:"location_id" validate:"omitempty,uuid" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+}
+
+// StockTransferProcessResponse adalah hasil pengiriman atau penerimaan transfer beserta
+// pergerakan stok yang dicatat
+type StockTransferProcessResponse struct {
+	Transfer  StockTransferResponse   `json:"transfer"`
+	Movements []StockMovementResponse `json:"movements"`
+	// Allocations berisi backorder di lokasi tujuan yang langsung mendapat stok saat transfer diterima
+	Allocations []BackorderResponse `json:"allocations,omitempty"`
+}
+
+// StockTransferToResponse mengkonversi entity.StockTransfer beserta item-itemnya menjadi
+// model StockTransferResponse. Parameter items boleh nil untuk respons daftar transfer
+func StockTransferToResponse(transfer *entity.StockTransfer, items []*entity.StockTransferItem) StockTransferResponse {
+	response := StockTransferResponse{
+		TransferID:     transfer.TransferId,
+		FromLocationID: transfer.FromLocationId,
+		ToLocationID:   transfer.ToLocationId,
+		Status:         string(transfer.Status),
+		Notes:          transfer.Notes,
+		CreatedAt:      transfer.CreatedAt,
+		UpdatedAt:      transfer.UpdatedAt,
+	}
+
+	if transfer.RequestedBy.Valid {
+		response.RequestedBy = &transfer.RequestedBy.UUID
+	}
+	if transfer.DispatchedBy.Valid {
+		response.DispatchedBy = &transfer.DispatchedBy.UUID
+	}
+	if transfer.DispatchedAt.Valid {
+		response.DispatchedAt = &transfer.DispatchedAt.Time
+	}
+	if transfer.ReceivedBy.Valid {
+		response.ReceivedBy = &transfer.ReceivedBy.UUID
+	}
+	if transfer.ReceivedAt.Valid {
+		response.ReceivedAt = &transfer.ReceivedAt.Time
+	}
+
+	for _, item := range items {
+		response.Items = append(response.Items, StockTransferItemResponse{
+			TransferItemID: item.TransferItemId,
+			BookID:         item.BookId,
+			Quantity:       item.Quantity,
+		})
+	}
+
+	return response
+}
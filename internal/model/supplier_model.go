@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/google/uuid"
+)
+
+type SupplierResponse struct {
+	SupplierID   uuid.UUID `json:"supplier_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	Name         string    `json:"name" example:"Gramedia Distribusi"`
+	ContactName  string    `json:"contact_name" example:"Budi Santoso"`
+	Email        string    `json:"email" example:"order@gramedia.example"`
+	Phone        string    `json:"phone" example:"+62215550100"`
+	Address      string    `json:"address" example:"Jl. Palmerah Barat 29, Jakarta"`
+	LeadTimeDays int32     `json:"lead_time_days" example:"7"`
+	CreatedAt    time.Time `json:"created_at" example:"2025-07-21T03:45:12Z"`
+	UpdatedAt    time.Time `json:"updated_at" example:"2025-07-21T03:45:12Z"`
+}
+
+type CreateSupplierRequest struct {
+	Name         string `json:"name" validate:"required,max=200" example:"Gramedia Distribusi"`
+	ContactName  string `json:"contact_name" validate:"max=200" example:"Budi Santoso"`
+	Email        string `json:"email" validate:"omitempty,email" example:"order@gramedia.example"`
+	Phone        string `json:"phone" validate:"max=50" example:"+62215550100"`
+	Address      string `json:"address" validate:"max=500" example:"Jl. Palmerah Barat 29, Jakarta"`
+	LeadTimeDays int32  `json:"lead_time_days" validate:"min=0,max=365" example:"7"`
+}
+
+// UpdateSupplierRequest merepresentasikan perubahan sebagian data supplier.
+// Field yang tidak dikirim tidak diubah
+type UpdateSupplierRequest struct {
+	SupplierID   uuid.UUID `json:"-"`
+	Name         *string   `json:"name" validate:"omitempty,min=1,max=200" example:"Gramedia Distribusi"`
+	ContactName  *string   `json:"contact_name" validate:"omitempty,max=200" example:"Budi Santoso"`
+	Email        *string   `json:"email" validate:"omitempty,email" example:"order@gramedia.example"`
+	Phone        *string   `json:"phone" validate:"omitempty,max=50" example:"+62215550100"`
+	Address      *string   `json:"address" validate:"omitempty,max=500" example:"Jl. Palmerah Barat 29, Jakarta"`
+	LeadTimeDays *int32    `json:"lead_time_days" validate:"omitempty,min=0,max=365" example:"7"`
+}
+
+// SupplierToResponse mengkonversi entity.Supplier menjadi model SupplierResponse
+func SupplierToResponse(supplier *entity.Supplier) SupplierResponse {
+	return SupplierResponse{
+		SupplierID:   supplier.SupplierId,
+		Name:         supplier.Name,
+		ContactName:  supplier.ContactName,
+		Email:        supplier.Email,
+		Phone:        supplier.Phone,
+		Address:      supplier.Address,
+		LeadTimeDays: supplier.LeadTimeDays,
+		CreatedAt:    supplier.CreatedAt,
+		UpdatedAt:    supplier.UpdatedAt,
+	}
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/google/uuid"
+)
+
+type UserResponse struct {
+	UserID    uuid.UUID `json:"user_id" example:"0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"`
+	Username  string    `json:"username" example:"ucup"`
+	FullName  string    `json:"full_name" example:"Ucup Surucup"`
+	Role      string    `json:"role" example:"cashier"`
+	IsActive  bool      `json:"is_active" example:"true"`
+	CreatedAt time.Time `json:"created_at" example:"2025-06-20T02:18:45Z"`
+}
+
+type CreateUserRequest struct {
+	Username string `json:"username" validate:"required,alphanum,min=3,max=64" example:"ucup"`
+	FullName string `json:"full_name" validate:"max=255" example:"Ucup Surucup"`
+	Password string `json:"password" validate:"required,min=8,max=72" example:"s3cr3t-p4ssw0rd"`
+	Role     string `json:"role" validate:"required" example:"cashier"`
+}
+
+// UserToResponse mengkonversi entity.User menjadi model UserResponse tanpa menyertakan hash password
+func UserToResponse(user *entity.User, role *entity.Role) UserResponse {
+	return UserResponse{
+		UserID:    user.UserId,
+		Username:  user.Username,
+		FullName:  user.FullName,
+		Role:      role.Name,
+		IsActive:  user.IsActive,
+		CreatedAt: user.CreatedAt,
+	}
+}
@@ -0,0 +1,34 @@
+// Package money memformat nominal harga untuk dicetak pada label rak dan struk
+package money
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Format menulis nominal dengan pemisah ribuan titik dan pemisah desimal koma.
+// Bagian desimal tidak ditulis jika nilainya nol, misalnya 89.000 atau 33.333,33
+func Format(d decimal.Decimal) string {
+	sign := ""
+	if d.IsNegative() {
+		sign = "-"
+	}
+
+	fixed := d.Abs().StringFixed(2)
+	integer, fraction, _ := strings.Cut(fixed, ".")
+
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	if fraction == "00" {
+		return sign + grouped.String()
+	}
+
+	return sign + grouped.String() + "," + fraction
+}
@@ -0,0 +1,33 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "0", want: "0"},
+		{input: "999", want: "999"},
+		{input: "89000", want: "89.000"},
+		{input: "1000000", want: "1.000.000"},
+		{input: "33333.333", want: "33.333,33"},
+		{input: "1234.5", want: "1.234,50"},
+		{input: "-1234.5", want: "-1.234,50"},
+		{input: "0.005", want: "0,01"},
+		{input: "89000.00", want: "89.000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := Format(decimal.RequireFromString(tt.input))
+			if got != tt.want {
+				t.Errorf("Format(%s) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+// Package pricing menghitung diskon promosi untuk sebuah keranjang belanja. Paket ini tidak
+// mengakses database, isi keranjang dan aturan promosi diberikan oleh pemanggil
+package pricing
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type RuleType string
+
+const (
+	// RulePercentOff memberi potongan Percent persen dari harga setiap baris yang cocok
+	RulePercentOff RuleType = "percent_off"
+	// RuleBuyXGetY menggratiskan FreeQuantity unit termurah untuk setiap BuyQuantity+FreeQuantity unit
+	RuleBuyXGetY RuleType = "buy_x_get_y"
+	// RuleFixedPriceBundle menjual setiap BundleQuantity unit dengan harga BundlePrice
+	RuleFixedPriceBundle RuleType = "fixed_price_bundle"
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// Line adalah satu baris keranjang. UnitPrice harus sudah dibulatkan ke dua desimal
+type Line struct {
+	BookID    uuid.UUID
+	Publisher string
+	Author    string
+	Quantity  int64
+	UnitPrice decimal.Decimal
+}
+
+// Rule adalah satu promosi yang sedang berlaku.
+//
+// Promosi diproses dari Priority tertinggi. Promosi yang tidak Stackable hanya berlaku untuk
+// baris yang belum mendapat diskon dan mengunci baris yang didiskonnya dari promosi lain.
+// Promosi yang Stackable dihitung dari harga setelah diskon sebelumnya, tetapi tidak berlaku
+// untuk baris yang sudah dikunci
+type Rule struct {
+	ID             uuid.UUID
+	Name           string
+	Type           RuleType
+	Percent        decimal.Decimal
+	BuyQuantity    int64
+	FreeQuantity   int64
+	BundleQuantity int64
+	BundlePrice    decimal.Decimal
+	// MinSubtotal adalah subtotal keranjang minimum agar promosi berlaku
+	MinSubtotal decimal.Decimal
+	Priority    int32
+	Stackable   bool
+	// Matches menentukan baris yang termasuk cakupan promosi, nil berarti semua baris
+	Matches func(line Line) bool
+}
+
+type LineResult struct {
+	Gross    decimal.Decimal
+	Discount decimal.Decimal
+	Total    decimal.Decimal
+	// RuleIDs berisi promosi yang memberi diskon pada baris ini sesuai urutan penerapannya
+	RuleIDs []uuid.UUID
+}
+
+// Applied adalah total diskon yang diberikan oleh satu promosi
+type Applied struct {
+	RuleID   uuid.UUID
+	Name     string
+	Discount decimal.Decimal
+}
+
+type Result struct {
+	Lines         []LineResult
+	Subtotal      decimal.Decimal
+	DiscountTotal decimal.Decimal
+	Total         decimal.Decimal
+	Applied       []Applied
+}
+
+type lineState struct {
+	line     Line
+	gross    decimal.Decimal
+	discount decimal.Decimal
+	locked   bool
+	ruleIDs  []uuid.UUID
+}
+
+func (s *lineState) net() decimal.Decimal {
+	return s.gross.Sub(s.discount)
+}
+
+func (s *lineState) unitNet() decimal.Decimal {
+	return s.net().Div(decimal.NewFromInt(s.line.Quantity))
+}
+
+// Calculate menerapkan rules pada lines dan mengembalikan diskon per baris serta total keranjang
+func Calculate(lines []Line, rules []Rule) Result {
+	states := make([]*lineState, len(lines))
+	subtotal := decimal.Zero
+	for i, line := range lines {
+		gross := line.UnitPrice.Mul(decimal.NewFromInt(line.Quantity)).Round(2)
+		states[i] = &lineState{line: line, gross: gross, discount: decimal.Zero}
+		subtotal = subtotal.Add(gross)
+	}
+
+	result := Result{Subtotal: subtotal, Applied: make([]Applied, 0)}
+
+	for _, rule := range sortRules(rules) {
+		if subtotal.LessThan(rule.MinSubtotal) {
+			continue
+		}
+
+		var eligible []int
+		for i, state := range states {
+			if state.line.Quantity <= 0 || state.locked || !state.net().IsPositive() {
+				continue
+			}
+			if !rule.Stackable && !state.discount.IsZero() {
+				continue
+			}
+			if rule.Matches != nil && !rule.Matches(state.line) {
+				continue
+			}
+			eligible = append(eligible, i)
+		}
+
+		if len(eligible) == 0 {
+			continue
+		}
+
+		var discounts map[int]decimal.Decimal
+		switch rule.Type {
+		case RulePercentOff:
+			discounts = percentOff(states, eligible, rule)
+		case RuleBuyXGetY:
+			discounts = buyXGetY(states, eligible, rule)
+		case RuleFixedPriceBundle:
+			discounts = fixedPriceBundle(states, eligible, rule)
+		}
+
+		applied := Applied{RuleID: rule.ID, Name: rule.Name, Discount: decimal.Zero}
+		for _, i := range eligible {
+			discount := decimal.Min(discounts[i], states[i].net())
+			if !discount.IsPositive() {
+				continue
+			}
+
+			states[i].discount = states[i].discount.Add(discount)
+			states[i].ruleIDs = append(states[i].ruleIDs, rule.ID)
+			if !rule.Stackable {
+				states[i].locked = true
+			}
+			applied.Discount = applied.Discount.Add(discount)
+		}
+
+		if applied.Discount.IsPositive() {
+			result.Applied = append(result.Applied, applied)
+		}
+	}
+
+	result.Lines = make([]LineResult, len(states))
+	result.DiscountTotal = decimal.Zero
+	for i, state := range states {
+		result.Lines[i] = LineResult{
+			Gross:    state.gross,
+			Discount: state.discount,
+			Total:    state.net(),
+			RuleIDs:  state.ruleIDs,
+		}
+		result.DiscountTotal = result.DiscountTotal.Add(state.discount)
+	}
+	result.Total = result.Subtotal.Sub(result.DiscountTotal)
+
+	return result
+}
+
+// sortRules mengurutkan promosi dari Priority tertinggi. ID dipakai sebagai pemutus urutan
+// agar hasil perhitungan selalu sama
+func sortRules(rules []Rule) []Rule {
+	sorted := slices.Clone(rules)
+	slices.SortStableFunc(sorted, func(a, b Rule) int {
+		if a.Priority != b.Priority {
+			return int(b.Priority) - int(a.Priority)
+		}
+		return bytes.Compare(a.ID[:], b.ID[:])
+	})
+
+	return sorted
+}
+
+func percentOff(states []*lineState, eligible []int, rule Rule) map[int]decimal.Decimal {
+	discounts := make(map[int]decimal.Decimal, len(eligible))
+	for _, i := range eligible {
+		discounts[i] = states[i].net().Mul(rule.Percent).Div(hundred).Round(2)
+	}
+
+	return discounts
+}
+
+// buyXGetY menggratiskan unit termurah dari seluruh baris yang cocok, sehingga promosi
+// seperti beli 2 gratis 1 bisa berlaku untuk judul yang berbeda dalam satu seri
+func buyXGetY(states []*lineState, eligible []int, rule Rule) map[int]decimal.Decimal {
+	discounts := make(map[int]decimal.Decimal)
+	group := rule.BuyQuantity + rule.FreeQuantity
+	if rule.BuyQuantity <= 0 || rule.FreeQuantity <= 0 {
+		return discounts
+	}
+
+	var units int64
+	for _, i := range eligible {
+		units += states[i].line.Quantity
+	}
+
+	free := units / group * rule.FreeQuantity
+	for _, i := range sortByUnitNet(states, eligible, false) {
+		if free == 0 {
+			break
+		}
+
+		take := min(free, states[i].line.Quantity)
+		discounts[i] = states[i].unitNet().Mul(decimal.NewFromInt(take)).Round(2)
+		free -= take
+	}
+
+	return discounts
+}
+
+// fixedPriceBundle membentuk paket dari unit termahal lebih dulu. Paket yang nilainya tidak
+// melebihi BundlePrice tidak diberi diskon. Diskon setiap paket dibagi ke unit di dalamnya
+// sebanding dengan harganya
+func fixedPriceBundle(states []*lineState, eligible []int, rule Rule) map[int]decimal.Decimal {
+	discounts := make(map[int]decimal.Decimal)
+	if rule.BundleQuantity <= 0 {
+		return discounts
+	}
+
+	order := sortByUnitNet(states, eligible, true)
+	remaining := make(map[int]int64, len(order))
+	for _, i := range order {
+		remaining[i] = states[i].line.Quantity
+	}
+
+	exact := make(map[int]decimal.Decimal)
+	total := decimal.Zero
+	pos := 0
+	for {
+		// ambil BundleQuantity unit berikutnya dari baris dengan harga satuan tertinggi
+		bundle := make(map[int]int64)
+		value := decimal.Zero
+		var taken int64
+		for p := pos; p < len(order) && taken < rule.BundleQuantity; p++ {
+			i := order[p]
+			take := min(remaining[i]-bundle[i], rule.BundleQuantity-taken)
+			if take <= 0 {
+				continue
+			}
+			bundle[i] += take
+			taken += take
+			value = value.Add(states[i].unitNet().Mul(decimal.NewFromInt(take)))
+		}
+
+		// unit diurutkan dari yang termahal, paket berikutnya tidak mungkin lebih menguntungkan
+		if taken < rule.BundleQuantity || !value.GreaterThan(rule.BundlePrice) {
+			break
+		}
+
+		discount := value.Sub(rule.BundlePrice)
+		for i, quantity := range bundle {
+			share := states[i].unitNet().Mul(decimal.NewFromInt(quantity)).Div(value)
+			exact[i] = exact[i].Add(discount.Mul(share))
+			remaining[i] -= quantity
+		}
+		total = total.Add(discount)
+
+		for pos < len(order) && remaining[order[pos]] == 0 {
+			pos++
+		}
+	}
+
+	// bulatkan per baris lalu masukkan selisih pembulatan ke baris dengan diskon terbesar
+	// agar jumlah diskon sama dengan total diskon paket
+	var largest int
+	rounded := decimal.Zero
+	for _, i := range order {
+		d, ok := exact[i]
+		if !ok {
+			continue
+		}
+		discounts[i] = d.Round(2)
+		rounded = rounded.Add(discounts[i])
+		if _, ok := discounts[largest]; !ok || discounts[i].GreaterThan(discounts[largest]) {
+			largest = i
+		}
+	}
+	if len(discounts) > 0 {
+		discounts[largest] = discounts[largest].Add(total.Round(2).Sub(rounded))
+	}
+
+	return discounts
+}
+
+// sortByUnitNet mengurutkan indeks baris berdasarkan harga satuan setelah diskon
+func sortByUnitNet(states []*lineState, indices []int, desc bool) []int {
+	sorted := slices.Clone(indices)
+	slices.SortStableFunc(sorted, func(a, b int) int {
+		c := states[a].unitNet().Cmp(states[b].unitNet())
+		if desc {
+			return -c
+		}
+		return c
+	})
+
+	return sorted
+}
@@ -0,0 +1,213 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func money(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func line(price string, quantity int64) Line {
+	return Line{BookID: uuid.New(), Quantity: quantity, UnitPrice: money(price)}
+}
+
+func TestCalculate(t *testing.T) {
+	first := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	second := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	tests := []struct {
+		name          string
+		lines         []Line
+		rules         []Rule
+		wantDiscounts []string
+		wantTotal     string
+		wantApplied   []uuid.UUID
+	}{
+		{
+			name:  "no rules",
+			lines: []Line{line("100", 1)},
+			rules: nil,
+
+			wantDiscounts: []string{"0"},
+			wantTotal:     "100",
+		},
+		{
+			name:  "non-stackable locks line from lower priority rules",
+			lines: []Line{line("100", 1)},
+			rules: []Rule{
+				{ID: first, Type: RulePercentOff, Percent: money("10"), Priority: 10},
+				{ID: second, Type: RulePercentOff, Percent: money("50"), Priority: 5, Stackable: true},
+			},
+
+			wantDiscounts: []string{"10"},
+			wantTotal:     "90",
+			wantApplied:   []uuid.UUID{first},
+		},
+		{
+			name:  "non-stackable skips already discounted line",
+			lines: []Line{line("100", 1), line("40", 1)},
+			rules: []Rule{
+				{ID: first, Type: RulePercentOff, Percent: money("10"), Priority: 10, Stackable: true,
+					Matches: func(l Line) bool { return l.UnitPrice.Equal(money("100")) }},
+				{ID: second, Type: RulePercentOff, Percent: money("50"), Priority: 5},
+			},
+
+			wantDiscounts: []string{"10", "20"},
+			wantTotal:     "110",
+			wantApplied:   []uuid.UUID{first, second},
+		},
+		{
+			name:  "stackable applies on price after earlier discount",
+			lines: []Line{line("100", 1)},
+			rules: []Rule{
+				{ID: first, Type: RulePercentOff, Percent: money("10"), Priority: 10, Stackable: true},
+				{ID: second, Type: RulePercentOff, Percent: money("10"), Priority: 5, Stackable: true},
+			},
+
+			wantDiscounts: []string{"19"},
+			wantTotal:     "81",
+			wantApplied:   []uuid.UUID{first, second},
+		},
+		{
+			name:  "buy x get y frees cheapest units across lines",
+			lines: []Line{line("30", 1), line("10", 1), line("20", 1)},
+			rules: []Rule{
+				{ID: first, Type: RuleBuyXGetY, BuyQuantity: 2, FreeQuantity: 1},
+			},
+
+			wantDiscounts: []string{"0", "10", "0"},
+			wantTotal:     "50",
+			wantApplied:   []uuid.UUID{first},
+		},
+		{
+			name:  "bundle rounding remainder goes to largest line",
+			lines: []Line{line("10", 1), line("10", 1), line("10", 1)},
+			rules: []Rule{
+				{ID: first, Type: RuleFixedPriceBundle, BundleQuantity: 3, BundlePrice: money("20")},
+			},
+
+			wantDiscounts: []string{"3.34", "3.33", "3.33"},
+			wantTotal:     "20",
+			wantApplied:   []uuid.UUID{first},
+		},
+		{
+			name:  "bundle split proportional to unit price",
+			lines: []Line{line("7", 1), line("5", 1), line("3", 1), line("1", 1)},
+			rules: []Rule{
+				{ID: first, Type: RuleFixedPriceBundle, BundleQuantity: 3, BundlePrice: money("10")},
+			},
+
+			wantDiscounts: []string{"2.33", "1.67", "1", "0"},
+			wantTotal:     "11",
+			wantApplied:   []uuid.UUID{first},
+		},
+		{
+			name:  "discount clamped at line net",
+			lines: []Line{line("50", 2)},
+			rules: []Rule{
+				{ID: first, Type: RulePercentOff, Percent: money("80"), Priority: 10, Stackable: true},
+				{ID: second, Type: RulePercentOff, Percent: money("150"), Priority: 5, Stackable: true},
+			},
+
+			wantDiscounts: []string{"100"},
+			wantTotal:     "0",
+			wantApplied:   []uuid.UUID{first, second},
+		},
+		{
+			name:  "min subtotal not reached",
+			lines: []Line{line("49.99", 1)},
+			rules: []Rule{
+				{ID: first, Type: RulePercentOff, Percent: money("10"), MinSubtotal: money("50")},
+			},
+
+			wantDiscounts: []string{"0"},
+			wantTotal:     "49.99",
+		},
+		{
+			name:  "min subtotal reached exactly",
+			lines: []Line{line("25", 2)},
+			rules: []Rule{
+				{ID: first, Type: RulePercentOff, Percent: money("10"), MinSubtotal: money("50")},
+			},
+
+			wantDiscounts: []string{"5"},
+			wantTotal:     "45",
+			wantApplied:   []uuid.UUID{first},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Calculate(tt.lines, tt.rules)
+
+			if len(result.Lines) != len(tt.wantDiscounts) {
+				t.Fatalf("got %d lines, want %d", len(result.Lines), len(tt.wantDiscounts))
+			}
+
+			sum := decimal.Zero
+			for i, want := range tt.wantDiscounts {
+				got := result.Lines[i]
+				if !got.Discount.Equal(money(want)) {
+					t.Errorf("line %d discount = %s, want %s", i, got.Discount, want)
+				}
+				if !got.Total.Equal(got.Gross.Sub(got.Discount)) {
+					t.Errorf("line %d total = %s, want gross %s minus discount %s", i, got.Total, got.Gross, got.Discount)
+				}
+				if got.Total.IsNegative() {
+					t.Errorf("line %d total = %s, must not be negative", i, got.Total)
+				}
+				sum = sum.Add(got.Discount)
+			}
+
+			if !result.DiscountTotal.Equal(sum) {
+				t.Errorf("discount total = %s, want sum of line discounts %s", result.DiscountTotal, sum)
+			}
+			if !result.Total.Equal(money(tt.wantTotal)) {
+				t.Errorf("total = %s, want %s", result.Total, tt.wantTotal)
+			}
+
+			applied := decimal.Zero
+			if len(result.Applied) != len(tt.wantApplied) {
+				t.Fatalf("got %d applied rules, want %d", len(result.Applied), len(tt.wantApplied))
+			}
+			for i, want := range tt.wantApplied {
+				if result.Applied[i].RuleID != want {
+					t.Errorf("applied rule %d = %s, want %s", i, result.Applied[i].RuleID, want)
+				}
+				applied = applied.Add(result.Applied[i].Discount)
+			}
+			if !applied.Equal(result.DiscountTotal) {
+				t.Errorf("applied rule discounts = %s, want discount total %s", applied, result.DiscountTotal)
+			}
+		})
+	}
+}
+
+func TestCalculateBundleDiscountMatchesBundlePrice(t *testing.T) {
+	// diskon paket yang dibulatkan per baris harus tetap berjumlah tepat value - BundlePrice
+	lines := []Line{line("19.99", 1), line("13.37", 2), line("7.01", 1), line("4.44", 2)}
+	rules := []Rule{{ID: uuid.New(), Type: RuleFixedPriceBundle, BundleQuantity: 3, BundlePrice: money("25")}}
+
+	result := Calculate(lines, rules)
+
+	// paket 1: 19.99 + 13.37 + 13.37 = 46.73, paket 2: 7.01 + 4.44 + 4.44 = 15.89 (tidak didiskon)
+	want := money("21.73")
+	if !result.DiscountTotal.Equal(want) {
+		t.Fatalf("discount total = %s, want %s", result.DiscountTotal, want)
+	}
+
+	sum := decimal.Zero
+	for _, l := range result.Lines {
+		if l.Discount.Exponent() < -2 {
+			t.Errorf("line discount %s has more than two decimals", l.Discount)
+		}
+		sum = sum.Add(l.Discount)
+	}
+	if !sum.Equal(want) {
+		t.Errorf("sum of line discounts = %s, want %s", sum, want)
+	}
+}
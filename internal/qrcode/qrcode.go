@@ -0,0 +1,168 @@
+// Package qrcode membuat gambar QR code dalam format PNG dan SVG tanpa layanan eksternal
+package qrcode
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	goqrcode "github.com/skip2/go-qrcode"
+)
+
+type Format string
+
+const (
+	FormatPNG Format = "png"
+	FormatSVG Format = "svg"
+)
+
+// Level adalah tingkat koreksi error QR code sesuai standar (L, M, Q, H)
+type Level string
+
+const (
+	LevelLow     Level = "L"
+	LevelMedium  Level = "M"
+	LevelQuarter Level = "Q"
+	LevelHigh    Level = "H"
+)
+
+const (
+	DefaultSize = 256
+	MinSize     = 64
+	MaxSize     = 2048
+)
+
+type Options struct {
+	Format Format
+	Size   int
+	Level  Level
+}
+
+// ContentType mengembalikan MIME type untuk format gambar
+func (f Format) ContentType() string {
+	if f == FormatSVG {
+		return "image/svg+xml"
+	}
+
+	return "image/png"
+}
+
+func (l Level) recoveryLevel() (goqrcode.RecoveryLevel, error) {
+	switch l {
+	case LevelLow:
+		return goqrcode.Low, nil
+	case LevelMedium, "":
+		return goqrcode.Medium, nil
+	case LevelQuarter:
+		return goqrcode.High, nil
+	case LevelHigh:
+		return goqrcode.Highest, nil
+	}
+
+	return 0, fmt.Errorf("unknown error correction level %q", l)
+}
+
+// Encode membuat QR code dari content dengan ukuran sisi Size piksel
+func Encode(content string, opts Options) ([]byte, error) {
+	level, err := opts.Level.recoveryLevel()
+	if err != nil {
+		return nil, err
+	}
+
+	size := opts.Size
+	if size == 0 {
+		size = DefaultSize
+	}
+
+	if size < MinSize || size > MaxSize {
+		return nil, fmt.Errorf("size must be between %d and %d pixels", MinSize, MaxSize)
+	}
+
+	qr, err := goqrcode.New(content, level)
+	if err != nil {
+		return nil, err
+	}
+
+	switch opts.Format {
+	case FormatPNG, "":
+		return qr.PNG(size)
+	case FormatSVG:
+		return renderSVG(qr.Bitmap(), size), nil
+	}
+
+	return nil, fmt.Errorf("unknown format %q", opts.Format)
+}
+
+// Bitmap mengembalikan matriks modul QR code (true berarti modul gelap) termasuk quiet zone.
+// Dipakai ketika QR code perlu digambar langsung ke dokumen lain seperti PDF
+func Bitmap(content string, level Level) ([][]bool, error) {
+	recoveryLevel, err := level.recoveryLevel()
+	if err != nil {
+		return nil, err
+	}
+
+	qr, err := goqrcode.New(content, recoveryLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	return qr.Bitmap(), nil
+}
+
+// DrawPDF menggambar QR code ke halaman PDF sebagai kumpulan persegi vektor agar tetap tajam
+// saat dicetak. x dan y adalah sudut kiri atas, side adalah panjang sisi dalam satuan dokumen
+func DrawPDF(pdf *fpdf.Fpdf, content string, x, y, side float64) error {
+	bitmap, err := Bitmap(content, LevelMedium)
+	if err != nil {
+		return err
+	}
+
+	module := side / float64(len(bitmap))
+	pdf.SetFillColor(0, 0, 0)
+
+	for row, modules := range bitmap {
+		for col := 0; col < len(modules); col++ {
+			if !modules[col] {
+				continue
+			}
+
+			start := col
+			for col < len(modules) && modules[col] {
+				col++
+			}
+			pdf.Rect(x+float64(start)*module, y+float64(row)*module, float64(col-start)*module, module, "F")
+		}
+	}
+
+	return nil
+}
+
+// renderSVG menggambar bitmap sebagai satu path SVG, modul gelap yang bersebelahan
+// dalam satu baris digabung menjadi satu segmen agar ukuran file tetap kecil
+func renderSVG(bitmap [][]bool, size int) []byte {
+	modules := len(bitmap)
+
+	var path strings.Builder
+	for y, row := range bitmap {
+		for x := 0; x < len(row); x++ {
+			if !row[x] {
+				continue
+			}
+
+			start := x
+			for x < len(row) && row[x] {
+				x++
+			}
+			fmt.Fprintf(&path, "M%d %dh%dv1h-%dz", start, y, x-start, x-start)
+		}
+	}
+
+	svg := fmt.Sprintf(
+		`<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+			`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+
+			`<rect width="100%%" height="100%%" fill="#ffffff"/><path fill="#000000" d="%s"/></svg>`+"\n",
+		size, size, modules, modules, path.String(),
+	)
+
+	return []byte(svg)
+}
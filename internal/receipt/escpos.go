@@ -0,0 +1,80 @@
+package receipt
+
+import (
+	"bufio"
+	"io"
+)
+
+const (
+	esc = 0x1b
+	gs  = 0x1d
+	lf  = 0x0a
+)
+
+// renderESCPOS menulis perintah ESC/POS untuk printer thermal. Printer diinisialisasi ulang
+// di awal, QR code dicetak oleh printer sendiri dengan perintah GS ( k, lalu kertas dipotong
+// setelah beberapa baris kosong
+func renderESCPOS(w io.Writer, lines []line, qrContent string, paper Paper) error {
+	bw := bufio.NewWriter(w)
+	bw.Write([]byte{esc, '@'})
+
+	for _, l := range lines {
+		bw.Write([]byte{esc, 'a', byte(l.align)})
+
+		if l.qr {
+			writeQRCode(bw, qrContent, paper)
+			continue
+		}
+
+		bold, size := byte(0), byte(0)
+		if l.bold {
+			bold = 1
+		}
+		if l.tall {
+			size = 0x01
+		}
+		bw.Write([]byte{esc, 'E', bold, gs, '!', size})
+		bw.WriteString(ascii(l.text))
+		bw.WriteByte(lf)
+	}
+
+	bw.Write([]byte{esc, 'E', 0, gs, '!', 0, esc, 'a', 0})
+	// GS V 66 n: potong sebagian setelah mengumpankan kertas n dot
+	bw.Write([]byte{gs, 'V', 66, 96})
+
+	return bw.Flush()
+}
+
+// writeQRCode mencetak QR code model 2 dengan koreksi kesalahan level M. Ukuran modul
+// disesuaikan dengan lebar kertas
+func writeQRCode(bw *bufio.Writer, content string, paper Paper) {
+	moduleSize := byte(5)
+	if paper == Paper80 {
+		moduleSize = 7
+	}
+
+	data := []byte(content)
+	length := len(data) + 3
+
+	bw.Write([]byte{gs, '(', 'k', 4, 0, 49, 65, 50, 0})
+	bw.Write([]byte{gs, '(', 'k', 3, 0, 49, 67, moduleSize})
+	bw.Write([]byte{gs, '(', 'k', 3, 0, 49, 69, 49})
+	bw.Write([]byte{gs, '(', 'k', byte(length % 256), byte(length / 256), 49, 80, 48})
+	bw.Write(data)
+	bw.Write([]byte{gs, '(', 'k', 3, 0, 49, 81, 48})
+	bw.WriteByte(lf)
+}
+
+// ascii mengganti karakter di luar ASCII dengan tanda tanya karena code page printer
+// thermal berbeda-beda
+func ascii(text string) string {
+	out := make([]byte, 0, len(text))
+	for _, r := range text {
+		if r < 0x20 || r > 0x7e {
+			r = '?'
+		}
+		out = append(out, byte(r))
+	}
+
+	return string(out)
+}
@@ -0,0 +1,81 @@
+package receipt
+
+import (
+	"io"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/qrcode"
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	pdfMargin     = 5.0
+	pdfLineHeight = 3.6
+	// ukuran font Courier yang membuat lebar satu karakter 1.5mm, sehingga 32 atau 48
+	// kolom tepat mengisi area cetak kertas 58mm atau 80mm
+	pdfFontSize = 1.5 / (0.6 * 25.4 / 72)
+)
+
+// renderPDF menulis struk sebagai satu halaman PDF selebar kertas thermal dengan tinggi
+// mengikuti jumlah baris, agar bisa dicetak dari printer biasa maupun dibagikan ke pembeli
+func renderPDF(w io.Writer, lines []line, qrContent string, paper Paper) error {
+	pageWidth := float64(paper)
+	contentWidth := float64(paper.columns()) * 1.5
+	qrSide := contentWidth * 0.6
+
+	height := 2 * pdfMargin
+	for _, l := range lines {
+		if l.qr {
+			height += qrSide + pdfLineHeight
+			continue
+		}
+		height += lineHeight(l)
+	}
+
+	pdf := fpdf.NewCustom(&fpdf.InitType{
+		OrientationStr: "P",
+		UnitStr:        "mm",
+		Size:           fpdf.SizeType{Wd: pageWidth, Ht: height},
+	})
+	x := (pageWidth - contentWidth) / 2
+	pdf.SetMargins(x, pdfMargin, x)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetTitle("Sale receipt", true)
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	y := pdfMargin
+	for _, l := range lines {
+		if l.qr {
+			err := qrcode.DrawPDF(pdf, qrContent, (pageWidth-qrSide)/2, y+pdfLineHeight/2, qrSide)
+			if err != nil {
+				return err
+			}
+			y += qrSide + pdfLineHeight
+			continue
+		}
+
+		style := ""
+		if l.bold {
+			style = "B"
+		}
+		align := "L"
+		if l.align == alignCenter {
+			align = "C"
+		}
+
+		pdf.SetFont("Courier", style, pdfFontSize)
+		pdf.SetXY(x, y)
+		pdf.CellFormat(contentWidth, lineHeight(l), tr(l.text), "", 0, align+"M", false, 0, "")
+		y += lineHeight(l)
+	}
+
+	return pdf.Output(w)
+}
+
+func lineHeight(l line) float64 {
+	if l.tall {
+		return pdfLineHeight * 1.5
+	}
+
+	return pdfLineHeight
+}
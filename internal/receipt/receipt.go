@@ -0,0 +1,294 @@
+// Package receipt membuat struk penjualan dalam format PDF, ESC/POS untuk printer thermal
+// dan teks biasa. Ketiga format memakai tata letak baris yang sama sehingga isinya identik
+package receipt
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/money"
+	"github.com/shopspring/decimal"
+)
+
+type Format string
+
+const (
+	FormatPDF    Format = "pdf"
+	FormatESCPOS Format = "escpos"
+	FormatText   Format = "txt"
+)
+
+// ContentType mengembalikan MIME type untuk format struk
+func (f Format) ContentType() string {
+	switch f {
+	case FormatPDF:
+		return "application/pdf"
+	case FormatESCPOS:
+		return "application/octet-stream"
+	}
+
+	return "text/plain; charset=utf-8"
+}
+
+// Extension mengembalikan ekstensi file untuk format struk
+func (f Format) Extension() string {
+	if f == FormatESCPOS {
+		return "bin"
+	}
+
+	return string(f)
+}
+
+// Paper adalah lebar kertas printer thermal dalam milimeter
+type Paper int
+
+const (
+	Paper58 Paper = 58
+	Paper80 Paper = 80
+)
+
+// columns mengembalikan jumlah karakter per baris dengan font A (12x24 dot). Printer 58mm
+// memiliki 384 dot per baris dan printer 80mm memiliki 576 dot per baris
+func (p Paper) columns() int {
+	if p == Paper80 {
+		return 48
+	}
+
+	return 32
+}
+
+// Store berisi identitas toko yang dicetak di bagian atas dan bawah struk
+type Store struct {
+	Name    string
+	Address string
+	Phone   string
+	TaxID   string
+	Footer  string
+}
+
+type Item struct {
+	Title     string
+	Quantity  int64
+	UnitPrice decimal.Decimal
+	Discount  decimal.Decimal
+}
+
+type Promotion struct {
+	Name     string
+	Discount decimal.Decimal
+}
+
+type Receipt struct {
+	Store         Store
+	SaleID        string
+	Cashier       string
+	CreatedAt     time.Time
+	Items         []Item
+	Promotions    []Promotion
+	Subtotal      decimal.Decimal
+	DiscountTotal decimal.Decimal
+	Total         decimal.Decimal
+	// TaxRate adalah persentase pajak yang sudah termasuk dalam harga jual. Nilai nol berarti
+	// baris pajak tidak dicetak
+	TaxRate       decimal.Decimal
+	PaymentMethod string
+	AmountPaid    decimal.Decimal
+	ChangeDue     decimal.Decimal
+	// QRContent biasanya berisi URL ke detail penjualan
+	QRContent string
+}
+
+// Render menulis struk ke w dalam format dan lebar kertas yang diminta
+func Render(w io.Writer, r Receipt, format Format, paper Paper) error {
+	if paper != Paper58 && paper != Paper80 {
+		return fmt.Errorf("unsupported paper width %dmm", paper)
+	}
+
+	lines := layout(r, paper.columns())
+
+	switch format {
+	case FormatPDF:
+		return renderPDF(w, lines, r.QRContent, paper)
+	case FormatESCPOS:
+		return renderESCPOS(w, lines, r.QRContent, paper)
+	case FormatText:
+		return renderText(w, lines, r.QRContent, paper.columns())
+	}
+
+	return fmt.Errorf("unsupported receipt format %q", format)
+}
+
+type alignment int
+
+const (
+	alignLeft alignment = iota
+	alignCenter
+)
+
+type line struct {
+	text  string
+	align alignment
+	bold  bool
+	// tall dicetak dengan tinggi dua kali lipat pada printer thermal
+	tall bool
+	// qr menandai posisi QR code, text diabaikan
+	qr bool
+}
+
+// layout menyusun isi struk menjadi baris-baris dengan lebar cols karakter
+func layout(r Receipt, cols int) []line {
+	var lines []line
+
+	center := func(text string, heading bool) {
+		for _, t := range wrap(text, cols) {
+			lines = append(lines, line{text: t, align: alignCenter, bold: heading, tall: heading})
+		}
+	}
+	pair := func(left, right string) {
+		for _, t := range justify(left, right, cols) {
+			lines = append(lines, line{text: t})
+		}
+	}
+	rule := func() {
+		lines = append(lines, line{text: strings.Repeat("-", cols)})
+	}
+
+	if r.Store.Name != "" {
+		center(r.Store.Name, true)
+	}
+	if r.Store.Address != "" {
+		center(r.Store.Address, false)
+	}
+	if r.Store.Phone != "" {
+		center("Tel. "+r.Store.Phone, false)
+	}
+	if r.Store.TaxID != "" {
+		center("Tax ID "+r.Store.TaxID, false)
+	}
+	rule()
+
+	for _, t := range wrap("Sale "+r.SaleID, cols) {
+		lines = append(lines, line{text: t})
+	}
+	pair("Date", r.CreatedAt.Format("2006-01-02 15:04"))
+	if r.Cashier != "" {
+		pair("Cashier", r.Cashier)
+	}
+	rule()
+
+	for _, item := range r.Items {
+		for _, t := range wrap(item.Title, cols) {
+			lines = append(lines, line{text: t})
+		}
+		pair(fmt.Sprintf("  %d x %s", item.Quantity, money.Format(item.UnitPrice)), money.Format(item.UnitPrice.Mul(decimal.NewFromInt(item.Quantity))))
+		if item.Discount.IsPositive() {
+			pair("  Discount", "-"+money.Format(item.Discount))
+		}
+	}
+	rule()
+
+	pair("Subtotal", money.Format(r.Subtotal))
+	if r.DiscountTotal.IsPositive() {
+		pair("Discount", "-"+money.Format(r.DiscountTotal))
+		for _, promotion := range r.Promotions {
+			pair("  "+promotion.Name, "-"+money.Format(promotion.Discount))
+		}
+	}
+	lines = append(lines, justifiedTall("TOTAL", money.Format(r.Total), cols)...)
+	if r.TaxRate.IsPositive() {
+		// pajak sudah termasuk dalam harga, sehingga nilainya dihitung mundur dari total
+		tax := r.Total.Mul(r.TaxRate).Div(r.TaxRate.Add(decimal.NewFromInt(100))).Round(2)
+		pair(fmt.Sprintf("Incl. tax %s%%", r.TaxRate.String()), money.Format(tax))
+	}
+	pair("Paid ("+r.PaymentMethod+")", money.Format(r.AmountPaid))
+	pair("Change", money.Format(r.ChangeDue))
+	rule()
+
+	if r.QRContent != "" {
+		lines = append(lines, line{qr: true, align: alignCenter})
+	}
+	if r.Store.Footer != "" {
+		center(r.Store.Footer, false)
+	}
+
+	return lines
+}
+
+func justifiedTall(left, right string, cols int) []line {
+	texts := justify(left, right, cols)
+	lines := make([]line, len(texts))
+	for i, t := range texts {
+		lines[i] = line{text: t, bold: true, tall: true}
+	}
+
+	return lines
+}
+
+// justify menaruh left di kiri dan right di kanan dalam satu baris. Jika tidak muat, left
+// dibungkus ke beberapa baris dan right ditaruh rata kanan pada baris terakhir
+func justify(left, right string, cols int) []string {
+	gap := cols - width(left) - width(right)
+	if gap >= 1 {
+		return []string{left + strings.Repeat(" ", gap) + right}
+	}
+
+	lines := wrap(left, cols)
+	return append(lines, strings.Repeat(" ", max(cols-width(right), 0))+right)
+}
+
+// wrap memecah teks per kata agar setiap baris tidak melebihi cols karakter.
+// Kata yang lebih panjang dari cols dipotong setelah tanda - atau / terakhir yang muat,
+// misalnya pada UUID dan URL, atau dipotong paksa jika tidak ada
+func wrap(text string, cols int) []string {
+	var (
+		lines   []string
+		current string
+	)
+
+	for _, word := range strings.Fields(text) {
+		for width(word) > cols {
+			if current != "" {
+				lines = append(lines, current)
+				current = ""
+			}
+			head, tail := splitAt(word, cols)
+			lines = append(lines, head)
+			word = tail
+		}
+
+		switch {
+		case current == "":
+			current = word
+		case width(current)+1+width(word) <= cols:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = word
+		}
+	}
+
+	if current != "" || len(lines) == 0 {
+		lines = append(lines, current)
+	}
+
+	return lines
+}
+
+func width(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+func splitAt(s string, n int) (string, string) {
+	runes := []rune(s)
+	for i := n - 1; i > 0; i-- {
+		if runes[i] == '-' || runes[i] == '/' {
+			n = i + 1
+			break
+		}
+	}
+
+	return string(runes[:n]), string(runes[n:])
+}
@@ -0,0 +1,49 @@
+package receipt
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		cols int
+		want []string
+	}{
+		{name: "fits", text: "Laskar Pelangi", cols: 32, want: []string{"Laskar Pelangi"}},
+		{name: "breaks between words", text: "hello world foo", cols: 11, want: []string{"hello world", "foo"}},
+		{name: "collapses whitespace", text: "  hello   world  ", cols: 32, want: []string{"hello world"}},
+		{name: "empty", text: "", cols: 10, want: []string{""}},
+		{
+			name: "long word split after last hyphen",
+			text: "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b",
+			cols: 20,
+			want: []string{"0196f1a2-7c3d-7e4f-", "8a9b-0c1d2e3f4a5b"},
+		},
+		{
+			name: "long word after short word",
+			text: "ID https://example.com/r/1234",
+			cols: 16,
+			want: []string{"ID", "https://", "example.com/r/", "1234"},
+		},
+		{name: "long word without separator", text: "abcdefghij", cols: 4, want: []string{"abcd", "efgh", "ij"}},
+		{name: "counts runes not bytes", text: "Ütë Ñöß", cols: 3, want: []string{"Ütë", "Ñöß"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wrap(tt.text, tt.cols)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("wrap(%q, %d) = %q, want %q", tt.text, tt.cols, got, tt.want)
+			}
+
+			for _, line := range got {
+				if width(line) > tt.cols {
+					t.Errorf("line %q is wider than %d columns", line, tt.cols)
+				}
+			}
+		})
+	}
+}
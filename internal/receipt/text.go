@@ -0,0 +1,37 @@
+package receipt
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// renderText menulis struk sebagai teks biasa. Teks tidak bisa memuat QR code sehingga
+// isinya dicetak apa adanya di posisi QR code
+func renderText(w io.Writer, lines []line, qrContent string, cols int) error {
+	bw := bufio.NewWriter(w)
+
+	for _, l := range lines {
+		if l.qr {
+			for _, t := range wrap(qrContent, cols) {
+				bw.WriteString(pad(t, alignCenter, cols))
+				bw.WriteByte('\n')
+			}
+			continue
+		}
+
+		bw.WriteString(pad(l.text, l.align, cols))
+		bw.WriteByte('\n')
+	}
+
+	return bw.Flush()
+}
+
+// pad menambahkan spasi di kiri teks yang rata tengah
+func pad(text string, align alignment, cols int) string {
+	if align != alignCenter {
+		return text
+	}
+
+	return strings.Repeat(" ", max(cols-width(text), 0)/2) + text
+}
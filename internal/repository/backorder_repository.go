@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+// BackorderFilter berisi kriteria pencarian daftar backorder. Field yang bernilai kosong diabaikan
+type BackorderFilter struct {
+	Status     string
+	Kind       string
+	BookId     uuid.NullUUID
+	LocationId uuid.NullUUID
+}
+
+type BackorderRepository struct {
+	db dbtx
+}
+
+func NewBackorderRepository(db *sqlx.DB) *BackorderRepository {
+	return &BackorderRepository{db}
+}
+
+// WithTx mengembalikan salinan repository yang menjalankan kueri di dalam transaksi tx
+func (b BackorderRepository) WithTx(tx *sqlx.Tx) *BackorderRepository {
+	return &BackorderRepository{tx}
+}
+
+func (b BackorderRepository) Create(ctx context.Context, backorder *entity.Backorder) (*entity.Backorder, error) {
+	err := b.db.QueryRowxContext(
+		ctx, backorderCreate,
+		backorder.BackorderId,
+		backorder.BookId,
+		backorder.LocationId,
+		backorder.Kind,
+		backorder.Status,
+		backorder.Quantity,
+		backorder.CustomerName,
+		backorder.CustomerContact,
+		backorder.Notes,
+		backorder.CreatedBy,
+	).StructScan(backorder)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, eris.Wrap(types.ErrMissingReference, "book or location not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return backorder, nil
+}
+
+// GetById mengambil backorder beserta posisinya di antrean
+func (b BackorderRepository) GetById(ctx context.Context, backorderId uuid.UUID) (*entity.Backorder, error) {
+	return b.get(ctx, backorderGetById, backorderId)
+}
+
+// GetByIdForUpdate mengambil backorder sekaligus mengunci barisnya sampai transaksi selesai
+func (b BackorderRepository) GetByIdForUpdate(ctx context.Context, backorderId uuid.UUID) (*entity.Backorder, error) {
+	return b.get(ctx, backorderGetByIdForUpdate, backorderId)
+}
+
+// Allocate menandai backorder sudah mendapat stok yang ditahan oleh reservationId
+func (b BackorderRepository) Allocate(ctx context.Context, backorderId uuid.UUID, reservationId uuid.UUID) (*entity.Backorder, error) {
+	return b.get(ctx, backorderAllocate, backorderId, reservationId)
+}
+
+func (b BackorderRepository) Cancel(ctx context.Context, backorderId uuid.UUID) (*entity.Backorder, error) {
+	return b.get(ctx, backorderCancel, backorderId)
+}
+
+// CancelByReservation membatalkan backorder yang dialokasikan ke reservationId
+func (b BackorderRepository) CancelByReservation(ctx context.Context, reservationId uuid.UUID) error {
+	_, err := b.db.ExecContext(ctx, backorderCancelByReservation, reservationId)
+	if err != nil {
+		return eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return nil
+}
+
+func (b BackorderRepository) get(ctx context.Context, query string, args ...any) (*entity.Backorder, error) {
+	backorder := new(entity.Backorder)
+	err := b.db.GetContext(ctx, backorder, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "backorder not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return backorder, nil
+}
+
+// GetWaitingForUpdate mengambil dan mengunci antrean backorder sebuah buku di satu lokasi,
+// urut dari pesanan paling lama
+func (b BackorderRepository) GetWaitingForUpdate(ctx context.Context, bookId uuid.UUID, locationId uuid.UUID) ([]*entity.Backorder, error) {
+	backorders := make([]*entity.Backorder, 0)
+	err := b.db.SelectContext(ctx, &backorders, backorderGetWaitingForUpdate, bookId, locationId)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return backorders, nil
+}
+
+// GetMany mengambil daftar backorder urut sesuai antrean, dari pesanan paling lama.
+// Filter yang kosong diabaikan
+func (b BackorderRepository) GetMany(ctx context.Context, filter BackorderFilter, offset int64, limit int64) ([]*entity.Backorder, error) {
+	backorders := make([]*entity.Backorder, 0)
+	err := b.db.SelectContext(ctx, &backorders, backorderGetMany, filter.Status, filter.Kind, filter.BookId, filter.LocationId, offset, limit)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return backorders, nil
+}
+
+// GetTotalCount returns the total number of backorders matching the filter
+func (b BackorderRepository) GetTotalCount(ctx context.Context, filter BackorderFilter) (int64, error) {
+	var total int64
+	err := b.db.GetContext(ctx, &total, backorderGetTotalCount, filter.Status, filter.Kind, filter.BookId, filter.LocationId)
+	if err != nil {
+		return 0, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return total, nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// kolom yang boleh dipakai untuk pengurutan daftar buku. Nilai map adalah ekspresi SQL-nya
+var bookSortColumns = map[string]string{
+	"title":        "title",
+	"author":       "author",
+	"publisher":    "publisher",
+	"published_at": "published_at",
+	"stock":        "stock",
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+}
+
+// BookFilter berisi kriteria pencarian daftar buku. Field yang bernilai kosong diabaikan
+type BookFilter struct {
+	// Search dicocokkan dengan title, author dan publisher menggunakan full-text search
+	Search        string
+	Publisher     string
+	Author        string
+	PublishedFrom *time.Time
+	PublishedTo   *time.Time
+	StockMin      *int64
+	StockMax      *int64
+	// LocationId mengganti stok total dengan stok di lokasi tersebut, termasuk untuk filter
+	// StockMin, StockMax dan pengurutan berdasarkan stok
+	LocationId *uuid.UUID
+	// Sort adalah salah satu key bookSortColumns atau "relevance" (hanya jika Search diisi),
+	// default berdasarkan book_id
+	Sort string
+	Desc bool
+	// AfterId dan BeforeId membatasi hasil untuk paginasi keyset berdasarkan book_id
+	AfterId  *uuid.UUID
+	BeforeId *uuid.UUID
+}
+
+// where membangun klausa FROM dan WHERE beserta argumennya. Jika LocationId diisi, lokasi
+// selalu menjadi argumen $1
+func (f BookFilter) where() (string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	from := " FROM books"
+	if f.LocationId != nil {
+		from = " FROM " + bookAtLocation + " AS books"
+		args = append(args, *f.LocationId)
+	}
+
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(len(args))))
+	}
+
+	if f.Search != "" {
+		add("search_vector @@ websearch_to_tsquery('simple', ?)", f.Search)
+	}
+	if f.Publisher != "" {
+		add("publisher = ?", f.Publisher)
+	}
+	if f.Author != "" {
+		add("author = ?", f.Author)
+	}
+	if f.PublishedFrom != nil {
+		add("published_at >= ?", *f.PublishedFrom)
+	}
+	if f.PublishedTo != nil {
+		add("published_at <= ?", *f.PublishedTo)
+	}
+	if f.StockMin != nil {
+		add("stock >= ?", *f.StockMin)
+	}
+	if f.StockMax != nil {
+		add("stock <= ?", *f.StockMax)
+	}
+
+	if f.AfterId != nil {
+		add("book_id > ?", *f.AfterId)
+	}
+	if f.BeforeId != nil {
+		add("book_id < ?", *f.BeforeId)
+	}
+
+	if len(conditions) == 0 {
+		return from, args
+	}
+
+	return from + " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// orderBy membangun klausa ORDER BY. book_id selalu ditambahkan sebagai pemutus urutan
+// agar hasil paginasi stabil
+func (f BookFilter) orderBy() string {
+	direction := " ASC"
+	if f.Desc {
+		direction = " DESC"
+	}
+
+	if f.Sort == "relevance" && f.Search != "" {
+		// kata kunci pencarian adalah argumen pertama setelah lokasi karena kondisi Search ditambahkan pertama
+		search := "$1"
+		if f.LocationId != nil {
+			search = "$2"
+		}
+		return " ORDER BY ts_rank(search_vector, websearch_to_tsquery('simple', " + search + "))" + direction + ", book_id"
+	}
+
+	column, ok := bookSortColumns[f.Sort]
+	if !ok {
+		return " ORDER BY book_id" + direction
+	}
+
+	return " ORDER BY " + column + direction + ", book_id" + direction
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+type BookPriceHistoryRepository struct {
+	db dbtx
+}
+
+func NewBookPriceHistoryRepository(db *sqlx.DB) *BookPriceHistoryRepository {
+	return &BookPriceHistoryRepository{db}
+}
+
+// WithTx mengembalikan salinan repository yang menjalankan kueri di dalam transaksi tx
+func (b BookPriceHistoryRepository) WithTx(tx *sqlx.Tx) *BookPriceHistoryRepository {
+	return &BookPriceHistoryRepository{tx}
+}
+
+// Create menambah harga baru yang berlaku mulai sekarang. Harga yang sedang berlaku harus
+// ditutup lebih dulu dengan CloseCurrent di transaksi yang sama
+func (b BookPriceHistoryRepository) Create(ctx context.Context, history *entity.BookPriceHistory) (*entity.BookPriceHistory, error) {
+	err := b.db.QueryRowxContext(
+		ctx, bookPriceHistoryCreate,
+		history.PriceHistoryId,
+		history.BookId,
+		history.CostPrice,
+		history.SalePrice,
+		history.Currency,
+		history.ChangedBy,
+	).StructScan(history)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return history, nil
+}
+
+// CloseCurrent mengakhiri masa berlaku harga buku yang sedang berlaku
+func (b BookPriceHistoryRepository) CloseCurrent(ctx context.Context, bookId uuid.UUID) error {
+	_, err := b.db.ExecContext(ctx, bookPriceHistoryCloseCurrent, bookId)
+	if err != nil {
+		return eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return nil
+}
+
+func (b BookPriceHistoryRepository) GetManyByBookId(ctx context.Context, bookId uuid.UUID, offset int64, limit int64) ([]*entity.BookPriceHistory, error) {
+	history := make([]*entity.BookPriceHistory, 0)
+	err := b.db.SelectContext(ctx, &history, bookPriceHistoryGetManyByBookId, bookId, offset, limit)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return history, nil
+}
+
+func (b BookPriceHistoryRepository) GetTotalCountByBookId(ctx context.Context, bookId uuid.UUID) (int64, error) {
+	var total int64
+	err := b.db.GetContext(ctx, &total, bookPriceHistoryGetTotalCountByBookId, bookId)
+	if err != nil {
+		return 0, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return total, nil
+}
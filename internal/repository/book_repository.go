@@ -3,25 +3,56 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/isbn"
 	"github.com/crazydw4rf/book-stock-manager/internal/types"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/rotisserie/eris"
+	"github.com/shopspring/decimal"
 )
 
 type BookRepository struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 func NewBookRepository(db *sqlx.DB) *BookRepository {
 	return &BookRepository{db}
 }
 
+// WithTx mengembalikan salinan repository yang menjalankan kueri di dalam transaksi tx
+func (b BookRepository) WithTx(tx *sqlx.Tx) *BookRepository {
+	return &BookRepository{tx}
+}
+
 func (b BookRepository) Create(ctx context.Context, book *entity.Book) (*entity.Book, error) {
-	err := b.db.QueryRowxContext(ctx, bookCreate, book.BookId, book.ISBN, book.Title, book.Author, book.Publisher, book.PublishedAt, book.Stock).StructScan(book)
+	err := b.db.QueryRowxContext(
+		ctx, bookCreate,
+		book.BookId,
+		book.ISBN,
+		book.Title,
+		book.Author,
+		book.Publisher,
+		book.PublishedAt,
+		book.Stock,
+		book.ReorderPoint,
+		book.ReorderQuantity,
+		book.PreferredSupplierId,
+		book.CostPrice,
+		book.SalePrice,
+		book.Currency,
+	).StructScan(book)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, eris.Wrap(types.ErrDuplicateKey, "isbn already exists")
+		}
+
+		if isForeignKeyViolation(err) {
+			return nil, eris.Wrap(types.ErrMissingReference, "preferred supplier not found")
+		}
+
 		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
 	}
 
@@ -42,9 +73,16 @@ func (b BookRepository) GetById(ctx context.Context, bookId uuid.UUID) (*entity.
 	return book, nil
 }
 
-func (b BookRepository) GetByISBN(ctx context.Context, isbn string) (*entity.Book, error) {
+// GetByISBN mencari buku berdasarkan ISBN. Input dinormalisasi terlebih dahulu menjadi ISBN-13
+// sehingga ISBN-10 maupun ISBN dengan tanda hubung tetap menemukan buku yang sama
+func (b BookRepository) GetByISBN(ctx context.Context, value string) (*entity.Book, error) {
+	normalized, err := isbn.Normalize(value)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrNoRows, "book not found")
+	}
+
 	book := new(entity.Book)
-	err := b.db.GetContext(ctx, book, bookGetByISBN, isbn)
+	err = b.db.GetContext(ctx, book, bookGetByISBN, normalized)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, eris.Wrap(types.ErrNoRows, "book not found")
@@ -55,21 +93,61 @@ func (b BookRepository) GetByISBN(ctx context.Context, isbn string) (*entity.Boo
 	return book, nil
 }
 
-func (b BookRepository) GetMany(ctx context.Context, offset int64, limit int64) ([]*entity.Book, error) {
+// GetMany mengambil daftar buku yang memenuhi filter dengan paginasi offset
+func (b BookRepository) GetMany(ctx context.Context, filter BookFilter, offset int64, limit int64) ([]*entity.Book, error) {
+	where, args := filter.where()
+	query := bookGetBooksMany + where + filter.orderBy() +
+		fmt.Sprintf(" OFFSET $%d LIMIT $%d", len(args)+1, len(args)+2)
+
 	books := make([]*entity.Book, 0)
-	err := b.db.SelectContext(ctx, &books, bookGetBooksMany, offset, limit)
+	err := b.db.SelectContext(ctx, &books, query, append(args, offset, limit)...)
 	if err != nil {
 		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
 	}
 
-	if len(books) == 0 {
-		return books, nil
+	return books, nil
+}
+
+// Each menjalankan fn untuk setiap buku yang memenuhi filter, baris demi baris dari cursor database,
+// sehingga seluruh tabel tidak perlu dimuat ke memori. Iterasi berhenti jika fn mengembalikan error
+func (b BookRepository) Each(ctx context.Context, filter BookFilter, fn func(book *entity.Book) error) error {
+	where, args := filter.where()
+	rows, err := b.db.QueryxContext(ctx, bookGetBooksMany+where+filter.orderBy(), args...)
+	if err != nil {
+		return eris.Wrap(types.ErrDatabaseQuery, err.Error())
 	}
+	defer rows.Close()
 
-	return books, nil
+	for rows.Next() {
+		book := new(entity.Book)
+		if err := rows.StructScan(book); err != nil {
+			return eris.Wrap(types.ErrDatabaseQuery, err.Error())
+		}
+
+		if err := fn(book); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return nil
 }
 
-func (b BookRepository) Update(ctx context.Context, book *entity.Book) (*entity.Book, error) {
+// BookUpdate berisi pengaturan buku yang diubah sebagian. Field yang tidak Valid tidak diubah,
+// ClearPreferredSupplier menghapus supplier
+type BookUpdate struct {
+	ReorderPoint           sql.NullInt64
+	ReorderQuantity        sql.NullInt64
+	PreferredSupplierId    uuid.NullUUID
+	ClearPreferredSupplier bool
+	CostPrice              decimal.NullDecimal
+	SalePrice              decimal.NullDecimal
+}
+
+func (b BookRepository) Update(ctx context.Context, book *entity.Book, update BookUpdate) (*entity.Book, error) {
 	err := b.db.QueryRowxContext(
 		ctx, bookUpdate,
 		book.BookId,
@@ -78,13 +156,174 @@ func (b BookRepository) Update(ctx context.Context, book *entity.Book) (*entity.
 		book.Author,
 		book.Publisher,
 		book.PublishedAt,
-		book.Stock,
+		update.ReorderPoint,
+		update.ReorderQuantity,
+		update.PreferredSupplierId,
+		update.ClearPreferredSupplier,
+		update.CostPrice,
+		update.SalePrice,
+		book.Currency,
 	).StructScan(book)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, eris.Wrap(types.ErrNoRows, "book not found")
 		}
 
+		if isUniqueViolation(err) {
+			return nil, eris.Wrap(types.ErrDuplicateKey, "isbn already exists")
+		}
+
+		if isForeignKeyViolation(err) {
+			return nil, eris.Wrap(types.ErrMissingReference, "preferred supplier not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return book, nil
+}
+
+// GetLowStock mengambil buku yang perlu dipesan ulang, diurutkan dari kekurangan stok terbesar.
+// supplierId yang tidak Valid berarti semua supplier
+func (b BookRepository) GetLowStock(ctx context.Context, supplierId uuid.NullUUID, offset int64, limit int64) ([]*entity.LowStockBook, error) {
+	books := make([]*entity.LowStockBook, 0)
+	err := b.db.SelectContext(ctx, &books, bookGetLowStock, supplierId, offset, limit)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return books, nil
+}
+
+func (b BookRepository) GetLowStockTotalCount(ctx context.Context, supplierId uuid.NullUUID) (int64, error) {
+	var count int64
+	err := b.db.GetContext(ctx, &count, bookGetLowStockTotalCount, supplierId)
+	if err != nil {
+		return 0, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return count, nil
+}
+
+// GetByIdForUpdate mengambil buku sekaligus mengunci barisnya sampai transaksi selesai
+func (b BookRepository) GetByIdForUpdate(ctx context.Context, bookId uuid.UUID) (*entity.Book, error) {
+	book := new(entity.Book)
+	err := b.db.GetContext(ctx, book, bookGetByIdForUpdate, bookId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "book not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return book, nil
+}
+
+// GetByIdAtLocation mengambil buku dengan stock berisi stok di lokasi locationId
+func (b BookRepository) GetByIdAtLocation(ctx context.Context, bookId uuid.UUID, locationId uuid.UUID) (*entity.Book, error) {
+	book := new(entity.Book)
+	err := b.db.GetContext(ctx, book, bookGetByIdAtLocation, locationId, bookId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "book not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return book, nil
+}
+
+// GetLocationStock mengambil stok buku di setiap lokasi yang pernah menyimpannya
+func (b BookRepository) GetLocationStock(ctx context.Context, bookId uuid.UUID) ([]*entity.LocationStock, error) {
+	stocks := make([]*entity.LocationStock, 0)
+	err := b.db.SelectContext(ctx, &stocks, locationStockGetByBookId, bookId)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return stocks, nil
+}
+
+// AdjustStock menambah atau mengurangi stok buku di satu lokasi sekaligus stok totalnya di books.stock.
+// Perubahan ditolak dengan types.ErrInsufficientStock jika stok total atau stok lokasi akan menjadi
+// negatif. Kedua kueri harus dijalankan di dalam transaksi yang sama agar tidak tercatat sebagian
+func (b BookRepository) AdjustStock(ctx context.Context, bookId uuid.UUID, locationId uuid.UUID, delta int64) (*entity.Book, error) {
+	return b.adjustStock(ctx, bookAdjustStock, bookAdjustLocationStock, bookId, locationId, delta)
+}
+
+// AdjustAvailableStock sama seperti AdjustStock, tetapi pengurangan juga ditolak jika memakai stok
+// yang sedang ditahan reservasi. Dipakai oleh penjualan dan pengiriman transfer
+func (b BookRepository) AdjustAvailableStock(ctx context.Context, bookId uuid.UUID, locationId uuid.UUID, delta int64) (*entity.Book, error) {
+	return b.adjustStock(ctx, bookAdjustAvailableStock, bookAdjustAvailableLocationStock, bookId, locationId, delta)
+}
+
+func (b BookRepository) adjustStock(ctx context.Context, bookQuery string, locationQuery string, bookId uuid.UUID, locationId uuid.UUID, delta int64) (*entity.Book, error) {
+	book := new(entity.Book)
+	err := b.db.QueryRowxContext(ctx, bookQuery, bookId, delta).StructScan(book)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+		}
+
+		// tidak ada baris yang diperbarui, bisa karena buku tidak ada atau stok tidak mencukupi
+		var exists bool
+		err = b.db.GetContext(ctx, &exists, bookExists, bookId)
+		if err != nil {
+			return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+		}
+
+		if !exists {
+			return nil, eris.Wrap(types.ErrNoRows, "book not found")
+		}
+
+		return nil, eris.Wrapf(types.ErrInsufficientStock, "stock of book %s cannot be changed by %d", bookId, delta)
+	}
+
+	var quantity int64
+	err = b.db.GetContext(ctx, &quantity, locationQuery, bookId, locationId, delta)
+	if err != nil {
+		if err == sql.ErrNoRows || isCheckViolation(err) {
+			return nil, eris.Wrapf(types.ErrInsufficientStock, "stock of book %s at location %s cannot be changed by %d", bookId, locationId, delta)
+		}
+
+		if isForeignKeyViolation(err) {
+			return nil, eris.Wrap(types.ErrMissingReference, "location not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return book, nil
+}
+
+// Reserve menambah (delta positif) atau melepas (delta negatif) jumlah yang ditahan reservasi di satu
+// lokasi sekaligus totalnya di books.reserved. Penambahan ditolak dengan types.ErrInsufficientStock
+// jika stok lokasi yang belum ditahan tidak mencukupi. Urutan kueri sama dengan AdjustStock agar
+// tidak deadlock dengan perubahan stok
+func (b BookRepository) Reserve(ctx context.Context, bookId uuid.UUID, locationId uuid.UUID, delta int64) (*entity.Book, error) {
+	book := new(entity.Book)
+	err := b.db.QueryRowxContext(ctx, bookReserve, bookId, delta).StructScan(book)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "book not found")
+		}
+
+		if isCheckViolation(err) {
+			return nil, eris.Wrapf(types.ErrInsufficientStock, "reserved stock of book %s cannot be changed by %d", bookId, delta)
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	var reserved int64
+	err = b.db.GetContext(ctx, &reserved, bookReserveLocationStock, bookId, locationId, delta)
+	if err != nil {
+		if err == sql.ErrNoRows || isCheckViolation(err) {
+			return nil, eris.Wrapf(types.ErrInsufficientStock, "reserved stock of book %s at location %s cannot be changed by %d", bookId, locationId, delta)
+		}
+
 		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
 	}
 
@@ -94,6 +333,10 @@ func (b BookRepository) Update(ctx context.Context, book *entity.Book) (*entity.
 func (b BookRepository) Delete(ctx context.Context, bookId uuid.UUID) error {
 	result, err := b.db.ExecContext(ctx, bookDelete, bookId)
 	if err != nil {
+		if isForeignKeyViolation(err) {
+			return eris.Wrap(types.ErrReferenced, "book is referenced by purchase orders, stock transfers or reservations")
+		}
+
 		return eris.Wrap(types.ErrDatabaseQuery, err.Error())
 	}
 
@@ -104,13 +347,15 @@ func (b BookRepository) Delete(ctx context.Context, bookId uuid.UUID) error {
 	return nil
 }
 
-// GetTotalCount returns the total number of books in the database
-func (b BookRepository) GetTotalCount(ctx context.Context) (int64, error) {
+// GetTotalCount returns the total number of books matching the filter
+func (b BookRepository) GetTotalCount(ctx context.Context, filter BookFilter) (int64, error) {
+	where, args := filter.where()
+
 	var total int64
-	err := b.db.GetContext(ctx, &total, bookGetTotalCount)
+	err := b.db.GetContext(ctx, &total, bookGetTotalCount+where, args...)
 	if err != nil {
 		return 0, eris.Wrap(types.ErrDatabaseQuery, err.Error())
 	}
-	
+
 	return total, nil
 }
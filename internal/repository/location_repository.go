@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+type LocationRepository struct {
+	db dbtx
+}
+
+func NewLocationRepository(db *sqlx.DB) *LocationRepository {
+	return &LocationRepository{db}
+}
+
+// WithTx mengembalikan salinan repository yang menjalankan kueri di dalam transaksi tx
+func (l LocationRepository) WithTx(tx *sqlx.Tx) *LocationRepository {
+	return &LocationRepository{tx}
+}
+
+func (l LocationRepository) Create(ctx context.Context, location *entity.Location) (*entity.Location, error) {
+	err := l.db.QueryRowxContext(
+		ctx, locationCreate,
+		location.LocationId,
+		location.Code,
+		location.Name,
+		location.Address,
+		location.IsDefault,
+	).StructScan(location)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, eris.Wrap(types.ErrDuplicateKey, "location code already exists")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return location, nil
+}
+
+func (l LocationRepository) GetById(ctx context.Context, locationId uuid.UUID) (*entity.Location, error) {
+	return l.get(ctx, locationGetById, locationId)
+}
+
+func (l LocationRepository) GetByCode(ctx context.Context, code string) (*entity.Location, error) {
+	return l.get(ctx, locationGetByCode, code)
+}
+
+// GetDefault mengambil lokasi yang dipakai jika pergerakan stok tidak menyebutkan lokasi
+func (l LocationRepository) GetDefault(ctx context.Context) (*entity.Location, error) {
+	return l.get(ctx, locationGetDefault)
+}
+
+func (l LocationRepository) get(ctx context.Context, query string, args ...any) (*entity.Location, error) {
+	location := new(entity.Location)
+	err := l.db.GetContext(ctx, location, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "location not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return location, nil
+}
+
+func (l LocationRepository) GetMany(ctx context.Context, offset int64, limit int64) ([]*entity.Location, error) {
+	locations := make([]*entity.Location, 0)
+	err := l.db.SelectContext(ctx, &locations, locationGetMany, offset, limit)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return locations, nil
+}
+
+// GetTotalCount returns the total number of locations in the database
+func (l LocationRepository) GetTotalCount(ctx context.Context) (int64, error) {
+	var total int64
+	err := l.db.GetContext(ctx, &total, locationGetTotalCount)
+	if err != nil {
+		return 0, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return total, nil
+}
+
+// LocationPatch berisi perubahan data lokasi. Field bernilai nil tidak diubah
+type LocationPatch struct {
+	Code    *string
+	Name    *string
+	Address *string
+}
+
+func (l LocationRepository) Update(ctx context.Context, locationId uuid.UUID, patch LocationPatch) (*entity.Location, error) {
+	location := new(entity.Location)
+	err := l.db.QueryRowxContext(ctx, locationUpdate, locationId, patch.Code, patch.Name, patch.Address).StructScan(location)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "location not found")
+		}
+
+		if isUniqueViolation(err) {
+			return nil, eris.Wrap(types.ErrDuplicateKey, "location code already exists")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return location, nil
+}
+
+// SetDefault menjadikan lokasi sebagai lokasi default dan mencabut status default lokasi lain.
+// Harus dijalankan di dalam transaksi
+func (l LocationRepository) SetDefault(ctx context.Context, locationId uuid.UUID) (*entity.Location, error) {
+	_, err := l.db.ExecContext(ctx, locationClearDefault, locationId)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return l.get(ctx, locationSetDefault, locationId)
+}
+
+// Delete menghapus lokasi beserta baris stoknya yang sudah kosong. Lokasi yang masih menyimpan
+// stok atau tercatat di transfer ditolak dengan types.ErrReferenced. Harus dijalankan di dalam transaksi
+func (l LocationRepository) Delete(ctx context.Context, locationId uuid.UUID) error {
+	_, err := l.db.ExecContext(ctx, locationDeleteEmptyStock, locationId)
+	if err != nil {
+		return eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	result, err := l.db.ExecContext(ctx, locationDelete, locationId)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return eris.Wrap(types.ErrReferenced, "location still holds stock or has stock transfers")
+		}
+
+		return eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	if i, _ := result.RowsAffected(); i <= 0 {
+		return eris.Wrap(types.ErrNoRows, "location not found")
+	}
+
+	return nil
+}
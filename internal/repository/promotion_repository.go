@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+type PromotionRepository struct {
+	db dbtx
+}
+
+func NewPromotionRepository(db *sqlx.DB) *PromotionRepository {
+	return &PromotionRepository{db}
+}
+
+// WithTx mengembalikan salinan repository yang menjalankan kueri di dalam transaksi tx
+func (p PromotionRepository) WithTx(tx *sqlx.Tx) *PromotionRepository {
+	return &PromotionRepository{tx}
+}
+
+func (p PromotionRepository) Create(ctx context.Context, promotion *entity.Promotion) (*entity.Promotion, error) {
+	err := p.db.QueryRowxContext(
+		ctx, promotionCreate,
+		promotion.PromotionId,
+		promotion.Name,
+		promotion.RuleType,
+		promotion.Scope,
+		promotion.ScopeValue,
+		promotion.Percent,
+		promotion.BuyQuantity,
+		promotion.FreeQuantity,
+		promotion.BundleQuantity,
+		promotion.BundlePrice,
+		promotion.MinSubtotal,
+		promotion.Priority,
+		promotion.Stackable,
+		promotion.Active,
+		promotion.StartsAt,
+		promotion.EndsAt,
+		promotion.CreatedBy,
+	).StructScan(promotion)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return promotion, nil
+}
+
+func (p PromotionRepository) GetById(ctx context.Context, promotionId uuid.UUID) (*entity.Promotion, error) {
+	promotion := new(entity.Promotion)
+	err := p.db.GetContext(ctx, promotion, promotionGetById, promotionId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "promotion not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return promotion, nil
+}
+
+func (p PromotionRepository) GetMany(ctx context.Context, offset int64, limit int64) ([]*entity.Promotion, error) {
+	promotions := make([]*entity.Promotion, 0)
+	err := p.db.SelectContext(ctx, &promotions, promotionGetMany, offset, limit)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return promotions, nil
+}
+
+// GetTotalCount returns the total number of promotions in the database
+func (p PromotionRepository) GetTotalCount(ctx context.Context) (int64, error) {
+	var total int64
+	err := p.db.GetContext(ctx, &total, promotionGetTotalCount)
+	if err != nil {
+		return 0, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return total, nil
+}
+
+// GetActive mengambil semua promosi yang berlaku pada waktu at
+func (p PromotionRepository) GetActive(ctx context.Context, at time.Time) ([]*entity.Promotion, error) {
+	promotions := make([]*entity.Promotion, 0)
+	err := p.db.SelectContext(ctx, &promotions, promotionGetActive, at)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return promotions, nil
+}
+
+func (p PromotionRepository) Update(ctx context.Context, promotion *entity.Promotion) (*entity.Promotion, error) {
+	err := p.db.QueryRowxContext(
+		ctx, promotionUpdate,
+		promotion.PromotionId,
+		promotion.Name,
+		promotion.RuleType,
+		promotion.Scope,
+		promotion.ScopeValue,
+		promotion.Percent,
+		promotion.BuyQuantity,
+		promotion.FreeQuantity,
+		promotion.BundleQuantity,
+		promotion.BundlePrice,
+		promotion.MinSubtotal,
+		promotion.Priority,
+		promotion.Stackable,
+		promotion.Active,
+		promotion.StartsAt,
+		promotion.EndsAt,
+	).StructScan(promotion)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "promotion not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return promotion, nil
+}
+
+func (p PromotionRepository) Delete(ctx context.Context, promotionId uuid.UUID) error {
+	result, err := p.db.ExecContext(ctx, promotionDelete, promotionId)
+	if err != nil {
+		return eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	if i, _ := result.RowsAffected(); i <= 0 {
+		return eris.Wrap(types.ErrNoRows, "promotion not found")
+	}
+
+	return nil
+}
+
+func (p PromotionRepository) CreateBook(ctx context.Context, promotionId uuid.UUID, bookId uuid.UUID) error {
+	_, err := p.db.ExecContext(ctx, promotionBookCreate, promotionId, bookId)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return eris.Wrap(types.ErrNoRows, "book not found")
+		}
+
+		return eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return nil
+}
+
+func (p PromotionRepository) GetBooksByPromotionId(ctx context.Context, promotionId uuid.UUID) ([]*entity.PromotionBook, error) {
+	books := make([]*entity.PromotionBook, 0)
+	err := p.db.SelectContext(ctx, &books, promotionBookGetByPromotionId, promotionId)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return books, nil
+}
+
+// GetActiveBooks mengambil daftar buku dari semua promosi yang berlaku pada waktu at
+func (p PromotionRepository) GetActiveBooks(ctx context.Context, at time.Time) ([]*entity.PromotionBook, error) {
+	books := make([]*entity.PromotionBook, 0)
+	err := p.db.SelectContext(ctx, &books, promotionBookGetActive, at)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return books, nil
+}
+
+// DeleteBooks menghapus semua buku dari promosi, dipakai saat daftar buku diganti
+func (p PromotionRepository) DeleteBooks(ctx context.Context, promotionId uuid.UUID) error {
+	_, err := p.db.ExecContext(ctx, promotionBookDeleteByPromotionId, promotionId)
+	if err != nil {
+		return eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+	"github.com/shopspring/decimal"
+)
+
+type PurchaseOrderRepository struct {
+	db dbtx
+}
+
+func NewPurchaseOrderRepository(db *sqlx.DB) *PurchaseOrderRepository {
+	return &PurchaseOrderRepository{db}
+}
+
+// WithTx mengembalikan salinan repository yang menjalankan kueri di dalam transaksi tx
+func (p PurchaseOrderRepository) WithTx(tx *sqlx.Tx) *PurchaseOrderRepository {
+	return &PurchaseOrderRepository{tx}
+}
+
+func (p PurchaseOrderRepository) Create(ctx context.Context, order *entity.PurchaseOrder) (*entity.PurchaseOrder, error) {
+	err := p.db.QueryRowxContext(
+		ctx, purchaseOrderCreate,
+		order.PurchaseOrderId,
+		order.SupplierId,
+		order.Status,
+		order.Notes,
+		order.ExpectedAt,
+		order.TotalCost,
+		order.CreatedBy,
+	).StructScan(order)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, eris.Wrap(types.ErrNoRows, "supplier not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return order, nil
+}
+
+func (p PurchaseOrderRepository) CreateItem(ctx context.Context, item *entity.PurchaseOrderItem) (*entity.PurchaseOrderItem, error) {
+	err := p.db.QueryRowxContext(
+		ctx, purchaseOrderItemCreate,
+		item.PurchaseOrderItemId,
+		item.PurchaseOrderId,
+		item.BookId,
+		item.QuantityOrdered,
+		item.QuantityReceived,
+		item.UnitCost,
+	).StructScan(item)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, eris.Wrap(types.ErrNoRows, "book not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return item, nil
+}
+
+func (p PurchaseOrderRepository) GetById(ctx context.Context, orderId uuid.UUID) (*entity.PurchaseOrder, error) {
+	return p.get(ctx, purchaseOrderGetById, orderId)
+}
+
+// GetByIdForUpdate mengambil purchase order sekaligus mengunci barisnya sampai transaksi selesai
+func (p PurchaseOrderRepository) GetByIdForUpdate(ctx context.Context, orderId uuid.UUID) (*entity.PurchaseOrder, error) {
+	return p.get(ctx, purchaseOrderGetByIdForUpdate, orderId)
+}
+
+func (p PurchaseOrderRepository) get(ctx context.Context, query string, orderId uuid.UUID) (*entity.PurchaseOrder, error) {
+	order := new(entity.PurchaseOrder)
+	err := p.db.GetContext(ctx, order, query, orderId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "purchase order not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return order, nil
+}
+
+func (p PurchaseOrderRepository) GetItemsByPurchaseOrderId(ctx context.Context, orderId uuid.UUID) ([]*entity.PurchaseOrderItem, error) {
+	items := make([]*entity.PurchaseOrderItem, 0)
+	err := p.db.SelectContext(ctx, &items, purchaseOrderItemGetByPurchaseOrderId, orderId)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return items, nil
+}
+
+// GetLastUnitCost mengambil harga satuan terakhir sebuah buku dari supplier tertentu.
+// Nilai nol dikembalikan jika buku belum pernah dipesan dari supplier tersebut
+func (p PurchaseOrderRepository) GetLastUnitCost(ctx context.Context, bookId uuid.UUID, supplierId uuid.UUID) (decimal.Decimal, error) {
+	var unitCost decimal.Decimal
+	err := p.db.GetContext(ctx, &unitCost, purchaseOrderItemGetLastUnitCost, bookId, supplierId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return decimal.Zero, nil
+		}
+
+		return decimal.Zero, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return unitCost, nil
+}
+
+// UpdateItemReceived menyimpan jumlah total barang yang sudah diterima untuk satu item purchase order
+func (p PurchaseOrderRepository) UpdateItemReceived(ctx context.Context, itemId uuid.UUID, quantityReceived int64) (*entity.PurchaseOrderItem, error) {
+	item := new(entity.PurchaseOrderItem)
+	err := p.db.GetContext(ctx, item, purchaseOrderItemUpdateReceived, itemId, quantityReceived)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "purchase order item not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return item, nil
+}
+
+// GetMany mengambil daftar purchase order terbaru. Filter status dan supplierId yang kosong diabaikan
+func (p PurchaseOrderRepository) GetMany(ctx context.Context, status string, supplierId uuid.NullUUID, offset int64, limit int64) ([]*entity.PurchaseOrder, error) {
+	orders := make([]*entity.PurchaseOrder, 0)
+	err := p.db.SelectContext(ctx, &orders, purchaseOrderGetMany, status, supplierId, offset, limit)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return orders, nil
+}
+
+// GetTotalCount returns the total number of purchase orders matching the filter
+func (p PurchaseOrderRepository) GetTotalCount(ctx context.Context, status string, supplierId uuid.NullUUID) (int64, error) {
+	var total int64
+	err := p.db.GetContext(ctx, &total, purchaseOrderGetTotalCount, status, supplierId)
+	if err != nil {
+		return 0, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return total, nil
+}
+
+func (p PurchaseOrderRepository) Update(ctx context.Context, order *entity.PurchaseOrder) (*entity.PurchaseOrder, error) {
+	err := p.db.QueryRowxContext(
+		ctx, purchaseOrderUpdate,
+		order.PurchaseOrderId,
+		order.SupplierId,
+		order.Notes,
+		order.ExpectedAt,
+		order.TotalCost,
+	).StructScan(order)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "purchase order not found")
+		}
+
+		if isForeignKeyViolation(err) {
+			return nil, eris.Wrap(types.ErrNoRows, "supplier not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return order, nil
+}
+
+func (p PurchaseOrderRepository) UpdateStatus(ctx context.Context, orderId uuid.UUID, status entity.PurchaseOrderStatus) (*entity.PurchaseOrder, error) {
+	order := new(entity.PurchaseOrder)
+	err := p.db.QueryRowxContext(ctx, purchaseOrderUpdateStatus, orderId, status).StructScan(order)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "purchase order not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return order, nil
+}
+
+// DeleteItems menghapus semua item purchase order, dipakai saat item draft diganti
+func (p PurchaseOrderRepository) DeleteItems(ctx context.Context, orderId uuid.UUID) error {
+	_, err := p.db.ExecContext(ctx, purchaseOrderItemDeleteByPurchaseOrderId, orderId)
+	if err != nil {
+		return eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return nil
+}
+
+func (p PurchaseOrderRepository) Delete(ctx context.Context, orderId uuid.UUID) error {
+	result, err := p.db.ExecContext(ctx, purchaseOrderDelete, orderId)
+	if err != nil {
+		return eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	if i, _ := result.RowsAffected(); i <= 0 {
+		return eris.Wrap(types.ErrNoRows, "purchase order not found")
+	}
+
+	return nil
+}
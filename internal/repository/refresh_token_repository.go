@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+type RefreshTokenRepository struct {
+	db dbtx
+}
+
+func NewRefreshTokenRepository(db *sqlx.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db}
+}
+
+// WithTx mengembalikan salinan repository yang menjalankan kueri di dalam transaksi tx
+func (r RefreshTokenRepository) WithTx(tx *sqlx.Tx) *RefreshTokenRepository {
+	return &RefreshTokenRepository{tx}
+}
+
+func (r RefreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) (*entity.RefreshToken, error) {
+	err := r.db.QueryRowxContext(ctx, refreshTokenCreate, token.TokenId, token.UserId, token.ExpiresAt).StructScan(token)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return token, nil
+}
+
+// GetByIdForUpdate mengambil refresh token sekaligus mengunci barisnya sampai transaksi selesai
+func (r RefreshTokenRepository) GetByIdForUpdate(ctx context.Context, tokenId uuid.UUID) (*entity.RefreshToken, error) {
+	token := new(entity.RefreshToken)
+	err := r.db.GetContext(ctx, token, refreshTokenGetByIdForUpdate, tokenId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "refresh token not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return token, nil
+}
+
+// Revoke mencabut refresh token dan mencatat token penggantinya jika ada
+func (r RefreshTokenRepository) Revoke(ctx context.Context, tokenId uuid.UUID, replacedBy uuid.NullUUID) error {
+	_, err := r.db.ExecContext(ctx, refreshTokenRevoke, tokenId, replacedBy)
+	if err != nil {
+		return eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return nil
+}
+
+// RevokeAllByUserId mencabut seluruh refresh token aktif milik user
+func (r RefreshTokenRepository) RevokeAllByUserId(ctx context.Context, userId uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, refreshTokenRevokeAllByUser, userId)
+	if err != nil {
+		return eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return nil
+}
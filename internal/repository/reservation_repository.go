@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+type ReservationRepository struct {
+	db dbtx
+}
+
+func NewReservationRepository(db *sqlx.DB) *ReservationRepository {
+	return &ReservationRepository{db}
+}
+
+// WithTx mengembalikan salinan repository yang menjalankan kueri di dalam transaksi tx
+func (r ReservationRepository) WithTx(tx *sqlx.Tx) *ReservationRepository {
+	return &ReservationRepository{tx}
+}
+
+func (r ReservationRepository) Create(ctx context.Context, reservation *entity.Reservation) (*entity.Reservation, error) {
+	err := r.db.QueryRowxContext(
+		ctx, reservationCreate,
+		reservation.ReservationId,
+		reservation.LocationId,
+		reservation.Status,
+		reservation.Channel,
+		reservation.CustomerName,
+		reservation.CustomerContact,
+		reservation.Notes,
+		reservation.ExpiresAt,
+		reservation.CreatedBy,
+	).StructScan(reservation)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, eris.Wrap(types.ErrMissingReference, "location not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return reservation, nil
+}
+
+func (r ReservationRepository) CreateItem(ctx context.Context, item *entity.ReservationItem) (*entity.ReservationItem, error) {
+	err := r.db.QueryRowxContext(
+		ctx, reservationItemCreate,
+		item.ReservationItemId,
+		item.ReservationId,
+		item.BookId,
+		item.Quantity,
+	).StructScan(item)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, eris.Wrap(types.ErrMissingReference, "book not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return item, nil
+}
+
+func (r ReservationRepository) GetById(ctx context.Context, reservationId uuid.UUID) (*entity.Reservation, error) {
+	return r.get(ctx, reservationGetById, reservationId)
+}
+
+// GetByIdForUpdate mengambil reservasi sekaligus mengunci barisnya sampai transaksi selesai
+func (r ReservationRepository) GetByIdForUpdate(ctx context.Context, reservationId uuid.UUID) (*entity.Reservation, error) {
+	return r.get(ctx, reservationGetByIdForUpdate, reservationId)
+}
+
+// SetStatus mengubah status reservasi, dipakai untuk pembatalan dan kedaluwarsa
+func (r ReservationRepository) SetStatus(ctx context.Context, reservationId uuid.UUID, status entity.ReservationStatus) (*entity.Reservation, error) {
+	return r.get(ctx, reservationSetStatus, reservationId, status)
+}
+
+// Fulfill menandai reservasi sudah dibeli lewat penjualan saleId
+func (r ReservationRepository) Fulfill(ctx context.Context, reservationId uuid.UUID, saleId uuid.UUID) (*entity.Reservation, error) {
+	return r.get(ctx, reservationFulfill, reservationId, saleId)
+}
+
+func (r ReservationRepository) get(ctx context.Context, query string, args ...any) (*entity.Reservation, error) {
+	reservation := new(entity.Reservation)
+	err := r.db.GetContext(ctx, reservation, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "reservation not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return reservation, nil
+}
+
+// GetItemsByReservationId mengambil item reservasi urut berdasarkan book_id
+func (r ReservationRepository) GetItemsByReservationId(ctx context.Context, reservationId uuid.UUID) ([]*entity.ReservationItem, error) {
+	items := make([]*entity.ReservationItem, 0)
+	err := r.db.SelectContext(ctx, &items, reservationItemGetByReservationId, reservationId)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return items, nil
+}
+
+// GetExpiredForUpdate mengambil dan mengunci paling banyak limit reservasi aktif yang sudah
+// melewati expires_at. Reservasi yang sedang dikunci transaksi lain dilewati
+func (r ReservationRepository) GetExpiredForUpdate(ctx context.Context, limit int64) ([]*entity.Reservation, error) {
+	reservations := make([]*entity.Reservation, 0)
+	err := r.db.SelectContext(ctx, &reservations, reservationGetExpiredForUpdate, limit)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return reservations, nil
+}
+
+// GetMany mengambil daftar reservasi terbaru. bookId mencocokkan reservasi yang memuat buku tersebut.
+// Filter yang kosong diabaikan
+func (r ReservationRepository) GetMany(ctx context.Context, status string, locationId uuid.NullUUID, bookId uuid.NullUUID, offset int64, limit int64) ([]*entity.Reservation, error) {
+	reservations := make([]*entity.Reservation, 0)
+	err := r.db.SelectContext(ctx, &reservations, reservationGetMany, status, locationId, bookId, offset, limit)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return reservations, nil
+}
+
+// GetTotalCount returns the total number of reservations matching the filter
+func (r ReservationRepository) GetTotalCount(ctx context.Context, status string, locationId uuid.NullUUID, bookId uuid.NullUUID) (int64, error) {
+	var total int64
+	err := r.db.GetContext(ctx, &total, reservationGetTotalCount, status, locationId, bookId)
+	if err != nil {
+		return 0, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return total, nil
+}
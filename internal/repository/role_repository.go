@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+type RoleRepository struct {
+	db dbtx
+}
+
+func NewRoleRepository(db *sqlx.DB) *RoleRepository {
+	return &RoleRepository{db}
+}
+
+func (r RoleRepository) GetById(ctx context.Context, roleId int64) (*entity.Role, error) {
+	role := new(entity.Role)
+	err := r.db.GetContext(ctx, role, roleGetById, roleId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "role not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return role, nil
+}
+
+func (r RoleRepository) GetByName(ctx context.Context, name string) (*entity.Role, error) {
+	role := new(entity.Role)
+	err := r.db.GetContext(ctx, role, roleGetByName, name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "role not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return role, nil
+}
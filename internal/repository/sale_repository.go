@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+type SaleRepository struct {
+	db dbtx
+}
+
+func NewSaleRepository(db *sqlx.DB) *SaleRepository {
+	return &SaleRepository{db}
+}
+
+// WithTx mengembalikan salinan repository yang menjalankan kueri di dalam transaksi tx
+func (s SaleRepository) WithTx(tx *sqlx.Tx) *SaleRepository {
+	return &SaleRepository{tx}
+}
+
+func (s SaleRepository) Create(ctx context.Context, sale *entity.Sale) (*entity.Sale, error) {
+	err := s.db.QueryRowxContext(
+		ctx, saleCreate,
+		sale.SaleId,
+		sale.CashierId,
+		sale.PaymentMethod,
+		sale.Subtotal,
+		sale.DiscountTotal,
+		sale.Total,
+		sale.AmountPaid,
+		sale.ChangeDue,
+		sale.Notes,
+	).StructScan(sale)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return sale, nil
+}
+
+func (s SaleRepository) CreateItem(ctx context.Context, item *entity.SaleItem) (*entity.SaleItem, error) {
+	err := s.db.QueryRowxContext(
+		ctx, saleItemCreate,
+		item.SaleItemId,
+		item.SaleId,
+		item.BookId,
+		item.ISBN,
+		item.Title,
+		item.Quantity,
+		item.UnitPrice,
+		item.Discount,
+		item.LineTotal,
+	).StructScan(item)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return item, nil
+}
+
+func (s SaleRepository) GetById(ctx context.Context, saleId uuid.UUID) (*entity.Sale, error) {
+	sale := new(entity.Sale)
+	err := s.db.GetContext(ctx, sale, saleGetById, saleId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "sale not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return sale, nil
+}
+
+func (s SaleRepository) GetItemsBySaleId(ctx context.Context, saleId uuid.UUID) ([]*entity.SaleItem, error) {
+	items := make([]*entity.SaleItem, 0)
+	err := s.db.SelectContext(ctx, &items, saleItemGetBySaleId, saleId)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return items, nil
+}
+
+func (s SaleRepository) GetMany(ctx context.Context, offset int64, limit int64) ([]*entity.Sale, error) {
+	sales := make([]*entity.Sale, 0)
+	err := s.db.SelectContext(ctx, &sales, saleGetMany, offset, limit)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return sales, nil
+}
+
+// GetTotalCount returns the total number of sales in the database
+func (s SaleRepository) GetTotalCount(ctx context.Context) (int64, error) {
+	var total int64
+	err := s.db.GetContext(ctx, &total, saleGetTotalCount)
+	if err != nil {
+		return 0, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return total, nil
+}
+
+func (s SaleRepository) CreatePromotion(ctx context.Context, promotion *entity.SalePromotion) (*entity.SalePromotion, error) {
+	err := s.db.QueryRowxContext(
+		ctx, salePromotionCreate,
+		promotion.SalePromotionId,
+		promotion.SaleId,
+		promotion.PromotionId,
+		promotion.Name,
+		promotion.Discount,
+	).StructScan(promotion)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return promotion, nil
+}
+
+func (s SaleRepository) GetPromotionsBySaleId(ctx context.Context, saleId uuid.UUID) ([]*entity.SalePromotion, error) {
+	promotions := make([]*entity.SalePromotion, 0)
+	err := s.db.SelectContext(ctx, &promotions, salePromotionGetBySaleId, saleId)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return promotions, nil
+}
+
+// GetByIdForUpdate mengambil penjualan sekaligus mengunci barisnya sampai transaksi selesai
+func (s SaleRepository) GetByIdForUpdate(ctx context.Context, saleId uuid.UUID) (*entity.Sale, error) {
+	sale := new(entity.Sale)
+	err := s.db.GetContext(ctx, sale, saleGetByIdForUpdate, saleId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "sale not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return sale, nil
+}
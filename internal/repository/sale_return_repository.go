@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+type SaleReturnRepository struct {
+	db dbtx
+}
+
+func NewSaleReturnRepository(db *sqlx.DB) *SaleReturnRepository {
+	return &SaleReturnRepository{db}
+}
+
+// WithTx mengembalikan salinan repository yang menjalankan kueri di dalam transaksi tx
+func (s SaleReturnRepository) WithTx(tx *sqlx.Tx) *SaleReturnRepository {
+	return &SaleReturnRepository{tx}
+}
+
+func (s SaleReturnRepository) Create(ctx context.Context, saleReturn *entity.SaleReturn) (*entity.SaleReturn, error) {
+	err := s.db.QueryRowxContext(
+		ctx, saleReturnCreate,
+		saleReturn.SaleReturnId,
+		saleReturn.SaleId,
+		saleReturn.Reason,
+		saleReturn.RefundTotal,
+		saleReturn.CreatedBy,
+	).StructScan(saleReturn)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return saleReturn, nil
+}
+
+func (s SaleReturnRepository) CreateItem(ctx context.Context, item *entity.SaleReturnItem) (*entity.SaleReturnItem, error) {
+	err := s.db.QueryRowxContext(
+		ctx, saleReturnItemCreate,
+		item.SaleReturnItemId,
+		item.SaleReturnId,
+		item.SaleItemId,
+		item.BookId,
+		item.Quantity,
+		item.Condition,
+		item.RefundAmount,
+	).StructScan(item)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return item, nil
+}
+
+func (s SaleReturnRepository) GetBySaleId(ctx context.Context, saleId uuid.UUID) ([]*entity.SaleReturn, error) {
+	returns := make([]*entity.SaleReturn, 0)
+	err := s.db.SelectContext(ctx, &returns, saleReturnGetBySaleId, saleId)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return returns, nil
+}
+
+// GetItemsBySaleId mengambil item dari semua retur sebuah penjualan
+func (s SaleReturnRepository) GetItemsBySaleId(ctx context.Context, saleId uuid.UUID) ([]*entity.SaleReturnItem, error) {
+	items := make([]*entity.SaleReturnItem, 0)
+	err := s.db.SelectContext(ctx, &items, saleReturnItemGetBySaleId, saleId)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return items, nil
+}
+
+// GetReturnedQuantities mengembalikan jumlah barang yang sudah diretur untuk setiap item penjualan
+func (s SaleReturnRepository) GetReturnedQuantities(ctx context.Context, saleId uuid.UUID) (map[uuid.UUID]int64, error) {
+	var rows []struct {
+		SaleItemId uuid.UUID `db:"sale_item_id"`
+		Quantity   int64     `db:"quantity"`
+	}
+
+	err := s.db.SelectContext(ctx, &rows, saleReturnItemGetReturnedQuantities, saleId)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	returned := make(map[uuid.UUID]int64, len(rows))
+	for _, row := range rows {
+		returned[row.SaleItemId] = row.Quantity
+	}
+
+	return returned, nil
+}
@@ -1,18 +1,319 @@
 package repository
 
+// bookColumns dipakai sebagai pengganti * agar kolom internal seperti search_vector tidak ikut terbaca
+const bookColumns = `book_id,isbn,title,author,publisher,published_at,stock,reserved,reorder_point,reorder_quantity,preferred_supplier_id,cost_price,sale_price,currency,created_at,updated_at`
+
+// bookAtLocation adalah books dengan kolom stock dan reserved diganti stok di lokasi $1, sehingga kueri
+// daftar buku bisa difilter per lokasi tanpa diubah. search_vector ikut dipilih untuk pencarian teks
+const bookAtLocation = `(SELECT b.book_id,b.isbn,b.title,b.author,b.publisher,b.published_at,COALESCE(s.quantity, 0) AS stock,COALESCE(s.reserved, 0) AS reserved,
+b.reorder_point,b.reorder_quantity,b.preferred_supplier_id,b.cost_price,b.sale_price,b.currency,b.created_at,b.updated_at,b.search_vector
+FROM books b LEFT JOIN location_stock s ON s.book_id = b.book_id AND s.location_id = $1)`
+
 const (
-	bookCreate        = `INSERT INTO books(book_id,isbn,title,author,publisher,published_at,stock) VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING *`
-	bookGetById       = `SELECT * FROM books WHERE book_id = $1 LIMIT 1`
-	bookGetByISBN     = `SELECT * FROM books WHERE isbn = $1 LIMIT 1`
-	bookGetBooksMany  = `SELECT * FROM books OFFSET $1 LIMIT $2`
-	bookDelete        = `DELETE FROM books WHERE book_id = $1 RETURNING book_id`
-	bookGetTotalCount = `SELECT COUNT(*) FROM books`
-	bookUpdate        = `UPDATE books SET
+	bookCreate = `INSERT INTO books(book_id,isbn,title,author,publisher,published_at,stock,reorder_point,reorder_quantity,preferred_supplier_id,cost_price,sale_price,currency)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13) RETURNING ` + bookColumns
+	bookGetById          = `SELECT ` + bookColumns + ` FROM books WHERE book_id = $1 LIMIT 1`
+	bookGetByISBN        = `SELECT ` + bookColumns + ` FROM books WHERE isbn = $1 LIMIT 1`
+	bookGetBooksMany     = `SELECT ` + bookColumns
+	bookDelete           = `DELETE FROM books WHERE book_id = $1 RETURNING book_id`
+	bookGetTotalCount    = `SELECT COUNT(*)`
+	bookGetByIdForUpdate = `SELECT ` + bookColumns + ` FROM books WHERE book_id = $1 LIMIT 1 FOR UPDATE`
+	bookExists           = `SELECT EXISTS(SELECT 1 FROM books WHERE book_id = $1)`
+	bookAdjustStock      = `UPDATE books SET stock = stock + $2, updated_at = NOW() WHERE book_id = $1 AND stock + $2 >= 0 RETURNING ` + bookColumns
+	// baris stok lokasi dibuat saat stok pertama kali masuk. Pengurangan dari baris yang belum ada
+	// ditolak oleh CHECK quantity >= 0, pengurangan yang melebihi stok tidak mengembalikan baris
+	bookAdjustLocationStock = `INSERT INTO location_stock(book_id,location_id,quantity) VALUES ($1,$2,$3)
+ON CONFLICT (book_id, location_id) DO UPDATE SET quantity = location_stock.quantity + EXCLUDED.quantity, updated_at = NOW()
+WHERE location_stock.quantity + EXCLUDED.quantity >= 0 RETURNING quantity`
+	// sama seperti bookAdjustStock dan bookAdjustLocationStock, tetapi stok yang ditahan reservasi tidak boleh ikut berkurang
+	bookAdjustAvailableStock         = `UPDATE books SET stock = stock + $2, updated_at = NOW() WHERE book_id = $1 AND stock - reserved + $2 >= 0 RETURNING ` + bookColumns
+	bookAdjustAvailableLocationStock = `INSERT INTO location_stock(book_id,location_id,quantity) VALUES ($1,$2,$3)
+ON CONFLICT (book_id, location_id) DO UPDATE SET quantity = location_stock.quantity + EXCLUDED.quantity, updated_at = NOW()
+WHERE location_stock.quantity - location_stock.reserved + EXCLUDED.quantity >= 0 RETURNING quantity`
+	// reservasi baru hanya boleh memakai stok lokasi yang belum ditahan, pelepasan ($3 negatif) selalu diizinkan
+	bookReserve              = `UPDATE books SET reserved = reserved + $2, updated_at = NOW() WHERE book_id = $1 RETURNING ` + bookColumns
+	bookReserveLocationStock = `UPDATE location_stock SET reserved = reserved + $3, updated_at = NOW()
+WHERE book_id = $1 AND location_id = $2 AND ($3 < 0 OR quantity - reserved >= $3) RETURNING reserved`
+	bookGetByIdAtLocation = `SELECT ` + bookColumns + ` FROM ` + bookAtLocation + ` AS books WHERE book_id = $2 LIMIT 1`
+	bookUpdate            = `UPDATE books SET
 isbn = COALESCE(NULLIF($2, ''), isbn),
 title = COALESCE(NULLIF($3, ''), title),
 author = COALESCE(NULLIF($4, ''), author),
 publisher = COALESCE(NULLIF($5, ''), publisher),
 published_at = COALESCE(NULLIF($6, '0001-01-01'::date), published_at),
-stock = CASE WHEN $7 < 0 THEN stock ELSE $7 END,
-updated_at = NOW() WHERE book_id = $1 RETURNING *`
+reorder_point = COALESCE($7, reorder_point),
+reorder_quantity = COALESCE($8, reorder_quantity),
+preferred_supplier_id = CASE WHEN $10 THEN NULL ELSE COALESCE($9, preferred_supplier_id) END,
+cost_price = COALESCE($11::numeric, cost_price),
+sale_price = COALESCE($12::numeric, sale_price),
+currency = COALESCE(NULLIF($13, ''), currency),
+updated_at = NOW() WHERE book_id = $1 RETURNING ` + bookColumns
+
+	// on_order dihitung dari purchase order yang barangnya belum diterima seluruhnya
+	bookLowStockCondition = `reorder_quantity > 0 AND stock <= reorder_point AND ($1::uuid IS NULL OR preferred_supplier_id = $1)`
+	bookGetLowStock       = `SELECT ` + bookColumns + `, COALESCE((
+SELECT SUM(GREATEST(i.quantity_ordered - i.quantity_received, 0))::bigint FROM purchase_order_items i
+JOIN purchase_orders o USING (purchase_order_id)
+WHERE i.book_id = books.book_id AND o.status IN ('draft', 'sent', 'partially_received')
+), 0) AS on_order FROM books WHERE ` + bookLowStockCondition + ` ORDER BY reorder_point - stock DESC, book_id OFFSET $2 LIMIT $3`
+	bookGetLowStockTotalCount = `SELECT COUNT(*) FROM books WHERE ` + bookLowStockCondition
+)
+
+// effective_from dan effective_to memakai NOW() yang bernilai sama selama satu transaksi,
+// sehingga baris lama dan baris baru tidak memiliki celah waktu
+const (
+	bookPriceHistoryCreate = `INSERT INTO book_price_history(price_history_id,book_id,cost_price,sale_price,currency,changed_by)
+VALUES ($1,$2,$3,$4,$5,$6) RETURNING *`
+	bookPriceHistoryCloseCurrent          = `UPDATE book_price_history SET effective_to = NOW() WHERE book_id = $1 AND effective_to IS NULL`
+	bookPriceHistoryGetManyByBookId       = `SELECT * FROM book_price_history WHERE book_id = $1 ORDER BY effective_from DESC, price_history_id DESC OFFSET $2 LIMIT $3`
+	bookPriceHistoryGetTotalCountByBookId = `SELECT COUNT(*) FROM book_price_history WHERE book_id = $1`
+)
+
+const (
+	stockMovementCreate = `INSERT INTO stock_movements(movement_id,book_id,location_id,movement_type,quantity,stock_after,reason,reference,created_by)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING *`
+	stockMovementGetManyByBookId       = `SELECT * FROM stock_movements WHERE book_id = $1 ORDER BY created_at DESC, movement_id DESC OFFSET $2 LIMIT $3`
+	stockMovementGetTotalCountByBookId = `SELECT COUNT(*) FROM stock_movements WHERE book_id = $1`
+)
+
+const (
+	userCreate        = `INSERT INTO users(user_id,username,full_name,password_hash,role_id) VALUES ($1,$2,$3,$4,$5) RETURNING *`
+	userGetById       = `SELECT * FROM users WHERE user_id = $1 LIMIT 1`
+	userGetByUsername = `SELECT * FROM users WHERE username = $1 LIMIT 1`
+	userGetTotalCount = `SELECT COUNT(*) FROM users`
+	userHasPermission = `SELECT EXISTS(
+SELECT 1 FROM users u
+JOIN role_permissions rp ON rp.role_id = u.role_id
+JOIN permissions p ON p.permission_id = rp.permission_id
+WHERE u.user_id = $1 AND u.is_active AND p.name = $2)`
+)
+
+const (
+	roleGetById   = `SELECT * FROM roles WHERE role_id = $1 LIMIT 1`
+	roleGetByName = `SELECT * FROM roles WHERE name = $1 LIMIT 1`
+)
+
+const (
+	refreshTokenCreate           = `INSERT INTO refresh_tokens(token_id,user_id,expires_at) VALUES ($1,$2,$3) RETURNING *`
+	refreshTokenGetByIdForUpdate = `SELECT * FROM refresh_tokens WHERE token_id = $1 LIMIT 1 FOR UPDATE`
+	refreshTokenRevoke           = `UPDATE refresh_tokens SET revoked_at = NOW(), replaced_by = $2 WHERE token_id = $1 AND revoked_at IS NULL`
+	refreshTokenRevokeAllByUser  = `UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`
+)
+
+const (
+	saleCreate = `INSERT INTO sales(sale_id,cashier_id,payment_method,subtotal,discount_total,total,amount_paid,change_due,notes)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING *`
+	saleGetById          = `SELECT * FROM sales WHERE sale_id = $1 LIMIT 1`
+	saleGetByIdForUpdate = `SELECT * FROM sales WHERE sale_id = $1 LIMIT 1 FOR UPDATE`
+	saleGetMany          = `SELECT * FROM sales ORDER BY created_at DESC, sale_id DESC OFFSET $1 LIMIT $2`
+	saleGetTotalCount    = `SELECT COUNT(*) FROM sales`
+	saleItemCreate       = `INSERT INTO sale_items(sale_item_id,sale_id,book_id,isbn,title,quantity,unit_price,discount,line_total)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING *`
+	saleItemGetBySaleId = `SELECT * FROM sale_items WHERE sale_id = $1 ORDER BY sale_item_id`
+	salePromotionCreate = `INSERT INTO sale_promotions(sale_promotion_id,sale_id,promotion_id,name,discount)
+VALUES ($1,$2,$3,$4,$5) RETURNING *`
+	salePromotionGetBySaleId = `SELECT * FROM sale_promotions WHERE sale_id = $1 ORDER BY sale_promotion_id`
+)
+
+const (
+	saleReturnCreate = `INSERT INTO sale_returns(sale_return_id,sale_id,reason,refund_total,created_by)
+VALUES ($1,$2,$3,$4,$5) RETURNING *`
+	saleReturnGetBySaleId = `SELECT * FROM sale_returns WHERE sale_id = $1 ORDER BY created_at, sale_return_id`
+	saleReturnItemCreate  = `INSERT INTO sale_return_items(sale_return_item_id,sale_return_id,sale_item_id,book_id,quantity,condition,refund_amount)
+VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING *`
+	saleReturnItemGetBySaleId = `SELECT i.* FROM sale_return_items i JOIN sale_returns r USING (sale_return_id)
+WHERE r.sale_id = $1 ORDER BY i.sale_return_item_id`
+	saleReturnItemGetReturnedQuantities = `SELECT i.sale_item_id, SUM(i.quantity)::bigint AS quantity FROM sale_return_items i
+JOIN sale_returns r USING (sale_return_id) WHERE r.sale_id = $1 GROUP BY i.sale_item_id`
+)
+
+const (
+	supplierCreate = `INSERT INTO suppliers(supplier_id,name,contact_name,email,phone,address,lead_time_days)
+VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING *`
+	supplierGetById       = `SELECT * FROM suppliers WHERE supplier_id = $1 LIMIT 1`
+	supplierGetMany       = `SELECT * FROM suppliers ORDER BY name, supplier_id OFFSET $1 LIMIT $2`
+	supplierGetTotalCount = `SELECT COUNT(*) FROM suppliers`
+	supplierDelete        = `DELETE FROM suppliers WHERE supplier_id = $1`
+	supplierUpdate        = `UPDATE suppliers SET
+name = COALESCE($2, name),
+contact_name = COALESCE($3, contact_name),
+email = COALESCE($4, email),
+phone = COALESCE($5, phone),
+address = COALESCE($6, address),
+lead_time_days = COALESCE($7, lead_time_days),
+updated_at = NOW() WHERE supplier_id = $1 RETURNING *`
+)
+
+const (
+	purchaseOrderCreate = `INSERT INTO purchase_orders(purchase_order_id,supplier_id,status,notes,expected_at,total_cost,created_by)
+VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING *`
+	purchaseOrderGetById          = `SELECT * FROM purchase_orders WHERE purchase_order_id = $1 LIMIT 1`
+	purchaseOrderGetByIdForUpdate = `SELECT * FROM purchase_orders WHERE purchase_order_id = $1 LIMIT 1 FOR UPDATE`
+	purchaseOrderGetMany          = `SELECT * FROM purchase_orders WHERE ($1 = '' OR status = $1) AND ($2::uuid IS NULL OR supplier_id = $2)
+ORDER BY created_at DESC, purchase_order_id DESC OFFSET $3 LIMIT $4`
+	purchaseOrderGetTotalCount = `SELECT COUNT(*) FROM purchase_orders WHERE ($1 = '' OR status = $1) AND ($2::uuid IS NULL OR supplier_id = $2)`
+	purchaseOrderUpdate        = `UPDATE purchase_orders SET supplier_id = $2, notes = $3, expected_at = $4, total_cost = $5, updated_at = NOW()
+WHERE purchase_order_id = $1 RETURNING *`
+	purchaseOrderUpdateStatus = `UPDATE purchase_orders SET status = $2, updated_at = NOW() WHERE purchase_order_id = $1 RETURNING *`
+	purchaseOrderDelete       = `DELETE FROM purchase_orders WHERE purchase_order_id = $1`
+	purchaseOrderItemCreate   = `INSERT INTO purchase_order_items(purchase_order_item_id,purchase_order_id,book_id,quantity_ordered,quantity_received,unit_cost)
+VALUES ($1,$2,$3,$4,$5,$6) RETURNING *`
+	purchaseOrderItemGetByPurchaseOrderId = `SELECT * FROM purchase_order_items WHERE purchase_order_id = $1 ORDER BY purchase_order_item_id`
+	purchaseOrderItemGetLastUnitCost      = `SELECT i.unit_cost FROM purchase_order_items i JOIN purchase_orders o USING (purchase_order_id)
+WHERE i.book_id = $1 AND o.supplier_id = $2 AND o.status <> 'cancelled' ORDER BY o.created_at DESC LIMIT 1`
+	purchaseOrderItemUpdateReceived          = `UPDATE purchase_order_items SET quantity_received = $2 WHERE purchase_order_item_id = $1 RETURNING *`
+	purchaseOrderItemDeleteByPurchaseOrderId = `DELETE FROM purchase_order_items WHERE purchase_order_id = $1`
+)
+
+// promosi berlaku jika aktif dan waktu $1 berada di antara starts_at (inklusif) dan ends_at (eksklusif)
+const promotionActiveCondition = `active AND starts_at <= $1 AND (ends_at IS NULL OR ends_at > $1)`
+
+const (
+	promotionCreate = `INSERT INTO promotions(promotion_id,name,rule_type,scope,scope_value,percent,buy_quantity,free_quantity,bundle_quantity,
+bundle_price,min_subtotal,priority,stackable,active,starts_at,ends_at,created_by)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17) RETURNING *`
+	promotionGetById       = `SELECT * FROM promotions WHERE promotion_id = $1 LIMIT 1`
+	promotionGetMany       = `SELECT * FROM promotions ORDER BY priority DESC, starts_at DESC, promotion_id OFFSET $1 LIMIT $2`
+	promotionGetTotalCount = `SELECT COUNT(*) FROM promotions`
+	promotionGetActive     = `SELECT * FROM promotions WHERE ` + promotionActiveCondition + ` ORDER BY priority DESC, promotion_id`
+	promotionUpdate        = `UPDATE promotions SET name = $2, rule_type = $3, scope = $4, scope_value = $5, percent = $6, buy_quantity = $7,
+free_quantity = $8, bundle_quantity = $9, bundle_price = $10, min_subtotal = $11, priority = $12, stackable = $13, active = $14,
+starts_at = $15, ends_at = $16, updated_at = NOW() WHERE promotion_id = $1 RETURNING *`
+	promotionDelete                  = `DELETE FROM promotions WHERE promotion_id = $1`
+	promotionBookCreate              = `INSERT INTO promotion_books(promotion_id,book_id) VALUES ($1,$2)`
+	promotionBookGetByPromotionId    = `SELECT * FROM promotion_books WHERE promotion_id = $1 ORDER BY book_id`
+	promotionBookDeleteByPromotionId = `DELETE FROM promotion_books WHERE promotion_id = $1`
+	promotionBookGetActive           = `SELECT pb.* FROM promotion_books pb JOIN promotions USING (promotion_id) WHERE ` + promotionActiveCondition
+)
+
+const (
+	locationCreate = `INSERT INTO locations(location_id,code,name,address,is_default)
+VALUES ($1,$2,$3,$4,$5) RETURNING *`
+	locationGetById       = `SELECT * FROM locations WHERE location_id = $1 LIMIT 1`
+	locationGetByCode     = `SELECT * FROM locations WHERE code = $1 LIMIT 1`
+	locationGetDefault    = `SELECT * FROM locations WHERE is_default LIMIT 1`
+	locationGetMany       = `SELECT * FROM locations ORDER BY is_default DESC, name, location_id OFFSET $1 LIMIT $2`
+	locationGetTotalCount = `SELECT COUNT(*) FROM locations`
+	locationUpdate        = `UPDATE locations SET
+code = COALESCE($2, code),
+name = COALESCE($3, name),
+address = COALESCE($4, address),
+updated_at = NOW() WHERE location_id = $1 RETURNING *`
+	locationClearDefault     = `UPDATE locations SET is_default = FALSE, updated_at = NOW() WHERE is_default AND location_id <> $1`
+	locationSetDefault       = `UPDATE locations SET is_default = TRUE, updated_at = NOW() WHERE location_id = $1 RETURNING *`
+	locationDelete           = `DELETE FROM locations WHERE location_id = $1`
+	locationDeleteEmptyStock = `DELETE FROM location_stock WHERE location_id = $1 AND quantity = 0`
+
+	locationStockGetByBookId = `SELECT s.book_id,s.location_id,l.code,l.name,s.quantity,s.updated_at FROM location_stock s
+JOIN locations l USING (location_id) WHERE s.book_id = $1 ORDER BY l.is_default DESC, l.name, s.location_id`
+)
+
+const (
+	stockTransferCreate = `INSERT INTO stock_transfers(transfer_id,from_location_id,to_location_id,status,notes,requested_by)
+VALUES ($1,$2,$3,$4,$5,$6) RETURNING *`
+	stockTransferGetById          = `SELECT * FROM stock_transfers WHERE transfer_id = $1 LIMIT 1`
+	stockTransferGetByIdForUpdate = `SELECT * FROM stock_transfers WHERE transfer_id = $1 LIMIT 1 FOR UPDATE`
+	stockTransferGetMany          = `SELECT * FROM stock_transfers WHERE ($1 = '' OR status = $1)
+AND ($2::uuid IS NULL OR from_location_id = $2 OR to_location_id = $2)
+ORDER BY created_at DESC, transfer_id DESC OFFSET $3 LIMIT $4`
+	stockTransferGetTotalCount = `SELECT COUNT(*) FROM stock_transfers WHERE ($1 = '' OR status = $1)
+AND ($2::uuid IS NULL OR from_location_id = $2 OR to_location_id = $2)`
+	stockTransferDispatch = `UPDATE stock_transfers SET status = 'in_transit', dispatched_by = $2, dispatched_at = NOW(), updated_at = NOW()
+WHERE transfer_id = $1 RETURNING *`
+	stockTransferReceive = `UPDATE stock_transfers SET status = 'received', received_by = $2, received_at = NOW(), updated_at = NOW()
+WHERE transfer_id = $1 RETURNING *`
+	stockTransferCancel     = `UPDATE stock_transfers SET status = 'cancelled', updated_at = NOW() WHERE transfer_id = $1 RETURNING *`
+	stockTransferItemCreate = `INSERT INTO stock_transfer_items(transfer_item_id,transfer_id,book_id,quantity)
+VALUES ($1,$2,$3,$4) RETURNING *`
+	stockTransferItemGetByTransferId = `SELECT * FROM stock_transfer_items WHERE transfer_id = $1 ORDER BY transfer_item_id`
+)
+
+// kondisi filter baris stock take berdasarkan $2: counted, uncounted, discrepancy atau kosong untuk semua
+const stockTakeLineFilter = `($2 = '' OR ($2 = 'counted' AND l.counted IS NOT NULL) OR ($2 = 'uncounted' AND l.counted IS NULL)
+OR ($2 = 'discrepancy' AND l.counted IS NOT NULL AND l.counted <> l.expected))`
+
+const (
+	stockTakeCreate = `INSERT INTO stock_takes(stock_take_id,location_id,status,notes,created_by)
+VALUES ($1,$2,$3,$4,$5) RETURNING *`
+	stockTakeGetById          = `SELECT * FROM stock_takes WHERE stock_take_id = $1 LIMIT 1`
+	stockTakeGetByIdForShare  = `SELECT * FROM stock_takes WHERE stock_take_id = $1 LIMIT 1 FOR SHARE`
+	stockTakeGetByIdForUpdate = `SELECT * FROM stock_takes WHERE stock_take_id = $1 LIMIT 1 FOR UPDATE`
+	stockTakeGetMany          = `SELECT * FROM stock_takes WHERE ($1 = '' OR status = $1) AND ($2::uuid IS NULL OR location_id = $2)
+ORDER BY created_at DESC, stock_take_id DESC OFFSET $3 LIMIT $4`
+	stockTakeGetTotalCount = `SELECT COUNT(*) FROM stock_takes WHERE ($1 = '' OR status = $1) AND ($2::uuid IS NULL OR location_id = $2)`
+	stockTakeApprove       = `UPDATE stock_takes SET status = 'approved', approved_by = $2, approved_at = NOW(), updated_at = NOW()
+WHERE stock_take_id = $1 RETURNING *`
+	stockTakeCancel = `UPDATE stock_takes SET status = 'cancelled', updated_at = NOW() WHERE stock_take_id = $1 RETURNING *`
+
+	// snapshot stok semua buku di lokasi stock take, termasuk buku yang stoknya nol
+	stockTakeLineSnapshot = `INSERT INTO stock_take_lines(stock_take_id,book_id,expected)
+SELECT $1, b.book_id, COALESCE(s.quantity, 0) FROM books b
+LEFT JOIN location_stock s ON s.book_id = b.book_id AND s.location_id = $2`
+	// buku yang ditambahkan setelah snapshot memakai stok saat pertama kali dihitung sebagai expected.
+	// $6 true berarti counted ditambahkan ke hasil hitung sebelumnya
+	stockTakeLineCount = `INSERT INTO stock_take_lines(stock_take_id,book_id,expected,counted,counted_by,counted_at)
+VALUES ($1,$2,COALESCE((SELECT quantity FROM location_stock WHERE book_id = $2 AND location_id = $3), 0),$4,$5,NOW())
+ON CONFLICT (stock_take_id, book_id) DO UPDATE SET
+counted = CASE WHEN $6::boolean THEN COALESCE(stock_take_lines.counted, 0) + EXCLUDED.counted ELSE EXCLUDED.counted END,
+counted_by = EXCLUDED.counted_by,
+counted_at = EXCLUDED.counted_at
+RETURNING *`
+	stockTakeLineGetMany = `SELECT l.*, b.title, b.isbn FROM stock_take_lines l JOIN books b USING (book_id)
+WHERE l.stock_take_id = $1 AND ` + stockTakeLineFilter + ` ORDER BY b.title, l.book_id OFFSET $3 LIMIT $4`
+	stockTakeLineGetTotalCount = `SELECT COUNT(*) FROM stock_take_lines l WHERE l.stock_take_id = $1 AND ` + stockTakeLineFilter
+	// baris yang perlu disesuaikan saat approval. $2 true berarti buku yang belum dihitung dianggap nol
+	stockTakeLineGetVariances = `SELECT * FROM stock_take_lines WHERE stock_take_id = $1 AND ($2 OR counted IS NOT NULL)
+AND COALESCE(counted, 0) <> expected ORDER BY book_id`
+	stockTakeLineGetSummary = `SELECT COUNT(*) AS total_books, COUNT(counted) AS counted_books,
+COUNT(*) FILTER (WHERE counted IS NOT NULL AND counted <> expected) AS discrepancy_books,
+COALESCE(SUM(counted - expected) FILTER (WHERE counted IS NOT NULL), 0) AS net_variance
+FROM stock_take_lines WHERE stock_take_id = $1`
+)
+
+const (
+	reservationCreate = `INSERT INTO reservations(reservation_id,location_id,status,channel,customer_name,customer_contact,notes,expires_at,created_by)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING *`
+	reservationGetById          = `SELECT * FROM reservations WHERE reservation_id = $1 LIMIT 1`
+	reservationGetByIdForUpdate = `SELECT * FROM reservations WHERE reservation_id = $1 LIMIT 1 FOR UPDATE`
+	reservationGetMany          = `SELECT * FROM reservations WHERE ($1 = '' OR status = $1) AND ($2::uuid IS NULL OR location_id = $2)
+AND ($3::uuid IS NULL OR reservation_id IN (SELECT reservation_id FROM reservation_items WHERE book_id = $3))
+ORDER BY created_at DESC, reservation_id DESC OFFSET $4 LIMIT $5`
+	reservationGetTotalCount = `SELECT COUNT(*) FROM reservations WHERE ($1 = '' OR status = $1) AND ($2::uuid IS NULL OR location_id = $2)
+AND ($3::uuid IS NULL OR reservation_id IN (SELECT reservation_id FROM reservation_items WHERE book_id = $3))`
+	// reservasi yang sedang diproses transaksi lain dilewati agar beberapa instance aplikasi bisa
+	// menjalankan worker bersamaan
+	reservationGetExpiredForUpdate = `SELECT * FROM reservations WHERE status = 'active' AND expires_at <= NOW()
+ORDER BY expires_at LIMIT $1 FOR UPDATE SKIP LOCKED`
+	reservationSetStatus = `UPDATE reservations SET status = $2, updated_at = NOW() WHERE reservation_id = $1 RETURNING *`
+	reservationFulfill   = `UPDATE reservations SET status = 'fulfilled', sale_id = $2, updated_at = NOW() WHERE reservation_id = $1 RETURNING *`
+
+	reservationItemCreate = `INSERT INTO reservation_items(reservation_item_id,reservation_id,book_id,quantity)
+VALUES ($1,$2,$3,$4) RETURNING *`
+	reservationItemGetByReservationId = `SELECT * FROM reservation_items WHERE reservation_id = $1 ORDER BY book_id`
+)
+
+// backorderSelect memilih backorder beserta posisinya di antrean buku dan lokasinya
+const backorderSelect = `SELECT b.*, CASE WHEN b.status = 'waiting' THEN (SELECT COUNT(*) FROM backorders w
+WHERE w.book_id = b.book_id AND w.location_id = b.location_id AND w.status = 'waiting'
+AND (w.created_at, w.backorder_id) <= (b.created_at, b.backorder_id)) END AS queue_position FROM backorders b`
+
+const (
+	backorderCreate = `INSERT INTO backorders(backorder_id,book_id,location_id,kind,status,quantity,customer_name,customer_contact,notes,created_by)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING *`
+	backorderGetById          = backorderSelect + ` WHERE b.backorder_id = $1 LIMIT 1`
+	backorderGetByIdForUpdate = `SELECT * FROM backorders WHERE backorder_id = $1 LIMIT 1 FOR UPDATE`
+	backorderGetMany          = backorderSelect + ` WHERE ($1 = '' OR b.status = $1) AND ($2 = '' OR b.kind = $2)
+AND ($3::uuid IS NULL OR b.book_id = $3) AND ($4::uuid IS NULL OR b.location_id = $4)
+ORDER BY b.created_at, b.backorder_id OFFSET $5 LIMIT $6`
+	backorderGetTotalCount = `SELECT COUNT(*) FROM backorders WHERE ($1 = '' OR status = $1) AND ($2 = '' OR kind = $2)
+AND ($3::uuid IS NULL OR book_id = $3) AND ($4::uuid IS NULL OR location_id = $4)`
+	// antrean sebuah buku di satu lokasi, dikunci agar dua penerimaan barang tidak mengalokasikan pesanan yang sama
+	backorderGetWaitingForUpdate = `SELECT * FROM backorders WHERE book_id = $1 AND location_id = $2 AND status = 'waiting'
+ORDER BY created_at, backorder_id FOR UPDATE`
+	backorderAllocate = `UPDATE backorders SET status = 'allocated', reservation_id = $2, allocated_at = NOW(), updated_at = NOW()
+WHERE backorder_id = $1 RETURNING *`
+	backorderCancel = `UPDATE backorders SET status = 'cancelled', updated_at = NOW() WHERE backorder_id = $1 RETURNING *`
+	// created_at tidak diubah sehingga backorder kembali ke posisi antreannya semula
+	backorderCancelByReservation = `UPDATE backorders SET status = 'cancelled', updated_at = NOW()
+WHERE reservation_id = $1 AND status = 'allocated'`
 )
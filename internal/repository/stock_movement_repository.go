@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+type StockMovementRepository struct {
+	db dbtx
+}
+
+func NewStockMovementRepository(db *sqlx.DB) *StockMovementRepository {
+	return &StockMovementRepository{db}
+}
+
+// WithTx mengembalikan salinan repository yang menjalankan kueri di dalam transaksi tx
+func (s StockMovementRepository) WithTx(tx *sqlx.Tx) *StockMovementRepository {
+	return &StockMovementRepository{tx}
+}
+
+func (s StockMovementRepository) Create(ctx context.Context, movement *entity.StockMovement) (*entity.StockMovement, error) {
+	err := s.db.QueryRowxContext(
+		ctx, stockMovementCreate,
+		movement.MovementId,
+		movement.BookId,
+		movement.LocationId,
+		movement.MovementType,
+		movement.Quantity,
+		movement.StockAfter,
+		movement.Reason,
+		movement.Reference,
+		movement.CreatedBy,
+	).StructScan(movement)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return movement, nil
+}
+
+func (s StockMovementRepository) GetManyByBookId(ctx context.Context, bookId uuid.UUID, offset int64, limit int64) ([]*entity.StockMovement, error) {
+	movements := make([]*entity.StockMovement, 0)
+	err := s.db.SelectContext(ctx, &movements, stockMovementGetManyByBookId, bookId, offset, limit)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return movements, nil
+}
+
+// GetTotalCountByBookId returns the total number of stock movements of a book
+func (s StockMovementRepository) GetTotalCountByBookId(ctx context.Context, bookId uuid.UUID) (int64, error) {
+	var total int64
+	err := s.db.GetContext(ctx, &total, stockMovementGetTotalCountByBookId, bookId)
+	if err != nil {
+		return 0, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return total, nil
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+type StockTakeRepository struct {
+	db dbtx
+}
+
+func NewStockTakeRepository(db *sqlx.DB) *StockTakeRepository {
+	return &StockTakeRepository{db}
+}
+
+// WithTx mengembalikan salinan repository yang menjalankan kueri di dalam transaksi tx
+func (s StockTakeRepository) WithTx(tx *sqlx.Tx) *StockTakeRepository {
+	return &StockTakeRepository{tx}
+}
+
+func (s StockTakeRepository) Create(ctx context.Context, stockTake *entity.StockTake) (*entity.StockTake, error) {
+	err := s.db.QueryRowxContext(
+		ctx, stockTakeCreate,
+		stockTake.StockTakeId,
+		stockTake.LocationId,
+		stockTake.Status,
+		stockTake.Notes,
+		stockTake.CreatedBy,
+	).StructScan(stockTake)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, eris.Wrap(types.ErrDuplicateKey, "location already has an open stock take")
+		}
+
+		if isForeignKeyViolation(err) {
+			return nil, eris.Wrap(types.ErrMissingReference, "location not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return stockTake, nil
+}
+
+// Snapshot mencatat stok setiap buku di lokasi stock take sebagai jumlah yang diharapkan
+func (s StockTakeRepository) Snapshot(ctx context.Context, stockTakeId uuid.UUID, locationId uuid.UUID) error {
+	_, err := s.db.ExecContext(ctx, stockTakeLineSnapshot, stockTakeId, locationId)
+	if err != nil {
+		return eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return nil
+}
+
+func (s StockTakeRepository) GetById(ctx context.Context, stockTakeId uuid.UUID) (*entity.StockTake, error) {
+	return s.get(ctx, stockTakeGetById, stockTakeId)
+}
+
+// GetByIdForShare mengambil stock take dan menahan approval atau pembatalan sampai transaksi selesai,
+// sementara penghitungan lain tetap bisa berjalan bersamaan
+func (s StockTakeRepository) GetByIdForShare(ctx context.Context, stockTakeId uuid.UUID) (*entity.StockTake, error) {
+	return s.get(ctx, stockTakeGetByIdForShare, stockTakeId)
+}
+
+// GetByIdForUpdate mengambil stock take sekaligus mengunci barisnya sampai transaksi selesai
+func (s StockTakeRepository) GetByIdForUpdate(ctx context.Context, stockTakeId uuid.UUID) (*entity.StockTake, error) {
+	return s.get(ctx, stockTakeGetByIdForUpdate, stockTakeId)
+}
+
+// Approve menandai stock take sudah disetujui oleh userId
+func (s StockTakeRepository) Approve(ctx context.Context, stockTakeId uuid.UUID, userId uuid.NullUUID) (*entity.StockTake, error) {
+	return s.get(ctx, stockTakeApprove, stockTakeId, userId)
+}
+
+func (s StockTakeRepository) Cancel(ctx context.Context, stockTakeId uuid.UUID) (*entity.StockTake, error) {
+	return s.get(ctx, stockTakeCancel, stockTakeId)
+}
+
+func (s StockTakeRepository) get(ctx context.Context, query string, args ...any) (*entity.StockTake, error) {
+	stockTake := new(entity.StockTake)
+	err := s.db.GetContext(ctx, stockTake, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "stock take not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return stockTake, nil
+}
+
+// GetMany mengambil daftar stock take terbaru. Filter status dan locationId yang kosong diabaikan
+func (s StockTakeRepository) GetMany(ctx context.Context, status string, locationId uuid.NullUUID, offset int64, limit int64) ([]*entity.StockTake, error) {
+	stockTakes := make([]*entity.StockTake, 0)
+	err := s.db.SelectContext(ctx, &stockTakes, stockTakeGetMany, status, locationId, offset, limit)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return stockTakes, nil
+}
+
+// GetTotalCount returns the total number of stock takes matching the filter
+func (s StockTakeRepository) GetTotalCount(ctx context.Context, status string, locationId uuid.NullUUID) (int64, error) {
+	var total int64
+	err := s.db.GetContext(ctx, &total, stockTakeGetTotalCount, status, locationId)
+	if err != nil {
+		return 0, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return total, nil
+}
+
+// Count mencatat hasil hitung buku. Jika add bernilai true, counted ditambahkan ke hasil hitung
+// sebelumnya, jika tidak hasil hitung sebelumnya diganti
+func (s StockTakeRepository) Count(ctx context.Context, line *entity.StockTakeLine, locationId uuid.UUID, add bool) (*entity.StockTakeLine, error) {
+	err := s.db.QueryRowxContext(
+		ctx, stockTakeLineCount,
+		line.StockTakeId,
+		line.BookId,
+		locationId,
+		line.Counted,
+		line.CountedBy,
+		add,
+	).StructScan(line)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, eris.Wrap(types.ErrMissingReference, "book not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return line, nil
+}
+
+// GetLines mengambil baris stock take beserta judul dan ISBN buku. status bernilai counted,
+// uncounted, discrepancy atau kosong untuk semua baris
+func (s StockTakeRepository) GetLines(ctx context.Context, stockTakeId uuid.UUID, status string, offset int64, limit int64) ([]*entity.StockTakeLine, error) {
+	lines := make([]*entity.StockTakeLine, 0)
+	err := s.db.SelectContext(ctx, &lines, stockTakeLineGetMany, stockTakeId, status, offset, limit)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return lines, nil
+}
+
+// GetLinesTotalCount returns the total number of stock take lines matching the status filter
+func (s StockTakeRepository) GetLinesTotalCount(ctx context.Context, stockTakeId uuid.UUID, status string) (int64, error) {
+	var total int64
+	err := s.db.GetContext(ctx, &total, stockTakeLineGetTotalCount, stockTakeId, status)
+	if err != nil {
+		return 0, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return total, nil
+}
+
+// GetVariances mengambil baris yang hasil hitungnya berbeda dari jumlah yang diharapkan, urut
+// berdasarkan book_id. Jika zeroUncounted bernilai true, buku yang belum dihitung dianggap nol
+func (s StockTakeRepository) GetVariances(ctx context.Context, stockTakeId uuid.UUID, zeroUncounted bool) ([]*entity.StockTakeLine, error) {
+	lines := make([]*entity.StockTakeLine, 0)
+	err := s.db.SelectContext(ctx, &lines, stockTakeLineGetVariances, stockTakeId, zeroUncounted)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return lines, nil
+}
+
+func (s StockTakeRepository) GetSummary(ctx context.Context, stockTakeId uuid.UUID) (*entity.StockTakeSummary, error) {
+	summary := new(entity.StockTakeSummary)
+	err := s.db.GetContext(ctx, summary, stockTakeLineGetSummary, stockTakeId)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+type StockTransferRepository struct {
+	db dbtx
+}
+
+func NewStockTransferRepository(db *sqlx.DB) *StockTransferRepository {
+	return &StockTransferRepository{db}
+}
+
+// WithTx mengembalikan salinan repository yang menjalankan kueri di dalam transaksi tx
+func (s StockTransferRepository) WithTx(tx *sqlx.Tx) *StockTransferRepository {
+	return &StockTransferRepository{tx}
+}
+
+func (s StockTransferRepository) Create(ctx context.Context, transfer *entity.StockTransfer) (*entity.StockTransfer, error) {
+	err := s.db.QueryRowxContext(
+		ctx, stockTransferCreate,
+		transfer.TransferId,
+		transfer.FromLocationId,
+		transfer.ToLocationId,
+		transfer.Status,
+		transfer.Notes,
+		transfer.RequestedBy,
+	).StructScan(transfer)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, eris.Wrap(types.ErrMissingReference, "location not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return transfer, nil
+}
+
+func (s StockTransferRepository) CreateItem(ctx context.Context, item *entity.StockTransferItem) (*entity.StockTransferItem, error) {
+	err := s.db.QueryRowxContext(
+		ctx, stockTransferItemCreate,
+		item.TransferItemId,
+		item.TransferId,
+		item.BookId,
+		item.Quantity,
+	).StructScan(item)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, eris.Wrap(types.ErrMissingReference, "book not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return item, nil
+}
+
+func (s StockTransferRepository) GetById(ctx context.Context, transferId uuid.UUID) (*entity.StockTransfer, error) {
+	return s.get(ctx, stockTransferGetById, transferId)
+}
+
+// GetByIdForUpdate mengambil transfer sekaligus mengunci barisnya sampai transaksi selesai
+func (s StockTransferRepository) GetByIdForUpdate(ctx context.Context, transferId uuid.UUID) (*entity.StockTransfer, error) {
+	return s.get(ctx, stockTransferGetByIdForUpdate, transferId)
+}
+
+// Dispatch menandai transfer sedang dikirim oleh userId
+func (s StockTransferRepository) Dispatch(ctx context.Context, transferId uuid.UUID, userId uuid.NullUUID) (*entity.StockTransfer, error) {
+	return s.get(ctx, stockTransferDispatch, transferId, userId)
+}
+
+// Receive menandai transfer sudah diterima di lokasi tujuan oleh userId
+func (s StockTransferRepository) Receive(ctx context.Context, transferId uuid.UUID, userId uuid.NullUUID) (*entity.StockTransfer, error) {
+	return s.get(ctx, stockTransferReceive, transferId, userId)
+}
+
+func (s StockTransferRepository) Cancel(ctx context.Context, transferId uuid.UUID) (*entity.StockTransfer, error) {
+	return s.get(ctx, stockTransferCancel, transferId)
+}
+
+func (s StockTransferRepository) get(ctx context.Context, query string, args ...any) (*entity.StockTransfer, error) {
+	transfer := new(entity.StockTransfer)
+	err := s.db.GetContext(ctx, transfer, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "stock transfer not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return transfer, nil
+}
+
+func (s StockTransferRepository) GetItemsByTransferId(ctx context.Context, transferId uuid.UUID) ([]*entity.StockTransferItem, error) {
+	items := make([]*entity.StockTransferItem, 0)
+	err := s.db.SelectContext(ctx, &items, stockTransferItemGetByTransferId, transferId)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return items, nil
+}
+
+// GetMany mengambil daftar transfer terbaru. locationId mencocokkan lokasi asal maupun tujuan.
+// Filter status dan locationId yang kosong diabaikan
+func (s StockTransferRepository) GetMany(ctx context.Context, status string, locationId uuid.NullUUID, offset int64, limit int64) ([]*entity.StockTransfer, error) {
+	transfers := make([]*entity.StockTransfer, 0)
+	err := s.db.SelectContext(ctx, &transfers, stockTransferGetMany, status, locationId, offset, limit)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return transfers, nil
+}
+
+// GetTotalCount returns the total number of stock transfers matching the filter
+func (s StockTransferRepository) GetTotalCount(ctx context.Context, status string, locationId uuid.NullUUID) (int64, error) {
+	var total int64
+	err := s.db.GetContext(ctx, &total, stockTransferGetTotalCount, status, locationId)
+	if err != nil {
+		return 0, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return total, nil
+}
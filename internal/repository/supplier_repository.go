@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+type SupplierRepository struct {
+	db dbtx
+}
+
+func NewSupplierRepository(db *sqlx.DB) *SupplierRepository {
+	return &SupplierRepository{db}
+}
+
+// WithTx mengembalikan salinan repository yang menjalankan kueri di dalam transaksi tx
+func (s SupplierRepository) WithTx(tx *sqlx.Tx) *SupplierRepository {
+	return &SupplierRepository{tx}
+}
+
+func (s SupplierRepository) Create(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error) {
+	err := s.db.QueryRowxContext(
+		ctx, supplierCreate,
+		supplier.SupplierId,
+		supplier.Name,
+		supplier.ContactName,
+		supplier.Email,
+		supplier.Phone,
+		supplier.Address,
+		supplier.LeadTimeDays,
+	).StructScan(supplier)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, eris.Wrap(types.ErrDuplicateKey, "supplier name already exists")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return supplier, nil
+}
+
+func (s SupplierRepository) GetById(ctx context.Context, supplierId uuid.UUID) (*entity.Supplier, error) {
+	supplier := new(entity.Supplier)
+	err := s.db.GetContext(ctx, supplier, supplierGetById, supplierId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "supplier not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return supplier, nil
+}
+
+func (s SupplierRepository) GetMany(ctx context.Context, offset int64, limit int64) ([]*entity.Supplier, error) {
+	suppliers := make([]*entity.Supplier, 0)
+	err := s.db.SelectContext(ctx, &suppliers, supplierGetMany, offset, limit)
+	if err != nil {
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return suppliers, nil
+}
+
+// GetTotalCount returns the total number of suppliers in the database
+func (s SupplierRepository) GetTotalCount(ctx context.Context) (int64, error) {
+	var total int64
+	err := s.db.GetContext(ctx, &total, supplierGetTotalCount)
+	if err != nil {
+		return 0, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return total, nil
+}
+
+// SupplierPatch berisi perubahan data supplier. Field bernilai nil tidak diubah
+type SupplierPatch struct {
+	Name         *string
+	ContactName  *string
+	Email        *string
+	Phone        *string
+	Address      *string
+	LeadTimeDays *int32
+}
+
+func (s SupplierRepository) Update(ctx context.Context, supplierId uuid.UUID, patch SupplierPatch) (*entity.Supplier, error) {
+	supplier := new(entity.Supplier)
+	err := s.db.QueryRowxContext(
+		ctx, supplierUpdate,
+		supplierId,
+		patch.Name,
+		patch.ContactName,
+		patch.Email,
+		patch.Phone,
+		patch.Address,
+		patch.LeadTimeDays,
+	).StructScan(supplier)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "supplier not found")
+		}
+
+		if isUniqueViolation(err) {
+			return nil, eris.Wrap(types.ErrDuplicateKey, "supplier name already exists")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return supplier, nil
+}
+
+func (s SupplierRepository) Delete(ctx context.Context, supplierId uuid.UUID) error {
+	result, err := s.db.ExecContext(ctx, supplierDelete, supplierId)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return eris.Wrap(types.ErrReferenced, "supplier is referenced by purchase orders")
+		}
+
+		return eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	if i, _ := result.RowsAffected(); i <= 0 {
+		return eris.Wrap(types.ErrNoRows, "supplier not found")
+	}
+
+	return nil
+}
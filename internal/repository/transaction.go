@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+// dbtx adalah kumpulan method yang dimiliki oleh *sqlx.DB dan *sqlx.Tx
+// sehingga repository dapat dipakai di dalam maupun di luar transaksi
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+	QueryRowxContext(ctx context.Context, query string, args ...any) *sqlx.Row
+	QueryxContext(ctx context.Context, query string, args ...any) (*sqlx.Rows, error)
+}
+
+type Transactor struct {
+	db *sqlx.DB
+}
+
+func NewTransactor(db *sqlx.DB) *Transactor {
+	return &Transactor{db}
+}
+
+// WithinTx menjalankan fn di dalam satu transaksi database.
+// Transaksi di-commit jika fn tidak mengembalikan error, selain itu di-rollback
+func (t Transactor) WithinTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+	defer tx.Rollback()
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+type UserRepository struct {
+	db dbtx
+}
+
+func NewUserRepository(db *sqlx.DB) *UserRepository {
+	return &UserRepository{db}
+}
+
+func (u UserRepository) Create(ctx context.Context, user *entity.User) (*entity.User, error) {
+	err := u.db.QueryRowxContext(ctx, userCreate, user.UserId, user.Username, user.FullName, user.PasswordHash, user.RoleId).StructScan(user)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, eris.Wrap(types.ErrDuplicateKey, "username already exists")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return user, nil
+}
+
+func (u UserRepository) GetById(ctx context.Context, userId uuid.UUID) (*entity.User, error) {
+	user := new(entity.User)
+	err := u.db.GetContext(ctx, user, userGetById, userId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "user not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return user, nil
+}
+
+func (u UserRepository) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
+	user := new(entity.User)
+	err := u.db.GetContext(ctx, user, userGetByUsername, username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, eris.Wrap(types.ErrNoRows, "user not found")
+		}
+
+		return nil, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return user, nil
+}
+
+// GetTotalCount returns the total number of users in the database
+func (u UserRepository) GetTotalCount(ctx context.Context) (int64, error) {
+	var total int64
+	err := u.db.GetContext(ctx, &total, userGetTotalCount)
+	if err != nil {
+		return 0, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return total, nil
+}
+
+// HasPermission mengecek apakah role milik user aktif memiliki permission tertentu
+func (u UserRepository) HasPermission(ctx context.Context, userId uuid.UUID, permission string) (bool, error) {
+	var allowed bool
+	err := u.db.GetContext(ctx, &allowed, userHasPermission, userId, permission)
+	if err != nil {
+		return false, eris.Wrap(types.ErrDatabaseQuery, err.Error())
+	}
+
+	return allowed, nil
+}
@@ -3,8 +3,13 @@ package types
 import "github.com/rotisserie/eris"
 
 var (
-	ErrDatabaseQuery = eris.New("database query error")
-	ErrNoRows        = eris.New("no rows found")
+	ErrDatabaseQuery    = eris.New("database query error")
+	ErrNoRows           = eris.New("no rows found")
+	ErrDuplicateKey     = eris.New("duplicate key")
+	ErrReferenced       = eris.New("row is still referenced")
+	ErrMissingReference = eris.New("referenced row does not exist")
+
+	ErrInsufficientStock = eris.New("insufficient stock")
 )
 
 type HTTPError struct {
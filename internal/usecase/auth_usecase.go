@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/config"
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/repository"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dummyPasswordHash dipakai saat username tidak ditemukan agar waktu respons login
+// tidak membocorkan username mana yang terdaftar
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
+type AuthUsecase struct {
+	accessSecret  []byte
+	refreshSecret []byte
+	transactor    *repository.Transactor
+	userRepo      *repository.UserRepository
+	roleRepo      *repository.RoleRepository
+	tokenRepo     *repository.RefreshTokenRepository
+	validator     *validator.Validate
+}
+
+func NewAuthUsecase(
+	cfg *config.Config,
+	transactor *repository.Transactor,
+	userRepo *repository.UserRepository,
+	roleRepo *repository.RoleRepository,
+	tokenRepo *repository.RefreshTokenRepository,
+	validator *validator.Validate,
+) (*AuthUsecase, error) {
+	if cfg.JWT_ACCESS_TOKEN_SECRET == "" || cfg.JWT_REFRESH_TOKEN_SECRET == "" {
+		return nil, eris.New("JWT_ACCESS_TOKEN_SECRET and JWT_REFRESH_TOKEN_SECRET must be set")
+	}
+
+	return &AuthUsecase{
+		accessSecret:  []byte(cfg.JWT_ACCESS_TOKEN_SECRET),
+		refreshSecret: []byte(cfg.JWT_REFRESH_TOKEN_SECRET),
+		transactor:    transactor,
+		userRepo:      userRepo,
+		roleRepo:      roleRepo,
+		tokenRepo:     tokenRepo,
+		validator:     validator,
+	}, nil
+}
+
+func (a AuthUsecase) Login(ctx context.Context, request *model.LoginRequest) (model.TokenResponse, error) {
+	err := a.validator.Struct(request)
+	if err != nil {
+		return model.TokenResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	user, err := a.userRepo.GetByUsername(ctx, request.Username)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(request.Password))
+			return model.TokenResponse{}, fiber.NewError(fiber.StatusUnauthorized, "Invalid username or password")
+		}
+
+		return model.TokenResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to login"), eris.ToString(err, true))
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(request.Password))
+	if err != nil {
+		return model.TokenResponse{}, fiber.NewError(fiber.StatusUnauthorized, "Invalid username or password")
+	}
+
+	if !user.IsActive {
+		return model.TokenResponse{}, fiber.NewError(fiber.StatusForbidden, "User account is disabled")
+	}
+
+	tokens, _, err := a.issueTokens(ctx, a.tokenRepo, user)
+	if err != nil {
+		return model.TokenResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to login"), eris.ToString(err, true))
+	}
+
+	return tokens, nil
+}
+
+// Refresh menukar refresh token dengan pasangan token baru (rotasi).
+// Refresh token yang sudah pernah dipakai akan mencabut seluruh sesi milik user tersebut
+func (a AuthUsecase) Refresh(ctx context.Context, refreshToken string) (model.TokenResponse, error) {
+	claims, err := a.parseRefreshToken(refreshToken)
+	if err != nil {
+		return model.TokenResponse{}, err
+	}
+
+	var (
+		tokens  model.TokenResponse
+		revoked bool
+	)
+	err = a.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		tokenRepo := a.tokenRepo.WithTx(tx)
+
+		token, err := tokenRepo.GetByIdForUpdate(ctx, claims.tokenId)
+		if err != nil {
+			return err
+		}
+
+		if token.UserId != claims.userId {
+			return eris.Wrap(types.ErrNoRows, "refresh token does not belong to user")
+		}
+
+		user, err := a.userRepo.GetById(ctx, token.UserId)
+		if err != nil {
+			return err
+		}
+
+		// token yang sudah dicabut dipakai lagi, kemungkinan token telah dicuri
+		if token.RevokedAt.Valid || !user.IsActive {
+			revoked = true
+			return tokenRepo.RevokeAllByUserId(ctx, token.UserId)
+		}
+
+		var newTokenId uuid.UUID
+		tokens, newTokenId, err = a.issueTokens(ctx, tokenRepo, user)
+		if err != nil {
+			return err
+		}
+
+		return tokenRepo.Revoke(ctx, token.TokenId, uuid.NullUUID{UUID: newTokenId, Valid: true})
+	})
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return model.TokenResponse{}, fiber.NewError(fiber.StatusUnauthorized, "Invalid refresh token")
+		}
+
+		return model.TokenResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to refresh token"), eris.ToString(err, true))
+	}
+
+	if revoked {
+		return model.TokenResponse{}, fiber.NewError(fiber.StatusUnauthorized, "Refresh token has been revoked")
+	}
+
+	return tokens, nil
+}
+
+// Logout mencabut refresh token. Token yang tidak valid diabaikan karena sesi sudah tidak bisa dipakai
+func (a AuthUsecase) Logout(ctx context.Context, refreshToken string) error {
+	claims, err := a.parseRefreshToken(refreshToken)
+	if err != nil {
+		return nil
+	}
+
+	err = a.tokenRepo.Revoke(ctx, claims.tokenId, uuid.NullUUID{})
+	if err != nil {
+		return eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to logout"), eris.ToString(err, true))
+	}
+
+	return nil
+}
+
+// Authorize mengecek apakah user memiliki permission yang dibutuhkan berdasarkan role-nya
+func (a AuthUsecase) Authorize(ctx context.Context, userId uuid.UUID, permission string) error {
+	allowed, err := a.userRepo.HasPermission(ctx, userId, permission)
+	if err != nil {
+		return eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to check permission"), eris.ToString(err, true))
+	}
+
+	if !allowed {
+		return fiber.NewError(fiber.StatusForbidden, "You do not have permission to perform this action")
+	}
+
+	return nil
+}
+
+// VerifyAccessToken memvalidasi access token dan mengembalikan claims di dalamnya
+func (a AuthUsecase) VerifyAccessToken(accessToken string) (*model.AccessTokenClaims, error) {
+	claims := new(model.AccessTokenClaims)
+	_, err := jwt.ParseWithClaims(accessToken, claims, func(t *jwt.Token) (any, error) {
+		return a.accessSecret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, eris.Wrap(fiber.NewError(fiber.StatusUnauthorized, "Invalid or expired access token"), err.Error())
+	}
+
+	if _, err = uuid.Parse(claims.Subject); err != nil {
+		return nil, eris.Wrap(fiber.NewError(fiber.StatusUnauthorized, "Invalid or expired access token"), err.Error())
+	}
+
+	return claims, nil
+}
+
+type refreshTokenClaims struct {
+	tokenId uuid.UUID
+	userId  uuid.UUID
+}
+
+func (a AuthUsecase) parseRefreshToken(refreshToken string) (refreshTokenClaims, error) {
+	if refreshToken == "" {
+		return refreshTokenClaims{}, fiber.NewError(fiber.StatusUnauthorized, "Refresh token is required")
+	}
+
+	claims := new(jwt.RegisteredClaims)
+	_, err := jwt.ParseWithClaims(refreshToken, claims, func(t *jwt.Token) (any, error) {
+		return a.refreshSecret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return refreshTokenClaims{}, eris.Wrap(fiber.NewError(fiber.StatusUnauthorized, "Invalid refresh token"), err.Error())
+	}
+
+	tokenId, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return refreshTokenClaims{}, eris.Wrap(fiber.NewError(fiber.StatusUnauthorized, "Invalid refresh token"), err.Error())
+	}
+
+	userId, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return refreshTokenClaims{}, eris.Wrap(fiber.NewError(fiber.StatusUnauthorized, "Invalid refresh token"), err.Error())
+	}
+
+	return refreshTokenClaims{tokenId, userId}, nil
+}
+
+// issueTokens membuat access token dan refresh token baru lalu menyimpan refresh token ke database
+func (a AuthUsecase) issueTokens(ctx context.Context, tokenRepo *repository.RefreshTokenRepository, user *entity.User) (model.TokenResponse, uuid.UUID, error) {
+	now := time.Now()
+
+	role, err := a.roleRepo.GetById(ctx, user.RoleId)
+	if err != nil {
+		return model.TokenResponse{}, uuid.Nil, err
+	}
+
+	accessTokenId, err := uuid.NewV7()
+	if err != nil {
+		return model.TokenResponse{}, uuid.Nil, eris.Errorf("Failed to generate token ID: %v", err)
+	}
+
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, model.AccessTokenClaims{
+		Username: user.Username,
+		Role:     role.Name,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        accessTokenId.String(),
+			Subject:   user.UserId.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(config.ACCESS_TOKEN_EXPIRATION_TIME)),
+		},
+	}).SignedString(a.accessSecret)
+	if err != nil {
+		return model.TokenResponse{}, uuid.Nil, eris.Wrap(err, "failed to sign access token")
+	}
+
+	refreshTokenId, err := uuid.NewV7()
+	if err != nil {
+		return model.TokenResponse{}, uuid.Nil, eris.Errorf("Failed to generate token ID: %v", err)
+	}
+
+	expiresAt := now.Add(config.REFRESH_TOKEN_EXPIRATION_TIME)
+	refreshToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ID:        refreshTokenId.String(),
+		Subject:   user.UserId.String(),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}).SignedString(a.refreshSecret)
+	if err != nil {
+		return model.TokenResponse{}, uuid.Nil, eris.Wrap(err, "failed to sign refresh token")
+	}
+
+	_, err = tokenRepo.Create(ctx, &entity.RefreshToken{
+		TokenId:   refreshTokenId,
+		UserId:    user.UserId,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return model.TokenResponse{}, uuid.Nil, err
+	}
+
+	return model.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(config.ACCESS_TOKEN_EXPIRATION_TIME.Seconds()),
+	}, refreshTokenId, nil
+}
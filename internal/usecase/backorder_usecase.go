@@ -0,0 +1,378 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/config"
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/repository"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+// BackorderUsecase mengelola antrean pesanan untuk buku yang stoknya habis (backorder) atau belum
+// terbit (preorder). Saat barang diterima di sebuah lokasi, stok yang masuk dialokasikan ke antrean
+// buku di lokasi tersebut sebagai reservasi, dari pesanan paling lama, sebelum bisa dijual
+type BackorderUsecase struct {
+	transactor      *repository.Transactor
+	backorderRepo   *repository.BackorderRepository
+	reservationRepo *repository.ReservationRepository
+	bookRepo        *repository.BookRepository
+	locationRepo    *repository.LocationRepository
+	cfg             *config.Config
+	validator       *validator.Validate
+}
+
+func NewBackorderUsecase(
+	transactor *repository.Transactor,
+	backorderRepo *repository.BackorderRepository,
+	reservationRepo *repository.ReservationRepository,
+	bookRepo *repository.BookRepository,
+	locationRepo *repository.LocationRepository,
+	cfg *config.Config,
+	validator *validator.Validate,
+) *BackorderUsecase {
+	return &BackorderUsecase{transactor, backorderRepo, reservationRepo, bookRepo, locationRepo, cfg, validator}
+}
+
+// Create memasukkan pesanan ke akhir antrean buku di lokasi pengambilan. Pesanan untuk buku yang
+// belum terbit menjadi preorder, selain itu backorder. Backorder ditolak jika stok lokasi yang
+// tersedia masih cukup dan tidak ada antrean, karena pelanggan bisa langsung membuat reservasi
+func (b BackorderUsecase) Create(ctx context.Context, userId uuid.UUID, request *model.CreateBackorderRequest) (model.BackorderResponse, error) {
+	err := b.validator.Struct(request)
+	if err != nil {
+		return model.BackorderResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	location, err := resolveLocation(ctx, b.locationRepo, request.LocationID)
+	if err != nil {
+		return model.BackorderResponse{}, err
+	}
+
+	book, err := b.bookRepo.GetByIdAtLocation(ctx, request.BookID, location.LocationId)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return model.BackorderResponse{}, fiber.NewError(fiber.StatusNotFound, "Book not found")
+		}
+
+		return model.BackorderResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get book"), eris.ToString(err, true))
+	}
+
+	kind := entity.BackorderKindBackorder
+	if book.PublishedAt.After(time.Now()) {
+		kind = entity.BackorderKindPreorder
+	}
+
+	if kind == entity.BackorderKindBackorder && book.Stock-book.Reserved >= request.Quantity {
+		waiting, err := b.backorderRepo.GetTotalCount(ctx, repository.BackorderFilter{
+			Status:     string(entity.BackorderWaiting),
+			BookId:     uuid.NullUUID{UUID: book.BookId, Valid: true},
+			LocationId: uuid.NullUUID{UUID: location.LocationId, Valid: true},
+		})
+		if err != nil {
+			return model.BackorderResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get backorder queue"), eris.ToString(err, true))
+		}
+
+		if waiting == 0 {
+			return model.BackorderResponse{}, fiber.NewError(fiber.StatusConflict, "Book is in stock at this location, create a reservation instead")
+		}
+	}
+
+	backorderId, err := uuid.NewV7()
+	if err != nil {
+		return model.BackorderResponse{}, eris.Errorf("Failed to generate backorder ID: %v", err)
+	}
+
+	backorder := &entity.Backorder{
+		BackorderId:     backorderId,
+		BookId:          book.BookId,
+		LocationId:      location.LocationId,
+		Kind:            kind,
+		Status:          entity.BackorderWaiting,
+		Quantity:        request.Quantity,
+		CustomerName:    request.CustomerName,
+		CustomerContact: request.CustomerContact,
+		Notes:           request.Notes,
+		CreatedBy:       uuid.NullUUID{UUID: userId, Valid: userId != uuid.Nil},
+	}
+
+	_, err = b.backorderRepo.Create(ctx, backorder)
+	if err != nil {
+		return model.BackorderResponse{}, backorderWriteError(err, "Failed to create backorder")
+	}
+
+	// dibaca ulang untuk mendapatkan posisi antrean
+	return b.GetById(ctx, backorderId.String())
+}
+
+// GetById mengambil backorder beserta posisinya di antrean
+func (b BackorderUsecase) GetById(ctx context.Context, backorderId string) (model.BackorderResponse, error) {
+	id, err := uuid.Parse(backorderId)
+	if err != nil {
+		return model.BackorderResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid backorder ID"), err.Error())
+	}
+
+	backorder, err := b.backorderRepo.GetById(ctx, id)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return model.BackorderResponse{}, fiber.NewError(fiber.StatusNotFound, "Backorder not found")
+		}
+
+		return model.BackorderResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get backorder"), eris.ToString(err, true))
+	}
+
+	return model.BackorderToResponse(backorder), nil
+}
+
+func (b BackorderUsecase) GetMany(ctx context.Context, request *model.BackorderSearchRequest, offset int64, limit int64) ([]model.BackorderResponse, int64, error) {
+	if limit <= 0 {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Limit must be greater than 0"), "Invalid limit")
+	}
+
+	err := b.validator.Struct(request)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters"), err.Error())
+	}
+
+	filter := repository.BackorderFilter{Status: request.Status, Kind: request.Kind}
+	if request.BookID != "" {
+		filter.BookId = uuid.NullUUID{UUID: uuid.MustParse(request.BookID), Valid: true}
+	}
+	if request.LocationID != "" {
+		filter.LocationId = uuid.NullUUID{UUID: uuid.MustParse(request.LocationID), Valid: true}
+	}
+
+	backorders, err := b.backorderRepo.GetMany(ctx, filter, offset, limit)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get backorders"), eris.ToString(err, true))
+	}
+
+	total, err := b.backorderRepo.GetTotalCount(ctx, filter)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get total count"), eris.ToString(err, true))
+	}
+
+	return model.BackordersToResponse(backorders), total, nil
+}
+
+// Cancel mengeluarkan pesanan yang masih menunggu dari antrean. Pesanan yang sudah dialokasikan
+// dibatalkan lewat reservasinya
+func (b BackorderUsecase) Cancel(ctx context.Context, backorderId string) (model.BackorderResponse, error) {
+	id, err := uuid.Parse(backorderId)
+	if err != nil {
+		return model.BackorderResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid backorder ID"), err.Error())
+	}
+
+	var backorder *entity.Backorder
+	err = b.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		backorderRepo := b.backorderRepo.WithTx(tx)
+
+		backorder, err = backorderRepo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if backorder.Status != entity.BackorderWaiting {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Backorder is %s, only waiting backorders can be cancelled", backorder.Status))
+		}
+
+		backorder, err = backorderRepo.Cancel(ctx, id)
+		return err
+	})
+	if err != nil {
+		return model.BackorderResponse{}, backorderWriteError(err, "Failed to cancel backorder")
+	}
+
+	return model.BackorderToResponse(backorder), nil
+}
+
+// Allocate mengalokasikan stok yang tersedia di sebuah lokasi ke antrean satu buku, misalnya
+// setelah stok bertambah lewat penyesuaian stok atau reservasi hasil alokasi sebelumnya kedaluwarsa
+func (b BackorderUsecase) Allocate(ctx context.Context, userId uuid.UUID, request *model.AllocateBackordersRequest) ([]model.BackorderResponse, error) {
+	err := b.validator.Struct(request)
+	if err != nil {
+		return nil, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	location, err := resolveLocation(ctx, b.locationRepo, request.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var allocated []*entity.Backorder
+	err = b.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		allocated, err = b.allocate(ctx, tx, location.LocationId, []uuid.UUID{request.BookID}, userId)
+		return err
+	})
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Book not found")
+		}
+
+		return nil, backorderWriteError(err, "Failed to allocate backorders")
+	}
+
+	return model.BackordersToResponse(allocated), nil
+}
+
+// allocate mengalokasikan stok lokasi yang belum ditahan ke antrean setiap buku di bookIds, dari
+// pesanan paling lama. Setiap pesanan yang mendapat stok dibuatkan reservasi berchannel backorder
+// dengan masa tahan RESERVATION_TTL. Pesanan hanya dialokasikan utuh, dan alokasi berhenti pada
+// pesanan pertama yang tidak tercukupi agar urutan antrean tetap adil. Fungsi ini dipanggil di dalam
+// transaksi yang menambah stok sehingga barang yang diterima tidak sempat terjual ke pelanggan lain
+func (b BackorderUsecase) allocate(ctx context.Context, tx *sqlx.Tx, locationId uuid.UUID, bookIds []uuid.UUID, userId uuid.UUID) ([]*entity.Backorder, error) {
+	backorderRepo := b.backorderRepo.WithTx(tx)
+	reservationRepo := b.reservationRepo.WithTx(tx)
+	bookRepo := b.bookRepo.WithTx(tx)
+
+	// buku dikunci berurutan berdasarkan book_id agar tidak deadlock dengan penjualan
+	ordered := slices.Clone(bookIds)
+	slices.SortFunc(ordered, func(a, b uuid.UUID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	ordered = slices.Compact(ordered)
+
+	var allocated []*entity.Backorder
+	for _, bookId := range ordered {
+		// buku dikunci sebelum antrean, sama seperti urutan penerimaan barang, agar stok yang
+		// tersedia tidak berubah sampai alokasi selesai
+		_, err := bookRepo.GetByIdForUpdate(ctx, bookId)
+		if err != nil {
+			return nil, err
+		}
+
+		waiting, err := backorderRepo.GetWaitingForUpdate(ctx, bookId, locationId)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(waiting) == 0 {
+			continue
+		}
+
+		book, err := bookRepo.GetByIdAtLocation(ctx, bookId, locationId)
+		if err != nil {
+			return nil, err
+		}
+
+		available := book.Stock - book.Reserved
+		for _, backorder := range waiting {
+			if backorder.Quantity > available {
+				break
+			}
+
+			reservation, err := b.holdBackorder(ctx, reservationRepo, bookRepo, backorder, userId)
+			if err != nil {
+				return nil, err
+			}
+
+			backorder, err = backorderRepo.Allocate(ctx, backorder.BackorderId, reservation.ReservationId)
+			if err != nil {
+				return nil, err
+			}
+
+			available -= backorder.Quantity
+			allocated = append(allocated, backorder)
+		}
+	}
+
+	return allocated, nil
+}
+
+// allocatesStock menentukan pergerakan stok yang barangnya dialokasikan lebih dulu ke antrean
+// backorder, yaitu barang masuk dari penerimaan dan retur
+func allocatesStock(movement *entity.StockMovement) bool {
+	if movement.Quantity <= 0 {
+		return false
+	}
+
+	return movement.MovementType == entity.StockMovementReceipt || movement.MovementType == entity.StockMovementReturn
+}
+
+// allocatedQuantity menjumlahkan stok yang ditahan untuk backorder yang baru dialokasikan
+func allocatedQuantity(backorders []*entity.Backorder) int64 {
+	var total int64
+	for _, backorder := range backorders {
+		total += backorder.Quantity
+	}
+
+	return total
+}
+
+// holdBackorder membuat reservasi yang menahan stok untuk satu backorder
+func (b BackorderUsecase) holdBackorder(
+	ctx context.Context,
+	reservationRepo *repository.ReservationRepository,
+	bookRepo *repository.BookRepository,
+	backorder *entity.Backorder,
+	userId uuid.UUID,
+) (*entity.Reservation, error) {
+	reservationId, err := uuid.NewV7()
+	if err != nil {
+		return nil, eris.Errorf("Failed to generate reservation ID: %v", err)
+	}
+
+	itemId, err := uuid.NewV7()
+	if err != nil {
+		return nil, eris.Errorf("Failed to generate reservation item ID: %v", err)
+	}
+
+	reservation, err := reservationRepo.Create(ctx, &entity.Reservation{
+		ReservationId:   reservationId,
+		LocationId:      backorder.LocationId,
+		Status:          entity.ReservationActive,
+		Channel:         entity.ReservationBackorder,
+		CustomerName:    backorder.CustomerName,
+		CustomerContact: backorder.CustomerContact,
+		Notes:           fmt.Sprintf("%s %s", backorder.Kind, backorder.BackorderId),
+		ExpiresAt:       time.Now().Add(reservationTTL(b.cfg)),
+		CreatedBy:       uuid.NullUUID{UUID: userId, Valid: userId != uuid.Nil},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = bookRepo.Reserve(ctx, backorder.BookId, backorder.LocationId, backorder.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = reservationRepo.CreateItem(ctx, &entity.ReservationItem{
+		ReservationItemId: itemId,
+		ReservationId:     reservationId,
+		BookId:            backorder.BookId,
+		Quantity:          backorder.Quantity,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// backorderWriteError memetakan error dari transaksi backorder menjadi error HTTP
+func backorderWriteError(err error, message string) error {
+	var fe *fiber.Error
+	if eris.As(err, &fe) {
+		return fe
+	}
+
+	if eris.Is(err, types.ErrMissingReference) {
+		return fiber.NewError(fiber.StatusNotFound, "Book or location not found")
+	}
+
+	if eris.Is(err, types.ErrNoRows) {
+		return fiber.NewError(fiber.StatusNotFound, "Backorder not found")
+	}
+
+	return eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, message), eris.ToString(err, true))
+}
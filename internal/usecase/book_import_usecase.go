@@ -0,0 +1,335 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/config"
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/isbn"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/repository"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+// maxImportRows membatasi jumlah baris data dalam satu file impor
+const maxImportRows = 5000
+
+// status hasil impor per baris
+const (
+	importStatusCreated = "created"
+	importStatusUpdated = "updated"
+	importStatusFailed  = "failed"
+)
+
+// bookImportFields memetakan nama field CSV ke nama field pada model.CreateBookRequest
+var bookImportFields = map[string]string{
+	"isbn":         "ISBN",
+	"title":        "Title",
+	"author":       "Author",
+	"publisher":    "Publisher",
+	"published_at": "PublishedAt",
+	"stock":        "Stock",
+}
+
+// errImportDryRun dipakai untuk membatalkan transaksi pada mode dry run
+var errImportDryRun = eris.New("dry run")
+
+type bookImportRow struct {
+	result *model.BookImportRowResult
+	book   *entity.Book
+	// stock bernilai -1 jika kolom stok tidak diisi
+	stock int64
+}
+
+// Import membaca file CSV berisi daftar buku lalu menyimpannya dalam satu transaksi.
+// Baris yang tidak valid dilaporkan sebagai failed tanpa membatalkan baris lainnya.
+// Pada mode dry run seluruh perubahan di-rollback sehingga hasilnya hanya berupa laporan
+func (b BookUsecase) Import(ctx context.Context, userId uuid.UUID, r io.Reader, request *model.BookImportRequest) (model.BookImportResponse, error) {
+	mapping, err := parseImportMapping(request.Mapping)
+	if err != nil {
+		return model.BookImportResponse{}, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return model.BookImportResponse{}, fiber.NewError(fiber.StatusBadRequest, "CSV file is empty")
+		}
+
+		return model.BookImportResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid CSV file"), err.Error())
+	}
+
+	columns, err := resolveImportColumns(header, mapping)
+	if err != nil {
+		return model.BookImportResponse{}, err
+	}
+
+	response := model.BookImportResponse{DryRun: request.DryRun, Rows: make([]model.BookImportRowResult, 0)}
+	var rows []bookImportRow
+	seen := make(map[string]int)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return model.BookImportResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid CSV file: "+err.Error()), err.Error())
+		}
+
+		if len(response.Rows) == maxImportRows {
+			return model.BookImportResponse{}, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("CSV file must not contain more than %d rows", maxImportRows))
+		}
+
+		line, _ := reader.FieldPos(0)
+		response.Rows = append(response.Rows, model.BookImportRowResult{Row: line})
+		result := &response.Rows[len(response.Rows)-1]
+
+		book, stock, err := b.parseImportRecord(record, columns)
+		if err != nil {
+			result.Status, result.Error = importStatusFailed, err.Error()
+			continue
+		}
+		result.ISBN = book.ISBN
+
+		if row, ok := seen[book.ISBN]; ok {
+			result.Status, result.Error = importStatusFailed, fmt.Sprintf("duplicate ISBN, already used in row %d", row)
+			continue
+		}
+		seen[book.ISBN] = line
+
+		rows = append(rows, bookImportRow{book: book, stock: stock})
+	}
+
+	// pointer result diisi setelah semua baris dibaca karena append dapat memindahkan isi slice
+	for i, j := 0, 0; i < len(response.Rows) && j < len(rows); i++ {
+		if response.Rows[i].Status == "" {
+			rows[j].result = &response.Rows[i]
+			j++
+		}
+	}
+
+	// stok dari file impor selalu dicatat di lokasi default
+	location, err := resolveLocation(ctx, b.locationRepo, nil)
+	if err != nil {
+		return model.BookImportResponse{}, err
+	}
+
+	err = b.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		bookRepo := b.bookRepo.WithTx(tx)
+		movementRepo := b.movementRepo.WithTx(tx)
+		priceRepo := b.priceRepo.WithTx(tx)
+
+		for _, row := range rows {
+			existing, err := bookRepo.GetByISBN(ctx, row.book.ISBN)
+			if err == nil {
+				if !request.Upsert {
+					row.result.Status, row.result.Error = importStatusFailed, "book with the same ISBN already exists"
+					continue
+				}
+
+				// file impor tidak memuat pengaturan pemesanan ulang dan harga sehingga nilainya dipertahankan
+				row.book.BookId = existing.BookId
+				_, err = updateBook(ctx, bookRepo, movementRepo, priceRepo, userId, location.LocationId, row.book, repository.BookUpdate{}, row.stock)
+				if err != nil {
+					var fe *fiber.Error
+					if eris.As(err, &fe) {
+						row.result.Status, row.result.Error = importStatusFailed, fe.Message
+						continue
+					}
+
+					return err
+				}
+
+				row.result.Status, row.result.BookID = importStatusUpdated, &existing.BookId
+				continue
+			}
+
+			if !eris.Is(err, types.ErrNoRows) {
+				return err
+			}
+
+			row.book.BookId, err = uuid.NewV7()
+			if err != nil {
+				return eris.Errorf("Failed to generate book ID: %v", err)
+			}
+
+			row.book.Currency = config.DEFAULT_CURRENCY
+			_, err = createBook(ctx, bookRepo, movementRepo, priceRepo, userId, location.LocationId, row.book, max(row.stock, 0))
+			if err != nil {
+				return err
+			}
+
+			row.result.Status, row.result.BookID = importStatusCreated, &row.book.BookId
+		}
+
+		if request.DryRun {
+			return errImportDryRun
+		}
+
+		return nil
+	})
+	if err != nil && !eris.Is(err, errImportDryRun) {
+		return model.BookImportResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to import books, no rows were saved"), eris.ToString(err, true))
+	}
+
+	response.Total = len(response.Rows)
+	for _, row := range response.Rows {
+		switch row.Status {
+		case importStatusCreated:
+			response.Created++
+		case importStatusUpdated:
+			response.Updated++
+		case importStatusFailed:
+			response.Failed++
+		}
+	}
+
+	return response, nil
+}
+
+// parseImportRecord mengubah satu baris CSV menjadi entity.Book dan memvalidasinya dengan
+// aturan yang sama seperti model.CreateBookRequest
+func (b BookUsecase) parseImportRecord(record []string, columns map[string]int) (*entity.Book, int64, error) {
+	value := func(field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	request := model.CreateBookRequest{
+		ISBN:      value("isbn"),
+		Title:     value("title"),
+		Author:    value("author"),
+		Publisher: value("publisher"),
+	}
+
+	if publishedAt := value("published_at"); publishedAt != "" {
+		t, err := time.Parse(time.DateOnly, publishedAt)
+		if err != nil {
+			return nil, 0, errors.New("published_at must use the YYYY-MM-DD format")
+		}
+		request.PublishedAt = t
+	}
+
+	stock := int64(-1)
+	if s := value("stock"); s != "" {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || n < 0 {
+			return nil, 0, errors.New("stock must be a non-negative integer")
+		}
+		stock, request.Stock = n, n
+	}
+
+	// stok 0 atau kosong tetap valid untuk impor, sehingga aturan required pada Stock dikecualikan
+	err := b.validator.StructExcept(&request, "Stock")
+	if err != nil {
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			messages := make([]string, len(validationErrs))
+			for i, fe := range validationErrs {
+				messages[i] = fmt.Sprintf("%s failed on the %s rule", importFieldName(fe.Field()), fe.Tag())
+			}
+			return nil, 0, errors.New(strings.Join(messages, ", "))
+		}
+
+		return nil, 0, err
+	}
+
+	normalizedISBN, err := isbn.Normalize(request.ISBN)
+	if err != nil {
+		return nil, 0, errors.New("isbn is not a valid ISBN-10 or ISBN-13")
+	}
+
+	book := &entity.Book{
+		ISBN:        normalizedISBN,
+		Title:       request.Title,
+		Author:      request.Author,
+		Publisher:   request.Publisher,
+		PublishedAt: request.PublishedAt,
+	}
+
+	return book, stock, nil
+}
+
+// parseImportMapping membaca mapping field ke nama kolom CSV dalam format JSON
+func parseImportMapping(raw string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	if strings.TrimSpace(raw) == "" {
+		return mapping, nil
+	}
+
+	err := json.Unmarshal([]byte(raw), &mapping)
+	if err != nil {
+		return nil, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid column mapping, expected a JSON object"), err.Error())
+	}
+
+	for field := range mapping {
+		if _, ok := bookImportFields[field]; !ok {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Unknown field %q in column mapping", field))
+		}
+	}
+
+	return mapping, nil
+}
+
+// resolveImportColumns mencari indeks kolom untuk setiap field. Tanpa mapping, nama kolom
+// dicocokkan dengan nama field tanpa membedakan huruf besar kecil
+func resolveImportColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		// hapus BOM UTF-8 yang sering ditambahkan oleh aplikasi spreadsheet
+		name = strings.TrimPrefix(name, "\ufeff")
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	columns := make(map[string]int, len(bookImportFields))
+	for field := range bookImportFields {
+		name := field
+		if mapped, ok := mapping[field]; ok {
+			name = mapped
+		}
+
+		if i, ok := index[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[field] = i
+		}
+	}
+
+	for _, field := range []string{"isbn", "title", "author", "publisher", "published_at"} {
+		if _, ok := columns[field]; !ok {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("CSV header is missing the %s column", field))
+		}
+	}
+
+	return columns, nil
+}
+
+// importFieldName mengembalikan nama field CSV dari nama field struct model.CreateBookRequest
+func importFieldName(structField string) string {
+	for field, name := range bookImportFields {
+		if name == structField {
+			return field
+		}
+	}
+
+	return structField
+}
@@ -1,63 +1,144 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/crazydw4rf/book-stock-manager/internal/config"
 	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/export"
+	"github.com/crazydw4rf/book-stock-manager/internal/isbn"
+	"github.com/crazydw4rf/book-stock-manager/internal/label"
 	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/money"
+	"github.com/crazydw4rf/book-stock-manager/internal/qrcode"
 	"github.com/crazydw4rf/book-stock-manager/internal/repository"
 	"github.com/crazydw4rf/book-stock-manager/internal/types"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	"github.com/rotisserie/eris"
+	"github.com/shopspring/decimal"
 )
 
+// maxLabelsPerRequest membatasi jumlah label dalam satu PDF yang dipilih lewat filter
+const maxLabelsPerRequest = 1000
+
 type BookUsecase struct {
-	bookRepo  *repository.BookRepository
-	validator *validator.Validate
+	transactor   *repository.Transactor
+	bookRepo     *repository.BookRepository
+	movementRepo *repository.StockMovementRepository
+	priceRepo    *repository.BookPriceHistoryRepository
+	locationRepo *repository.LocationRepository
+	validator    *validator.Validate
 }
 
-func NewBookUsecase(bookRepo *repository.BookRepository, validator *validator.Validate) *BookUsecase {
-	return &BookUsecase{bookRepo, validator}
+func NewBookUsecase(
+	transactor *repository.Transactor,
+	bookRepo *repository.BookRepository,
+	movementRepo *repository.StockMovementRepository,
+	priceRepo *repository.BookPriceHistoryRepository,
+	locationRepo *repository.LocationRepository,
+	validator *validator.Validate,
+) *BookUsecase {
+	return &BookUsecase{transactor, bookRepo, movementRepo, priceRepo, locationRepo, validator}
 }
 
-func (b BookUsecase) Create(ctx context.Context, bookReq *model.CreateBookRequest) (model.BookResponse, error) {
+func (b BookUsecase) Create(ctx context.Context, userId uuid.UUID, bookReq *model.CreateBookRequest) (model.BookResponse, error) {
 	err := b.validator.Struct(bookReq)
 	if err != nil {
 		return model.BookResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
 	}
 
+	normalizedISBN, err := isbn.Normalize(bookReq.ISBN)
+	if err != nil {
+		return model.BookResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid ISBN format"), err.Error())
+	}
+
+	if bookReq.CostPrice.IsNegative() || bookReq.SalePrice.IsNegative() {
+		return model.BookResponse{}, fiber.NewError(fiber.StatusBadRequest, "Price cannot be negative")
+	}
+
+	currency := bookReq.Currency
+	if currency == "" {
+		currency = config.DEFAULT_CURRENCY
+	}
+
 	bookId, err := uuid.NewV7()
 	if err != nil {
 		return model.BookResponse{}, eris.Errorf("Failed to generate book ID: %v", err)
 	}
 
 	book := &entity.Book{
-		BookId:      bookId,
-		ISBN:        bookReq.ISBN,
-		Title:       bookReq.Title,
-		Author:      bookReq.Author,
-		Publisher:   bookReq.Publisher,
-		PublishedAt: bookReq.PublishedAt,
-		Stock:       bookReq.Stock,
+		BookId:          bookId,
+		ISBN:            normalizedISBN,
+		Title:           bookReq.Title,
+		Author:          bookReq.Author,
+		Publisher:       bookReq.Publisher,
+		PublishedAt:     bookReq.PublishedAt,
+		ReorderPoint:    bookReq.ReorderPoint,
+		ReorderQuantity: bookReq.ReorderQuantity,
+		CostPrice:       bookReq.CostPrice.Round(2),
+		SalePrice:       bookReq.SalePrice.Round(2),
+		Currency:        currency,
 	}
 
-	book, err = b.bookRepo.Create(ctx, book)
+	if bookReq.PreferredSupplierID != nil {
+		book.PreferredSupplierId = uuid.NullUUID{UUID: *bookReq.PreferredSupplierID, Valid: true}
+	}
+
+	// stok awal dicatat di lokasi default, pemindahan ke lokasi lain dilakukan lewat pergerakan stok
+	location, err := resolveLocation(ctx, b.locationRepo, nil)
+	if err != nil {
+		return model.BookResponse{}, err
+	}
+
+	err = b.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		book, err = createBook(ctx, b.bookRepo.WithTx(tx), b.movementRepo.WithTx(tx), b.priceRepo.WithTx(tx), userId, location.LocationId, book, bookReq.Stock)
+		return err
+	})
 	if err != nil {
+		if eris.Is(err, types.ErrDuplicateKey) {
+			return model.BookResponse{}, fiber.NewError(fiber.StatusConflict, "Book with the same ISBN already exists")
+		}
+
+		if eris.Is(err, types.ErrMissingReference) {
+			return model.BookResponse{}, fiber.NewError(fiber.StatusNotFound, "Preferred supplier not found")
+		}
+
 		return model.BookResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to create book"), eris.ToString(err, true))
 	}
 
 	return model.BookToResponse(book), nil
 }
 
-func (b BookUsecase) GetById(ctx context.Context, bookId string) (model.BookResponse, error) {
+// GetById mengambil buku berdasarkan ID. Jika location diisi dengan ID atau kode lokasi, stock
+// berisi stok di lokasi tersebut, bukan stok total
+func (b BookUsecase) GetById(ctx context.Context, bookId string, location string) (model.BookResponse, error) {
 	id, err := uuid.Parse(bookId)
 	if err != nil {
 		return model.BookResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid book ID"), err.Error())
 	}
 
-	book, err := b.bookRepo.GetById(ctx, id)
+	var book *entity.Book
+	if location == "" {
+		book, err = b.bookRepo.GetById(ctx, id)
+	} else {
+		var loc *entity.Location
+		loc, err = findLocation(ctx, b.locationRepo, location)
+		if err != nil {
+			return model.BookResponse{}, err
+		}
+
+		book, err = b.bookRepo.GetByIdAtLocation(ctx, id, loc.LocationId)
+	}
 	if err != nil {
 		if eris.Is(err, types.ErrNoRows) {
 			return model.BookResponse{}, fiber.NewError(fiber.StatusNotFound, "Book not found")
@@ -87,21 +168,121 @@ func (b BookUsecase) GetByISBN(ctx context.Context, isbn string) (model.BookResp
 	return model.BookToResponse(book), nil
 }
 
-func (b BookUsecase) GetMany(ctx context.Context, offset int64, limit int64) ([]model.BookResponse, int64, error) {
+// GetQRCode membuat QR code untuk buku. Isi QR code berupa book_id, atau URL ke
+// endpoint detail buku jika content bernilai "url"
+func (b BookUsecase) GetQRCode(ctx context.Context, bookId string, request *model.BookQRCodeRequest, baseURL string) ([]byte, qrcode.Format, error) {
+	err := b.validator.Struct(request)
+	if err != nil {
+		return nil, "", eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters"), err.Error())
+	}
+
+	book, err := b.GetById(ctx, bookId, "")
+	if err != nil {
+		return nil, "", err
+	}
+
+	content := book.BookID.String()
+	if request.Content == "url" {
+		content = baseURL + config.BASE_API_HTTP_PATH + "/books/" + content
+	}
+
+	format := qrcode.Format(request.Format)
+	if format == "" {
+		format = qrcode.FormatPNG
+	}
+
+	image, err := qrcode.Encode(content, qrcode.Options{
+		Format: format,
+		Size:   request.Size,
+		Level:  qrcode.Level(request.Level),
+	})
+	if err != nil {
+		return nil, "", eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to generate QR code"), err.Error())
+	}
+
+	return image, format, nil
+}
+
+// GetLabelSheet membuat lembar label A4 dalam format PDF untuk buku yang dipilih
+func (b BookUsecase) GetLabelSheet(ctx context.Context, request *model.BookLabelRequest, baseURL string) ([]byte, error) {
+	err := b.validator.Struct(request)
+	if err != nil {
+		return nil, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	var books []*entity.Book
+	if len(request.BookIDs) > 0 {
+		books = make([]*entity.Book, 0, len(request.BookIDs))
+		for _, id := range request.BookIDs {
+			book, err := b.bookRepo.GetById(ctx, id)
+			if err != nil {
+				if eris.Is(err, types.ErrNoRows) {
+					return nil, fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("Book %s not found", id))
+				}
+
+				return nil, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get book"), eris.ToString(err, true))
+			}
+
+			books = append(books, book)
+		}
+	} else {
+		if request.Publisher == "" && request.Author == "" {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Either book_ids or a publisher/author filter is required")
+		}
+
+		filter := repository.BookFilter{Publisher: request.Publisher, Author: request.Author, Sort: "title"}
+		books, err = b.bookRepo.GetMany(ctx, filter, 0, maxLabelsPerRequest)
+		if err != nil {
+			return nil, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get books"), eris.ToString(err, true))
+		}
+	}
+
+	labels := make([]label.Label, len(books))
+	for i, book := range books {
+		content := book.BookId.String()
+		if request.Content == "url" {
+			content = baseURL + config.BASE_API_HTTP_PATH + "/books/" + content
+		}
+
+		labels[i] = label.Label{
+			Title:     book.Title,
+			Author:    book.Author,
+			ISBN:      book.ISBN,
+			QRContent: content,
+		}
+
+		// buku yang belum diberi harga jual dicetak tanpa baris harga
+		if book.SalePrice.IsPositive() {
+			labels[i].Price = strings.TrimSpace(book.Currency + " " + money.Format(book.SalePrice))
+		}
+	}
+
+	var buf bytes.Buffer
+	err = label.RenderSheet(&buf, labels, label.Layout{Columns: request.Columns, Rows: request.Rows})
+	if err != nil {
+		return nil, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to generate label sheet"), err.Error())
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (b BookUsecase) GetMany(ctx context.Context, request *model.BookSearchRequest, offset int64, limit int64) ([]model.BookResponse, int64, error) {
 	if limit <= 0 {
 		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Limit must be greater than 0"), "Invalid limit")
 	}
 
-	books, err := b.bookRepo.GetMany(ctx, offset, limit)
+	filter, err := b.newBookFilter(ctx, request)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	books, err := b.bookRepo.GetMany(ctx, filter, offset, limit)
 	if err != nil {
-		if eris.Is(err, types.ErrNoRows) {
-			return nil, 0, fiber.NewError(fiber.StatusNotFound, "No books found")
-		}
 		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get books"), eris.ToString(err, true))
 	}
 
 	// Get total count for pagination
-	total, err := b.bookRepo.GetTotalCount(ctx)
+	total, err := b.bookRepo.GetTotalCount(ctx, filter)
 	if err != nil {
 		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get total count"), eris.ToString(err, true))
 	}
@@ -114,34 +295,474 @@ func (b BookUsecase) GetMany(ctx context.Context, offset int64, limit int64) ([]
 	return booksResp, total, nil
 }
 
-func (b BookUsecase) Update(ctx context.Context, request *model.UpdateBookRequest) (model.BookResponse, error) {
+// GetLowStock mengambil daftar buku yang stoknya sudah mencapai reorder point
+func (b BookUsecase) GetLowStock(ctx context.Context, request *model.LowStockSearchRequest, offset int64, limit int64) ([]model.LowStockBookResponse, int64, error) {
+	if limit <= 0 {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Limit must be greater than 0"), "Invalid limit")
+	}
+
+	err := b.validator.Struct(request)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters"), err.Error())
+	}
+
+	var supplierId uuid.NullUUID
+	if request.SupplierID != "" {
+		supplierId = uuid.NullUUID{UUID: uuid.MustParse(request.SupplierID), Valid: true}
+	}
+
+	books, err := b.bookRepo.GetLowStock(ctx, supplierId, offset, limit)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get low stock books"), eris.ToString(err, true))
+	}
+
+	total, err := b.bookRepo.GetLowStockTotalCount(ctx, supplierId)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get total count"), eris.ToString(err, true))
+	}
+
+	booksResp := make([]model.LowStockBookResponse, len(books))
+	for i, book := range books {
+		booksResp[i] = model.LowStockBookResponse{
+			BookResponse:      model.BookToResponse(&book.Book),
+			OnOrder:           book.OnOrder,
+			SuggestedQuantity: suggestedReorderQuantity(book),
+		}
+	}
+
+	return booksResp, total, nil
+}
+
+// suggestedReorderQuantity menghitung jumlah pesanan agar stok ditambah pesanan yang berjalan
+// kembali di atas reorder point, minimal sebanyak reorder_quantity
+func suggestedReorderQuantity(book *entity.LowStockBook) int64 {
+	available := book.Stock + book.OnOrder
+	if available > book.ReorderPoint {
+		return 0
+	}
+
+	return max(book.ReorderQuantity, book.ReorderPoint-available+1)
+}
+
+// GetManyByCursor mengambil daftar buku dengan paginasi keyset berdasarkan book_id.
+// Cursor kosong berarti halaman pertama
+func (b BookUsecase) GetManyByCursor(ctx context.Context, request *model.BookSearchRequest, cursor string, limit int64) ([]model.BookResponse, model.CursorPage, error) {
+	if limit <= 0 {
+		return nil, model.CursorPage{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Limit must be greater than 0"), "Invalid limit")
+	}
+
+	if request.Sort != "" || request.Order != "" {
+		return nil, model.CursorPage{}, fiber.NewError(fiber.StatusBadRequest, "Cursor pagination is always ordered by book_id, sort and order are not supported")
+	}
+
+	filter, err := b.newBookFilter(ctx, request)
+	if err != nil {
+		return nil, model.CursorPage{}, err
+	}
+	filter.Sort, filter.Desc = "", false
+
+	var current *model.Cursor
+	if cursor != "" {
+		decoded, err := model.DecodeCursor(cursor)
+		if err != nil {
+			return nil, model.CursorPage{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid cursor"), err.Error())
+		}
+		current = &decoded
+
+		if current.Backward {
+			// halaman sebelumnya diambil terbalik lalu diurutkan ulang di bawah
+			filter.BeforeId, filter.Desc = &current.ID, true
+		} else {
+			filter.AfterId = &current.ID
+		}
+	}
+
+	// satu baris tambahan dipakai untuk mengetahui apakah masih ada halaman lanjutan
+	books, err := b.bookRepo.GetMany(ctx, filter, 0, limit+1)
+	if err != nil {
+		return nil, model.CursorPage{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get books"), eris.ToString(err, true))
+	}
+
+	hasMore := int64(len(books)) > limit
+	if hasMore {
+		books = books[:limit]
+	}
+
+	if filter.Desc {
+		slices.Reverse(books)
+	}
+
+	var page model.CursorPage
+	backward := current != nil && current.Backward
+	if len(books) == 0 {
+		// halaman kosong tetap bisa kembali ke posisi cursor asal
+		if current != nil {
+			page.Prev = model.Cursor{ID: current.ID, Backward: true}.Encode()
+			if backward {
+				page.Prev, page.Next = "", model.Cursor{ID: current.ID}.Encode()
+			}
+		}
+
+		return []model.BookResponse{}, page, nil
+	}
+
+	first, last := books[0].BookId, books[len(books)-1].BookId
+	if hasMore || backward {
+		page.Next = model.Cursor{ID: last}.Encode()
+	}
+	if (backward && hasMore) || (!backward && current != nil) {
+		page.Prev = model.Cursor{ID: first, Backward: true}.Encode()
+	}
+
+	booksResp := make([]model.BookResponse, len(books))
+	for i, book := range books {
+		booksResp[i] = model.BookToResponse(book)
+	}
+
+	return booksResp, page, nil
+}
+
+// bookExportColumns adalah urutan kolom pada file export katalog
+var bookExportColumns = []string{
+	"book_id", "isbn", "title", "author", "publisher", "published_at", "stock", "reorder_point", "reorder_quantity",
+	"cost_price", "sale_price", "currency", "created_at", "updated_at",
+}
+
+// Export memvalidasi format dan filter lalu mengembalikan fungsi yang menulis seluruh buku
+// yang cocok ke w. Buku dibaca satu per satu dari cursor database sehingga bisa di-stream
+func (b BookUsecase) Export(ctx context.Context, format string, request *model.BookSearchRequest) (export.Format, func(ctx context.Context, w io.Writer) error, error) {
+	err := b.validator.Var(format, "oneof=csv jsonl xlsx")
+	if err != nil {
+		return "", nil, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid export format, expected csv, jsonl or xlsx"), err.Error())
+	}
+
+	filter, err := b.newBookFilter(ctx, request)
+	if err != nil {
+		return "", nil, err
+	}
+
+	exportFormat := export.Format(format)
+	write := func(ctx context.Context, w io.Writer) error {
+		writer, err := export.NewWriter(exportFormat, w, bookExportColumns)
+		if err != nil {
+			return err
+		}
+
+		err = b.bookRepo.Each(ctx, filter, func(book *entity.Book) error {
+			return writer.Write([]any{
+				book.BookId,
+				book.ISBN,
+				book.Title,
+				book.Author,
+				book.Publisher,
+				book.PublishedAt,
+				book.Stock,
+				book.ReorderPoint,
+				book.ReorderQuantity,
+				book.CostPrice,
+				book.SalePrice,
+				book.Currency,
+				book.CreatedAt,
+				book.UpdatedAt,
+			})
+		})
+		if err != nil {
+			// tetap ditutup agar file sementara xlsx dibersihkan, error penutupan diabaikan
+			// karena error dari cursor atau penulisan lebih berguna untuk dilaporkan
+			writer.Close()
+			return err
+		}
+
+		return writer.Close()
+	}
+
+	return exportFormat, write, nil
+}
+
+// newBookFilter memvalidasi parameter pencarian dan mengubahnya menjadi repository.BookFilter
+func (b BookUsecase) newBookFilter(ctx context.Context, request *model.BookSearchRequest) (repository.BookFilter, error) {
+	err := b.validator.Struct(request)
+	if err != nil {
+		return repository.BookFilter{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters"), err.Error())
+	}
+
+	filter := repository.BookFilter{
+		Search:    strings.TrimSpace(request.Query),
+		Publisher: request.Publisher,
+		Author:    request.Author,
+		StockMin:  request.StockMin,
+		StockMax:  request.StockMax,
+		Sort:      request.Sort,
+		Desc:      request.Order == "desc",
+	}
+
+	if request.PublishedFrom != "" {
+		from, _ := time.Parse(time.DateOnly, request.PublishedFrom)
+		filter.PublishedFrom = &from
+	}
+	if request.PublishedTo != "" {
+		to, _ := time.Parse(time.DateOnly, request.PublishedTo)
+		filter.PublishedTo = &to
+	}
+
+	if request.Location != "" {
+		location, err := findLocation(ctx, b.locationRepo, request.Location)
+		if err != nil {
+			return repository.BookFilter{}, err
+		}
+		filter.LocationId = &location.LocationId
+	}
+
+	if filter.Sort == "relevance" && filter.Search == "" {
+		return repository.BookFilter{}, fiber.NewError(fiber.StatusBadRequest, "Sort by relevance requires a search query")
+	}
+
+	// hasil pencarian teks paling relevan ditampilkan lebih dulu jika sort tidak diisi
+	if filter.Sort == "" && filter.Search != "" {
+		filter.Sort = "relevance"
+		filter.Desc = request.Order != "asc"
+	}
+
+	return filter, nil
+}
+
+func (b BookUsecase) Update(ctx context.Context, userId uuid.UUID, request *model.UpdateBookRequest) (model.BookResponse, error) {
 	err := b.validator.Struct(request)
 	if err != nil {
 		return model.BookResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
 	}
 
+	// ISBN kosong berarti ISBN tidak diubah
+	var normalizedISBN string
+	if request.ISBN != "" {
+		normalizedISBN, err = isbn.Normalize(request.ISBN)
+		if err != nil {
+			return model.BookResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid ISBN format"), err.Error())
+		}
+	}
+
 	book := &entity.Book{
 		BookId:      request.BookID,
-		ISBN:        request.ISBN,
+		ISBN:        normalizedISBN,
 		Title:       request.Title,
 		Author:      request.Author,
 		Publisher:   request.Publisher,
 		PublishedAt: request.PublishedAt,
-		Stock:       request.Stock,
+		Currency:    request.Currency,
+	}
+
+	update := repository.BookUpdate{ClearPreferredSupplier: request.ClearPreferredSupplier}
+	if request.CostPrice != nil {
+		if request.CostPrice.IsNegative() {
+			return model.BookResponse{}, fiber.NewError(fiber.StatusBadRequest, "Price cannot be negative")
+		}
+		update.CostPrice = decimal.NewNullDecimal(request.CostPrice.Round(2))
+	}
+
+	if request.SalePrice != nil {
+		if request.SalePrice.IsNegative() {
+			return model.BookResponse{}, fiber.NewError(fiber.StatusBadRequest, "Price cannot be negative")
+		}
+		update.SalePrice = decimal.NewNullDecimal(request.SalePrice.Round(2))
+	}
+
+	if request.ReorderPoint != nil {
+		update.ReorderPoint = sql.NullInt64{Int64: *request.ReorderPoint, Valid: true}
 	}
 
-	updatedBook, err := b.bookRepo.Update(ctx, book)
+	if request.ReorderQuantity != nil {
+		update.ReorderQuantity = sql.NullInt64{Int64: *request.ReorderQuantity, Valid: true}
+	}
+
+	if request.PreferredSupplierID != nil {
+		update.PreferredSupplierId = uuid.NullUUID{UUID: *request.PreferredSupplierID, Valid: true}
+	}
+
+	location, err := resolveLocation(ctx, b.locationRepo, request.LocationID)
+	if err != nil {
+		return model.BookResponse{}, err
+	}
+
+	var updatedBook *entity.Book
+	err = b.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		updatedBook, err = updateBook(ctx, b.bookRepo.WithTx(tx), b.movementRepo.WithTx(tx), b.priceRepo.WithTx(tx), userId, location.LocationId, book, update, request.Stock)
+		return err
+	})
 	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return model.BookResponse{}, fe
+		}
+
 		if eris.Is(err, types.ErrNoRows) {
 			return model.BookResponse{}, fiber.NewError(fiber.StatusNotFound, "Book not found")
 		}
 
+		if eris.Is(err, types.ErrDuplicateKey) {
+			return model.BookResponse{}, fiber.NewError(fiber.StatusConflict, "Book with the same ISBN already exists")
+		}
+
+		if eris.Is(err, types.ErrMissingReference) {
+			return model.BookResponse{}, fiber.NewError(fiber.StatusNotFound, "Preferred supplier not found")
+		}
+
 		return model.BookResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to update book"), eris.ToString(err, true))
 	}
 
 	return model.BookToResponse(updatedBook), nil
 }
 
+// createBook menyimpan buku baru di dalam transaksi. Stok awal dicatat sebagai pergerakan stok di
+// locationId agar ledger selalu sama dengan books.stock, harga awal dicatat sebagai riwayat harga pertama
+func createBook(
+	ctx context.Context,
+	bookRepo *repository.BookRepository,
+	movementRepo *repository.StockMovementRepository,
+	priceRepo *repository.BookPriceHistoryRepository,
+	userId uuid.UUID,
+	locationId uuid.UUID,
+	book *entity.Book,
+	stock int64,
+) (*entity.Book, error) {
+	book, err := bookRepo.Create(ctx, book)
+	if err != nil {
+		return nil, err
+	}
+
+	err = recordBookPrice(ctx, priceRepo, book, userId)
+	if err != nil || stock == 0 {
+		return book, err
+	}
+
+	movement, err := newStockMovement(book.BookId, locationId, entity.StockMovementAdjustment, stock, "initial stock", "", userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyStockMovement(ctx, bookRepo, movementRepo, movement)
+}
+
+// updateBook memperbarui buku di dalam transaksi. stock adalah stok baru di locationId, stok tidak
+// ditimpa langsung tetapi selisihnya terhadap stok lokasi dicatat sebagai pergerakan stok. Nilai stock
+// negatif berarti stok tidak diubah. Perubahan harga atau mata uang dicatat di riwayat harga
+func updateBook(
+	ctx context.Context,
+	bookRepo *repository.BookRepository,
+	movementRepo *repository.StockMovementRepository,
+	priceRepo *repository.BookPriceHistoryRepository,
+	userId uuid.UUID,
+	locationId uuid.UUID,
+	book *entity.Book,
+	update repository.BookUpdate,
+	stock int64,
+) (*entity.Book, error) {
+	current, err := bookRepo.GetByIdForUpdate(ctx, book.BookId)
+	if err != nil {
+		return nil, err
+	}
+
+	if stock >= 0 {
+		atLocation, err := bookRepo.GetByIdAtLocation(ctx, book.BookId, locationId)
+		if err != nil {
+			return nil, err
+		}
+
+		// stok yang ditahan reservasi harus tetap tersedia secara fisik
+		if stock < atLocation.Reserved {
+			return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Stock cannot be set below the %d reserved copies at this location", atLocation.Reserved))
+		}
+
+		if delta := stock - atLocation.Stock; delta != 0 {
+			movement, err := newStockMovement(book.BookId, locationId, entity.StockMovementAdjustment, delta, "stock updated via book update", "", userId)
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = applyStockMovement(ctx, bookRepo, movementRepo, movement)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	updated, err := bookRepo.Update(ctx, book, update)
+	if err != nil {
+		return nil, err
+	}
+
+	if !updated.CostPrice.Equal(current.CostPrice) || !updated.SalePrice.Equal(current.SalePrice) || updated.Currency != current.Currency {
+		err = recordBookPrice(ctx, priceRepo, updated, userId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return updated, nil
+}
+
+// recordBookPrice menutup harga yang sedang berlaku lalu mencatat harga buku saat ini
+// sebagai harga yang berlaku mulai sekarang
+func recordBookPrice(ctx context.Context, priceRepo *repository.BookPriceHistoryRepository, book *entity.Book, userId uuid.UUID) error {
+	historyId, err := uuid.NewV7()
+	if err != nil {
+		return eris.Errorf("Failed to generate price history ID: %v", err)
+	}
+
+	err = priceRepo.CloseCurrent(ctx, book.BookId)
+	if err != nil {
+		return err
+	}
+
+	_, err = priceRepo.Create(ctx, &entity.BookPriceHistory{
+		PriceHistoryId: historyId,
+		BookId:         book.BookId,
+		CostPrice:      book.CostPrice,
+		SalePrice:      book.SalePrice,
+		Currency:       book.Currency,
+		ChangedBy:      uuid.NullUUID{UUID: userId, Valid: userId != uuid.Nil},
+	})
+
+	return err
+}
+
+// GetPriceHistory mengambil riwayat harga sebuah buku, dimulai dari harga terbaru
+func (b BookUsecase) GetPriceHistory(ctx context.Context, bookId string, offset int64, limit int64) ([]model.BookPriceHistoryResponse, int64, error) {
+	id, err := uuid.Parse(bookId)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid book ID"), err.Error())
+	}
+
+	if limit <= 0 {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Limit must be greater than 0"), "Invalid limit")
+	}
+
+	_, err = b.bookRepo.GetById(ctx, id)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return nil, 0, fiber.NewError(fiber.StatusNotFound, "Book not found")
+		}
+
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get book"), eris.ToString(err, true))
+	}
+
+	history, err := b.priceRepo.GetManyByBookId(ctx, id, offset, limit)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get price history"), eris.ToString(err, true))
+	}
+
+	total, err := b.priceRepo.GetTotalCountByBookId(ctx, id)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get total count"), eris.ToString(err, true))
+	}
+
+	historyResp := make([]model.BookPriceHistoryResponse, len(history))
+	for i, price := range history {
+		historyResp[i] = model.BookPriceHistoryToResponse(price)
+	}
+
+	return historyResp, total, nil
+}
+
 func (b BookUsecase) Delete(ctx context.Context, bookId string) error {
 	id, err := uuid.Parse(bookId)
 	if err != nil {
@@ -154,6 +775,10 @@ func (b BookUsecase) Delete(ctx context.Context, bookId string) error {
 			return fiber.NewError(fiber.StatusNotFound, "Book not found")
 		}
 
+		if eris.Is(err, types.ErrReferenced) {
+			return fiber.NewError(fiber.StatusConflict, "Book is used by purchase orders, stock transfers or reservations and cannot be deleted")
+		}
+
 		return eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to delete book"), err.Error())
 	}
 
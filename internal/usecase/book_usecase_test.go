@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+)
+
+func TestSuggestedReorderQuantity(t *testing.T) {
+	tests := []struct {
+		name            string
+		stock           int64
+		onOrder         int64
+		reorderPoint    int64
+		reorderQuantity int64
+		want            int64
+	}{
+		{name: "above reorder point", stock: 11, reorderPoint: 10, reorderQuantity: 20, want: 0},
+		{name: "on order lifts above reorder point", stock: 2, onOrder: 9, reorderPoint: 10, reorderQuantity: 20, want: 0},
+		{name: "at reorder point orders reorder quantity", stock: 10, reorderPoint: 10, reorderQuantity: 20, want: 20},
+		{name: "on order counts toward available", stock: 3, onOrder: 4, reorderPoint: 10, reorderQuantity: 5, want: 5},
+		{name: "deficit larger than reorder quantity", stock: 0, reorderPoint: 30, reorderQuantity: 10, want: 31},
+		{name: "negative stock", stock: -4, reorderPoint: 5, reorderQuantity: 2, want: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book := &entity.LowStockBook{
+				Book:    entity.Book{Stock: tt.stock, ReorderPoint: tt.reorderPoint, ReorderQuantity: tt.reorderQuantity},
+				OnOrder: tt.onOrder,
+			}
+
+			if got := suggestedReorderQuantity(book); got != tt.want {
+				t.Errorf("suggestedReorderQuantity() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/repository"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+// LocationUsecase mengelola lokasi penyimpanan stok seperti toko, booth atau gudang
+type LocationUsecase struct {
+	transactor   *repository.Transactor
+	locationRepo *repository.LocationRepository
+	validator    *validator.Validate
+}
+
+func NewLocationUsecase(transactor *repository.Transactor, locationRepo *repository.LocationRepository, validator *validator.Validate) *LocationUsecase {
+	return &LocationUsecase{transactor, locationRepo, validator}
+}
+
+func (l LocationUsecase) Create(ctx context.Context, request *model.CreateLocationRequest) (model.LocationResponse, error) {
+	err := l.validator.Struct(request)
+	if err != nil {
+		return model.LocationResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	locationId, err := uuid.NewV7()
+	if err != nil {
+		return model.LocationResponse{}, eris.Errorf("Failed to generate location ID: %v", err)
+	}
+
+	location := &entity.Location{
+		LocationId: locationId,
+		Code:       request.Code,
+		Name:       request.Name,
+		Address:    request.Address,
+	}
+
+	err = l.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		locationRepo := l.locationRepo.WithTx(tx)
+
+		location, err = locationRepo.Create(ctx, location)
+		if err != nil || !request.IsDefault {
+			return err
+		}
+
+		location, err = locationRepo.SetDefault(ctx, location.LocationId)
+		return err
+	})
+	if err != nil {
+		if eris.Is(err, types.ErrDuplicateKey) {
+			return model.LocationResponse{}, fiber.NewError(fiber.StatusConflict, "Location with the same code already exists")
+		}
+
+		return model.LocationResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to create location"), eris.ToString(err, true))
+	}
+
+	return model.LocationToResponse(location), nil
+}
+
+func (l LocationUsecase) GetById(ctx context.Context, locationId string) (model.LocationResponse, error) {
+	id, err := uuid.Parse(locationId)
+	if err != nil {
+		return model.LocationResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid location ID"), err.Error())
+	}
+
+	location, err := resolveLocation(ctx, l.locationRepo, &id)
+	if err != nil {
+		return model.LocationResponse{}, err
+	}
+
+	return model.LocationToResponse(location), nil
+}
+
+func (l LocationUsecase) GetMany(ctx context.Context, offset int64, limit int64) ([]model.LocationResponse, int64, error) {
+	if limit <= 0 {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Limit must be greater than 0"), "Invalid limit")
+	}
+
+	locations, err := l.locationRepo.GetMany(ctx, offset, limit)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get locations"), eris.ToString(err, true))
+	}
+
+	total, err := l.locationRepo.GetTotalCount(ctx)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get total count"), eris.ToString(err, true))
+	}
+
+	locationsResp := make([]model.LocationResponse, len(locations))
+	for i, location := range locations {
+		locationsResp[i] = model.LocationToResponse(location)
+	}
+
+	return locationsResp, total, nil
+}
+
+func (l LocationUsecase) Update(ctx context.Context, request *model.UpdateLocationRequest) (model.LocationResponse, error) {
+	err := l.validator.Struct(request)
+	if err != nil {
+		return model.LocationResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	// selalu harus ada satu lokasi default, sehingga status default hanya bisa dipindahkan
+	if request.IsDefault != nil && !*request.IsDefault {
+		return model.LocationResponse{}, fiber.NewError(fiber.StatusBadRequest, "Set another location as default instead of unsetting the default location")
+	}
+
+	var location *entity.Location
+	err = l.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		locationRepo := l.locationRepo.WithTx(tx)
+
+		location, err = locationRepo.Update(ctx, request.LocationID, repository.LocationPatch{
+			Code:    request.Code,
+			Name:    request.Name,
+			Address: request.Address,
+		})
+		if err != nil || request.IsDefault == nil || location.IsDefault {
+			return err
+		}
+
+		location, err = locationRepo.SetDefault(ctx, location.LocationId)
+		return err
+	})
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return model.LocationResponse{}, fiber.NewError(fiber.StatusNotFound, "Location not found")
+		}
+
+		if eris.Is(err, types.ErrDuplicateKey) {
+			return model.LocationResponse{}, fiber.NewError(fiber.StatusConflict, "Location with the same code already exists")
+		}
+
+		return model.LocationResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to update location"), eris.ToString(err, true))
+	}
+
+	return model.LocationToResponse(location), nil
+}
+
+// Delete menghapus lokasi yang sudah tidak menyimpan stok. Lokasi default tidak bisa dihapus.
+// Pergerakan stok lama tetap ada tanpa lokasi
+func (l LocationUsecase) Delete(ctx context.Context, locationId string) error {
+	id, err := uuid.Parse(locationId)
+	if err != nil {
+		return eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid location ID"), err.Error())
+	}
+
+	location, err := resolveLocation(ctx, l.locationRepo, &id)
+	if err != nil {
+		return err
+	}
+
+	if location.IsDefault {
+		return fiber.NewError(fiber.StatusConflict, "Default location cannot be deleted")
+	}
+
+	err = l.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		return l.locationRepo.WithTx(tx).Delete(ctx, id)
+	})
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "Location not found")
+		}
+
+		if eris.Is(err, types.ErrReferenced) {
+			return fiber.NewError(fiber.StatusConflict, "Location still holds stock or has stock transfers and cannot be deleted")
+		}
+
+		return eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to delete location"), err.Error())
+	}
+
+	return nil
+}
+
+// resolveLocation mengambil lokasi berdasarkan ID, atau lokasi default jika locationId nil.
+// Dipakai oleh setiap perubahan stok untuk menentukan lokasi yang stoknya diubah
+func resolveLocation(ctx context.Context, locationRepo *repository.LocationRepository, locationId *uuid.UUID) (*entity.Location, error) {
+	var (
+		location *entity.Location
+		err      error
+	)
+	if locationId == nil {
+		location, err = locationRepo.GetDefault(ctx)
+	} else {
+		location, err = locationRepo.GetById(ctx, *locationId)
+	}
+
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) && locationId != nil {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Location not found")
+		}
+
+		return nil, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get location"), eris.ToString(err, true))
+	}
+
+	return location, nil
+}
+
+// findLocation mencari lokasi berdasarkan ID atau kode, dipakai oleh parameter kueri location
+func findLocation(ctx context.Context, locationRepo *repository.LocationRepository, value string) (*entity.Location, error) {
+	if id, err := uuid.Parse(value); err == nil {
+		return resolveLocation(ctx, locationRepo, &id)
+	}
+
+	location, err := locationRepo.GetByCode(ctx, value)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Location not found")
+		}
+
+		return nil, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get location"), eris.ToString(err, true))
+	}
+
+	return location, nil
+}
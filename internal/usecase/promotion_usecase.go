@@ -0,0 +1,356 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/pricing"
+	"github.com/crazydw4rf/book-stock-manager/internal/repository"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+	"github.com/shopspring/decimal"
+)
+
+type PromotionUsecase struct {
+	transactor    *repository.Transactor
+	promotionRepo *repository.PromotionRepository
+	validator     *validator.Validate
+}
+
+func NewPromotionUsecase(
+	transactor *repository.Transactor,
+	promotionRepo *repository.PromotionRepository,
+	validator *validator.Validate,
+) *PromotionUsecase {
+	return &PromotionUsecase{transactor, promotionRepo, validator}
+}
+
+// Create membuat promosi baru beserta daftar bukunya dalam satu transaksi
+func (p PromotionUsecase) Create(ctx context.Context, userId uuid.UUID, request *model.PromotionRequest) (model.PromotionResponse, error) {
+	promotionId, err := uuid.NewV7()
+	if err != nil {
+		return model.PromotionResponse{}, eris.Errorf("Failed to generate promotion ID: %v", err)
+	}
+
+	promotion, err := p.newPromotion(promotionId, request)
+	if err != nil {
+		return model.PromotionResponse{}, err
+	}
+	promotion.CreatedBy = uuid.NullUUID{UUID: userId, Valid: userId != uuid.Nil}
+
+	var (
+		books        []*entity.PromotionBook
+		failedBookId uuid.UUID
+	)
+	err = p.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		promotionRepo := p.promotionRepo.WithTx(tx)
+
+		promotion, err = promotionRepo.Create(ctx, promotion)
+		if err != nil {
+			return err
+		}
+
+		books, failedBookId, err = createPromotionBooks(ctx, promotionRepo, promotion, request.BookIDs)
+		return err
+	})
+	if err != nil {
+		return model.PromotionResponse{}, promotionWriteError(err, failedBookId, "Failed to create promotion")
+	}
+
+	return model.PromotionToResponse(promotion, books), nil
+}
+
+func (p PromotionUsecase) GetById(ctx context.Context, promotionId string) (model.PromotionResponse, error) {
+	id, err := uuid.Parse(promotionId)
+	if err != nil {
+		return model.PromotionResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid promotion ID"), err.Error())
+	}
+
+	promotion, err := p.promotionRepo.GetById(ctx, id)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return model.PromotionResponse{}, fiber.NewError(fiber.StatusNotFound, "Promotion not found")
+		}
+
+		return model.PromotionResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get promotion"), eris.ToString(err, true))
+	}
+
+	books, err := p.promotionRepo.GetBooksByPromotionId(ctx, id)
+	if err != nil {
+		return model.PromotionResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get promotion books"), eris.ToString(err, true))
+	}
+
+	return model.PromotionToResponse(promotion, books), nil
+}
+
+func (p PromotionUsecase) GetMany(ctx context.Context, offset int64, limit int64) ([]model.PromotionResponse, int64, error) {
+	if limit <= 0 {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Limit must be greater than 0"), "Invalid limit")
+	}
+
+	promotions, err := p.promotionRepo.GetMany(ctx, offset, limit)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get promotions"), eris.ToString(err, true))
+	}
+
+	total, err := p.promotionRepo.GetTotalCount(ctx)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get total count"), eris.ToString(err, true))
+	}
+
+	promotionsResp := make([]model.PromotionResponse, len(promotions))
+	for i, promotion := range promotions {
+		promotionsResp[i] = model.PromotionToResponse(promotion, nil)
+	}
+
+	return promotionsResp, total, nil
+}
+
+// Update mengganti seluruh pengaturan dan daftar buku promosi. Penjualan yang sudah tercatat
+// tidak berubah karena diskonnya disimpan pada penjualan
+func (p PromotionUsecase) Update(ctx context.Context, promotionId string, request *model.PromotionRequest) (model.PromotionResponse, error) {
+	id, err := uuid.Parse(promotionId)
+	if err != nil {
+		return model.PromotionResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid promotion ID"), err.Error())
+	}
+
+	promotion, err := p.newPromotion(id, request)
+	if err != nil {
+		return model.PromotionResponse{}, err
+	}
+
+	var (
+		books        []*entity.PromotionBook
+		failedBookId uuid.UUID
+	)
+	err = p.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		promotionRepo := p.promotionRepo.WithTx(tx)
+
+		promotion, err = promotionRepo.Update(ctx, promotion)
+		if err != nil {
+			return err
+		}
+
+		err = promotionRepo.DeleteBooks(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		books, failedBookId, err = createPromotionBooks(ctx, promotionRepo, promotion, request.BookIDs)
+		return err
+	})
+	if err != nil {
+		return model.PromotionResponse{}, promotionWriteError(err, failedBookId, "Failed to update promotion")
+	}
+
+	return model.PromotionToResponse(promotion, books), nil
+}
+
+func (p PromotionUsecase) Delete(ctx context.Context, promotionId string) error {
+	id, err := uuid.Parse(promotionId)
+	if err != nil {
+		return eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid promotion ID"), err.Error())
+	}
+
+	err = p.promotionRepo.Delete(ctx, id)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "Promotion not found")
+		}
+
+		return eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to delete promotion"), err.Error())
+	}
+
+	return nil
+}
+
+// newPromotion memvalidasi request lalu membentuk entity.Promotion. Field aturan yang tidak
+// dipakai oleh rule_type dikosongkan agar data yang tersimpan tidak membingungkan
+func (p PromotionUsecase) newPromotion(promotionId uuid.UUID, request *model.PromotionRequest) (*entity.Promotion, error) {
+	err := p.validator.Struct(request)
+	if err != nil {
+		return nil, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	promotion := &entity.Promotion{
+		PromotionId: promotionId,
+		Name:        request.Name,
+		RuleType:    entity.PromotionRuleType(request.RuleType),
+		Scope:       entity.PromotionScope(request.Scope),
+		ScopeValue:  strings.TrimSpace(request.ScopeValue),
+		Percent:     decimal.Zero,
+		BundlePrice: decimal.Zero,
+		MinSubtotal: request.MinSubtotal.Round(2),
+		Priority:    request.Priority,
+		Stackable:   request.Stackable,
+		Active:      request.Active == nil || *request.Active,
+		StartsAt:    time.Now(),
+	}
+
+	switch promotion.RuleType {
+	case entity.PromotionPercentOff:
+		if !request.Percent.IsPositive() || request.Percent.GreaterThan(decimal.NewFromInt(100)) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Percent must be greater than 0 and at most 100")
+		}
+		promotion.Percent = request.Percent.Round(2)
+	case entity.PromotionBuyXGetY:
+		if request.BuyQuantity <= 0 || request.FreeQuantity <= 0 {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Buy quantity and free quantity must be greater than 0")
+		}
+		promotion.BuyQuantity, promotion.FreeQuantity = request.BuyQuantity, request.FreeQuantity
+	case entity.PromotionFixedPriceBundle:
+		if request.BundleQuantity < 2 {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Bundle quantity must be at least 2")
+		}
+		if !request.BundlePrice.IsPositive() {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Bundle price must be greater than 0")
+		}
+		promotion.BundleQuantity, promotion.BundlePrice = request.BundleQuantity, request.BundlePrice.Round(2)
+	}
+
+	switch promotion.Scope {
+	case entity.PromotionScopePublisher, entity.PromotionScopeAuthor:
+		if promotion.ScopeValue == "" {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Scope value is required for the %s scope", promotion.Scope))
+		}
+	case entity.PromotionScopeBooks:
+		if len(request.BookIDs) == 0 {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Book IDs are required for the books scope")
+		}
+	default:
+		promotion.ScopeValue = ""
+	}
+
+	if promotion.Scope != entity.PromotionScopeBooks && len(request.BookIDs) > 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Book IDs can only be set for the books scope")
+	}
+
+	if promotion.MinSubtotal.IsNegative() {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Minimum subtotal cannot be negative")
+	}
+
+	if request.StartsAt != nil {
+		promotion.StartsAt = *request.StartsAt
+	}
+
+	if request.EndsAt != nil {
+		if !request.EndsAt.After(promotion.StartsAt) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "End time must be after start time")
+		}
+		promotion.EndsAt = sql.NullTime{Time: *request.EndsAt, Valid: true}
+	}
+
+	return promotion, nil
+}
+
+// createPromotionBooks menyimpan daftar buku promosi. Book ID yang gagal disimpan dikembalikan
+// agar pesan error dapat menyebutkan buku yang tidak ditemukan
+func createPromotionBooks(
+	ctx context.Context,
+	promotionRepo *repository.PromotionRepository,
+	promotion *entity.Promotion,
+	bookIds []uuid.UUID,
+) ([]*entity.PromotionBook, uuid.UUID, error) {
+	books := make([]*entity.PromotionBook, 0, len(bookIds))
+	seen := make(map[uuid.UUID]bool, len(bookIds))
+	for _, bookId := range bookIds {
+		if seen[bookId] {
+			continue
+		}
+		seen[bookId] = true
+
+		err := promotionRepo.CreateBook(ctx, promotion.PromotionId, bookId)
+		if err != nil {
+			return nil, bookId, err
+		}
+
+		books = append(books, &entity.PromotionBook{PromotionId: promotion.PromotionId, BookId: bookId})
+	}
+
+	return books, uuid.Nil, nil
+}
+
+// promotionWriteError mengubah error dari transaksi pembuatan atau perubahan promosi menjadi fiber.Error
+func promotionWriteError(err error, failedBookId uuid.UUID, message string) error {
+	if eris.Is(err, types.ErrNoRows) {
+		if failedBookId != uuid.Nil {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("Book %s not found", failedBookId))
+		}
+
+		return fiber.NewError(fiber.StatusNotFound, "Promotion not found")
+	}
+
+	return eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, message), eris.ToString(err, true))
+}
+
+// activePromotionRules mengambil promosi yang berlaku pada waktu at dan mengubahnya menjadi
+// aturan untuk paket pricing
+func activePromotionRules(ctx context.Context, promotionRepo *repository.PromotionRepository, at time.Time) ([]pricing.Rule, error) {
+	promotions, err := promotionRepo.GetActive(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+
+	books, err := promotionRepo.GetActiveBooks(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+
+	booksByPromotion := make(map[uuid.UUID]map[uuid.UUID]bool)
+	for _, book := range books {
+		if booksByPromotion[book.PromotionId] == nil {
+			booksByPromotion[book.PromotionId] = make(map[uuid.UUID]bool)
+		}
+		booksByPromotion[book.PromotionId][book.BookId] = true
+	}
+
+	rules := make([]pricing.Rule, len(promotions))
+	for i, promotion := range promotions {
+		rules[i] = pricing.Rule{
+			ID:             promotion.PromotionId,
+			Name:           promotion.Name,
+			Type:           pricing.RuleType(promotion.RuleType),
+			Percent:        promotion.Percent,
+			BuyQuantity:    promotion.BuyQuantity,
+			FreeQuantity:   promotion.FreeQuantity,
+			BundleQuantity: promotion.BundleQuantity,
+			BundlePrice:    promotion.BundlePrice,
+			MinSubtotal:    promotion.MinSubtotal,
+			Priority:       promotion.Priority,
+			Stackable:      promotion.Stackable,
+			Matches:        promotionMatcher(promotion, booksByPromotion[promotion.PromotionId]),
+		}
+	}
+
+	return rules, nil
+}
+
+// promotionMatcher membuat fungsi pencocokan baris keranjang sesuai scope promosi.
+// Penerbit dan penulis dicocokkan tanpa membedakan huruf besar kecil
+func promotionMatcher(promotion *entity.Promotion, books map[uuid.UUID]bool) func(pricing.Line) bool {
+	switch promotion.Scope {
+	case entity.PromotionScopePublisher:
+		return func(line pricing.Line) bool {
+			return strings.EqualFold(strings.TrimSpace(line.Publisher), promotion.ScopeValue)
+		}
+	case entity.PromotionScopeAuthor:
+		return func(line pricing.Line) bool {
+			return strings.EqualFold(strings.TrimSpace(line.Author), promotion.ScopeValue)
+		}
+	case entity.PromotionScopeBooks:
+		return func(line pricing.Line) bool {
+			return books[line.BookID]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,504 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/repository"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+	"github.com/shopspring/decimal"
+)
+
+// purchaseOrderTransitions berisi perubahan status yang boleh dilakukan secara manual.
+// Status partially_received dan received diatur oleh penerimaan barang
+var purchaseOrderTransitions = map[entity.PurchaseOrderStatus][]entity.PurchaseOrderStatus{
+	entity.PurchaseOrderDraft: {entity.PurchaseOrderSent, entity.PurchaseOrderCancelled},
+	entity.PurchaseOrderSent:  {entity.PurchaseOrderCancelled},
+}
+
+// reorderPageSize adalah jumlah buku stok menipis yang dibaca per halaman saat pemesanan ulang
+const reorderPageSize = 1000
+
+type PurchaseOrderUsecase struct {
+	transactor   *repository.Transactor
+	orderRepo    *repository.PurchaseOrderRepository
+	supplierRepo *repository.SupplierRepository
+	bookRepo     *repository.BookRepository
+	validator    *validator.Validate
+}
+
+func NewPurchaseOrderUsecase(
+	transactor *repository.Transactor,
+	orderRepo *repository.PurchaseOrderRepository,
+	supplierRepo *repository.SupplierRepository,
+	bookRepo *repository.BookRepository,
+	validator *validator.Validate,
+) *PurchaseOrderUsecase {
+	return &PurchaseOrderUsecase{transactor, orderRepo, supplierRepo, bookRepo, validator}
+}
+
+// Create membuat purchase order baru berstatus draft beserta item-itemnya dalam satu transaksi
+func (p PurchaseOrderUsecase) Create(ctx context.Context, userId uuid.UUID, request *model.PurchaseOrderRequest) (model.PurchaseOrderResponse, error) {
+	err := p.validator.Struct(request)
+	if err != nil {
+		return model.PurchaseOrderResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	orderId, err := uuid.NewV7()
+	if err != nil {
+		return model.PurchaseOrderResponse{}, eris.Errorf("Failed to generate purchase order ID: %v", err)
+	}
+
+	items, totalCost, err := newPurchaseOrderItems(orderId, request.Items)
+	if err != nil {
+		return model.PurchaseOrderResponse{}, err
+	}
+
+	order := &entity.PurchaseOrder{
+		PurchaseOrderId: orderId,
+		SupplierId:      request.SupplierID,
+		Status:          entity.PurchaseOrderDraft,
+		Notes:           request.Notes,
+		ExpectedAt:      parseExpectedAt(request.ExpectedAt),
+		TotalCost:       totalCost,
+		CreatedBy:       uuid.NullUUID{UUID: userId, Valid: userId != uuid.Nil},
+	}
+
+	var failedBookId uuid.UUID
+	err = p.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		orderRepo := p.orderRepo.WithTx(tx)
+
+		err = p.checkSupplier(ctx, tx, order.SupplierId)
+		if err != nil {
+			return err
+		}
+
+		order, err = orderRepo.Create(ctx, order)
+		if err != nil {
+			return err
+		}
+
+		failedBookId, err = createPurchaseOrderItems(ctx, orderRepo, items)
+		return err
+	})
+	if err != nil {
+		return model.PurchaseOrderResponse{}, purchaseOrderWriteError(err, failedBookId, "Failed to create purchase order")
+	}
+
+	return model.PurchaseOrderToResponse(order, items), nil
+}
+
+func (p PurchaseOrderUsecase) GetById(ctx context.Context, orderId string) (model.PurchaseOrderResponse, error) {
+	id, err := uuid.Parse(orderId)
+	if err != nil {
+		return model.PurchaseOrderResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid purchase order ID"), err.Error())
+	}
+
+	order, err := p.orderRepo.GetById(ctx, id)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return model.PurchaseOrderResponse{}, fiber.NewError(fiber.StatusNotFound, "Purchase order not found")
+		}
+
+		return model.PurchaseOrderResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get purchase order"), eris.ToString(err, true))
+	}
+
+	items, err := p.orderRepo.GetItemsByPurchaseOrderId(ctx, id)
+	if err != nil {
+		return model.PurchaseOrderResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get purchase order items"), eris.ToString(err, true))
+	}
+
+	return model.PurchaseOrderToResponse(order, items), nil
+}
+
+func (p PurchaseOrderUsecase) GetMany(ctx context.Context, request *model.PurchaseOrderSearchRequest, offset int64, limit int64) ([]model.PurchaseOrderResponse, int64, error) {
+	if limit <= 0 {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Limit must be greater than 0"), "Invalid limit")
+	}
+
+	err := p.validator.Struct(request)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters"), err.Error())
+	}
+
+	var supplierId uuid.NullUUID
+	if request.SupplierID != "" {
+		supplierId = uuid.NullUUID{UUID: uuid.MustParse(request.SupplierID), Valid: true}
+	}
+
+	orders, err := p.orderRepo.GetMany(ctx, request.Status, supplierId, offset, limit)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get purchase orders"), eris.ToString(err, true))
+	}
+
+	total, err := p.orderRepo.GetTotalCount(ctx, request.Status, supplierId)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get total count"), eris.ToString(err, true))
+	}
+
+	ordersResp := make([]model.PurchaseOrderResponse, len(orders))
+	for i, order := range orders {
+		ordersResp[i] = model.PurchaseOrderToResponse(order, nil)
+	}
+
+	return ordersResp, total, nil
+}
+
+// Update mengganti supplier, catatan, tanggal perkiraan dan seluruh item purchase order.
+// Hanya purchase order berstatus draft yang boleh diubah
+func (p PurchaseOrderUsecase) Update(ctx context.Context, orderId string, request *model.PurchaseOrderRequest) (model.PurchaseOrderResponse, error) {
+	id, err := uuid.Parse(orderId)
+	if err != nil {
+		return model.PurchaseOrderResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid purchase order ID"), err.Error())
+	}
+
+	err = p.validator.Struct(request)
+	if err != nil {
+		return model.PurchaseOrderResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	items, totalCost, err := newPurchaseOrderItems(id, request.Items)
+	if err != nil {
+		return model.PurchaseOrderResponse{}, err
+	}
+
+	var (
+		order        *entity.PurchaseOrder
+		failedBookId uuid.UUID
+	)
+	err = p.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		orderRepo := p.orderRepo.WithTx(tx)
+
+		order, err = orderRepo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if order.Status != entity.PurchaseOrderDraft {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Purchase order is %s, only draft purchase orders can be edited", order.Status))
+		}
+
+		err = p.checkSupplier(ctx, tx, request.SupplierID)
+		if err != nil {
+			return err
+		}
+
+		order.SupplierId = request.SupplierID
+		order.Notes = request.Notes
+		order.ExpectedAt = parseExpectedAt(request.ExpectedAt)
+		order.TotalCost = totalCost
+
+		order, err = orderRepo.Update(ctx, order)
+		if err != nil {
+			return err
+		}
+
+		err = orderRepo.DeleteItems(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		failedBookId, err = createPurchaseOrderItems(ctx, orderRepo, items)
+		return err
+	})
+	if err != nil {
+		return model.PurchaseOrderResponse{}, purchaseOrderWriteError(err, failedBookId, "Failed to update purchase order")
+	}
+
+	return model.PurchaseOrderToResponse(order, items), nil
+}
+
+// UpdateStatus mengubah status purchase order secara manual, yaitu mengirim atau membatalkan
+func (p PurchaseOrderUsecase) UpdateStatus(ctx context.Context, orderId string, request *model.UpdatePurchaseOrderStatusRequest) (model.PurchaseOrderResponse, error) {
+	id, err := uuid.Parse(orderId)
+	if err != nil {
+		return model.PurchaseOrderResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid purchase order ID"), err.Error())
+	}
+
+	err = p.validator.Struct(request)
+	if err != nil {
+		return model.PurchaseOrderResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	status := entity.PurchaseOrderStatus(request.Status)
+
+	var order *entity.PurchaseOrder
+	err = p.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		orderRepo := p.orderRepo.WithTx(tx)
+
+		order, err = orderRepo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if !slices.Contains(purchaseOrderTransitions[order.Status], status) {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Cannot change purchase order status from %s to %s", order.Status, status))
+		}
+
+		order, err = orderRepo.UpdateStatus(ctx, id, status)
+		return err
+	})
+	if err != nil {
+		return model.PurchaseOrderResponse{}, purchaseOrderWriteError(err, uuid.Nil, "Failed to update purchase order status")
+	}
+
+	return model.PurchaseOrderToResponse(order, nil), nil
+}
+
+// Delete menghapus purchase order yang masih berstatus draft
+func (p PurchaseOrderUsecase) Delete(ctx context.Context, orderId string) error {
+	id, err := uuid.Parse(orderId)
+	if err != nil {
+		return eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid purchase order ID"), err.Error())
+	}
+
+	err = p.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		orderRepo := p.orderRepo.WithTx(tx)
+
+		order, err := orderRepo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if order.Status != entity.PurchaseOrderDraft {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Purchase order is %s, only draft purchase orders can be deleted", order.Status))
+		}
+
+		return orderRepo.Delete(ctx, id)
+	})
+	if err != nil {
+		return purchaseOrderWriteError(err, uuid.Nil, "Failed to delete purchase order")
+	}
+
+	return nil
+}
+
+// Reorder membuat satu purchase order draft per supplier untuk buku dengan stok menipis.
+// Buku tanpa supplier utama atau yang pesanannya sudah mencukupi dilewati dan dilaporkan.
+// Harga satuan diambil dari pesanan terakhir ke supplier yang sama atau dari harga pokok buku
+func (p PurchaseOrderUsecase) Reorder(ctx context.Context, userId uuid.UUID, request *model.ReorderRequest) (model.ReorderResponse, error) {
+	var supplierId uuid.NullUUID
+	if request.SupplierID != nil {
+		supplierId = uuid.NullUUID{UUID: *request.SupplierID, Valid: true}
+	}
+
+	response := model.ReorderResponse{
+		PurchaseOrders: make([]model.PurchaseOrderResponse, 0),
+		Skipped:        make([]model.ReorderSkippedBook, 0),
+	}
+
+	err := p.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		orderRepo := p.orderRepo.WithTx(tx)
+		supplierRepo := p.supplierRepo.WithTx(tx)
+
+		// seluruh buku stok menipis dibaca per halaman agar tidak ada yang terlewat
+		var books []*entity.LowStockBook
+		for offset := int64(0); ; offset += reorderPageSize {
+			page, err := p.bookRepo.WithTx(tx).GetLowStock(ctx, supplierId, offset, reorderPageSize)
+			if err != nil {
+				return err
+			}
+
+			books = append(books, page...)
+			if len(page) < reorderPageSize {
+				break
+			}
+		}
+
+		var supplierIds []uuid.UUID
+		booksBySupplier := make(map[uuid.UUID][]*entity.LowStockBook)
+		for _, book := range books {
+			switch {
+			case !book.PreferredSupplierId.Valid:
+				response.Skipped = append(response.Skipped, model.ReorderSkippedBook{BookID: book.BookId, Reason: "no preferred supplier"})
+				continue
+			case suggestedReorderQuantity(book) == 0:
+				response.Skipped = append(response.Skipped, model.ReorderSkippedBook{BookID: book.BookId, Reason: "already on order"})
+				continue
+			}
+
+			id := book.PreferredSupplierId.UUID
+			if _, ok := booksBySupplier[id]; !ok {
+				supplierIds = append(supplierIds, id)
+			}
+			booksBySupplier[id] = append(booksBySupplier[id], book)
+		}
+
+		for _, id := range supplierIds {
+			supplier, err := supplierRepo.GetById(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			order, items, err := newReorderPurchaseOrder(ctx, orderRepo, supplier, booksBySupplier[id], userId)
+			if err != nil {
+				return err
+			}
+
+			order, err = orderRepo.Create(ctx, order)
+			if err != nil {
+				return err
+			}
+
+			_, err = createPurchaseOrderItems(ctx, orderRepo, items)
+			if err != nil {
+				return err
+			}
+
+			response.PurchaseOrders = append(response.PurchaseOrders, model.PurchaseOrderToResponse(order, items))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return model.ReorderResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to generate purchase orders"), eris.ToString(err, true))
+	}
+
+	return response, nil
+}
+
+// newReorderPurchaseOrder menyusun purchase order draft untuk satu supplier. Tanggal perkiraan
+// kedatangan dihitung dari lead time supplier
+func newReorderPurchaseOrder(
+	ctx context.Context,
+	orderRepo *repository.PurchaseOrderRepository,
+	supplier *entity.Supplier,
+	books []*entity.LowStockBook,
+	userId uuid.UUID,
+) (*entity.PurchaseOrder, []*entity.PurchaseOrderItem, error) {
+	orderId, err := uuid.NewV7()
+	if err != nil {
+		return nil, nil, eris.Errorf("Failed to generate purchase order ID: %v", err)
+	}
+
+	lines := make([]model.PurchaseOrderItemRequest, len(books))
+	for i, book := range books {
+		unitCost, err := orderRepo.GetLastUnitCost(ctx, book.BookId, supplier.SupplierId)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// buku yang belum pernah dipesan dari supplier ini memakai harga pokok buku
+		if unitCost.IsZero() {
+			unitCost = book.CostPrice
+		}
+
+		lines[i] = model.PurchaseOrderItemRequest{BookID: book.BookId, Quantity: suggestedReorderQuantity(book), UnitCost: unitCost}
+	}
+
+	items, totalCost, err := newPurchaseOrderItems(orderId, lines)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	order := &entity.PurchaseOrder{
+		PurchaseOrderId: orderId,
+		SupplierId:      supplier.SupplierId,
+		Status:          entity.PurchaseOrderDraft,
+		Notes:           "Generated from low stock alerts",
+		TotalCost:       totalCost,
+		CreatedBy:       uuid.NullUUID{UUID: userId, Valid: userId != uuid.Nil},
+	}
+
+	if supplier.LeadTimeDays > 0 {
+		expectedAt := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, int(supplier.LeadTimeDays))
+		order.ExpectedAt = sql.NullTime{Time: expectedAt, Valid: true}
+	}
+
+	return order, items, nil
+}
+
+// checkSupplier memastikan supplier ada sebelum dipakai oleh purchase order
+func (p PurchaseOrderUsecase) checkSupplier(ctx context.Context, tx *sqlx.Tx, supplierId uuid.UUID) error {
+	_, err := p.supplierRepo.WithTx(tx).GetById(ctx, supplierId)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "Supplier not found")
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// newPurchaseOrderItems membuat entity item purchase order dan menghitung total biayanya
+func newPurchaseOrderItems(orderId uuid.UUID, lines []model.PurchaseOrderItemRequest) ([]*entity.PurchaseOrderItem, decimal.Decimal, error) {
+	items := make([]*entity.PurchaseOrderItem, len(lines))
+	totalCost := decimal.Zero
+	seen := make(map[uuid.UUID]bool, len(lines))
+
+	for i, line := range lines {
+		if line.UnitCost.IsNegative() {
+			return nil, decimal.Zero, fiber.NewError(fiber.StatusBadRequest, "Unit cost cannot be negative")
+		}
+
+		if seen[line.BookID] {
+			return nil, decimal.Zero, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Book %s is listed more than once", line.BookID))
+		}
+		seen[line.BookID] = true
+
+		itemId, err := uuid.NewV7()
+		if err != nil {
+			return nil, decimal.Zero, eris.Errorf("Failed to generate purchase order item ID: %v", err)
+		}
+
+		items[i] = &entity.PurchaseOrderItem{
+			PurchaseOrderItemId: itemId,
+			PurchaseOrderId:     orderId,
+			BookId:              line.BookID,
+			QuantityOrdered:     line.Quantity,
+			UnitCost:            line.UnitCost.Round(2),
+		}
+
+		totalCost = totalCost.Add(items[i].UnitCost.Mul(decimal.NewFromInt(line.Quantity)))
+	}
+
+	return items, totalCost.Round(2), nil
+}
+
+// createPurchaseOrderItems menyimpan item purchase order dan mengembalikan book_id yang gagal disimpan
+func createPurchaseOrderItems(ctx context.Context, orderRepo *repository.PurchaseOrderRepository, items []*entity.PurchaseOrderItem) (uuid.UUID, error) {
+	for _, item := range items {
+		_, err := orderRepo.CreateItem(ctx, item)
+		if err != nil {
+			return item.BookId, err
+		}
+	}
+
+	return uuid.Nil, nil
+}
+
+// purchaseOrderWriteError memetakan error dari transaksi purchase order menjadi error HTTP
+func purchaseOrderWriteError(err error, failedBookId uuid.UUID, message string) error {
+	var fe *fiber.Error
+	if eris.As(err, &fe) {
+		return fe
+	}
+
+	if eris.Is(err, types.ErrNoRows) {
+		if failedBookId != uuid.Nil {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("Book %s not found", failedBookId))
+		}
+
+		return fiber.NewError(fiber.StatusNotFound, "Purchase order not found")
+	}
+
+	return eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, message), eris.ToString(err, true))
+}
+
+// parseExpectedAt mengubah tanggal YYYY-MM-DD yang sudah divalidasi menjadi sql.NullTime
+func parseExpectedAt(value string) sql.NullTime {
+	t, err := time.Parse(time.DateOnly, value)
+	return sql.NullTime{Time: t, Valid: err == nil}
+}
@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/repository"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+// ReceivingUsecase mencatat penerimaan barang dari supplier terhadap purchase order
+type ReceivingUsecase struct {
+	transactor   *repository.Transactor
+	orderRepo    *repository.PurchaseOrderRepository
+	bookRepo     *repository.BookRepository
+	movementRepo *repository.StockMovementRepository
+	locationRepo *repository.LocationRepository
+	backorders   *BackorderUsecase
+	validator    *validator.Validate
+}
+
+func NewReceivingUsecase(
+	transactor *repository.Transactor,
+	orderRepo *repository.PurchaseOrderRepository,
+	bookRepo *repository.BookRepository,
+	movementRepo *repository.StockMovementRepository,
+	locationRepo *repository.LocationRepository,
+	backorders *BackorderUsecase,
+	validator *validator.Validate,
+) *ReceivingUsecase {
+	return &ReceivingUsecase{transactor, orderRepo, bookRepo, movementRepo, locationRepo, backorders, validator}
+}
+
+// receiptLine adalah jumlah barang yang diterima untuk satu buku dalam satu pengiriman
+type receiptLine struct {
+	bookId   uuid.UUID
+	quantity int64
+}
+
+// Receive menambah stok buku sesuai barang yang diterima, memperbarui jumlah diterima pada item
+// purchase order dan mengubah status purchase order dalam satu transaksi. Kelebihan maupun
+// kekurangan kiriman tetap dicatat dan terlihat pada quantity_over dan quantity_short. Barang
+// masuk ke lokasi yang diminta atau lokasi default, lalu dialokasikan lebih dulu ke antrean
+// backorder dan preorder di lokasi tersebut sebelum bisa dijual
+func (r ReceivingUsecase) Receive(ctx context.Context, userId uuid.UUID, orderId string, request *model.ReceivePurchaseOrderRequest) (model.ReceivePurchaseOrderResponse, error) {
+	id, err := uuid.Parse(orderId)
+	if err != nil {
+		return model.ReceivePurchaseOrderResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid purchase order ID"), err.Error())
+	}
+
+	err = r.validator.Struct(request)
+	if err != nil {
+		return model.ReceivePurchaseOrderResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	location, err := resolveLocation(ctx, r.locationRepo, request.LocationID)
+	if err != nil {
+		return model.ReceivePurchaseOrderResponse{}, err
+	}
+
+	var (
+		order     *entity.PurchaseOrder
+		items     []*entity.PurchaseOrderItem
+		movements []*entity.StockMovement
+		allocated []*entity.Backorder
+	)
+	err = r.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		orderRepo := r.orderRepo.WithTx(tx)
+		bookRepo := r.bookRepo.WithTx(tx)
+		movementRepo := r.movementRepo.WithTx(tx)
+
+		order, err = orderRepo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if order.Status != entity.PurchaseOrderSent && order.Status != entity.PurchaseOrderPartiallyReceived {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Purchase order is %s, only sent or partially received purchase orders can receive goods", order.Status))
+		}
+
+		lines, err := r.resolveReceiptLines(ctx, bookRepo, request.Items)
+		if err != nil {
+			return err
+		}
+
+		items, err = orderRepo.GetItemsByPurchaseOrderId(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		itemByBook := make(map[uuid.UUID]*entity.PurchaseOrderItem, len(items))
+		for _, item := range items {
+			itemByBook[item.BookId] = item
+		}
+
+		// stok ditambah berurutan berdasarkan book_id agar penerimaan tidak saling menunggu
+		// (deadlock) dengan penjualan atau transfer yang mengubah buku yang sama
+		slices.SortFunc(lines, func(a, b receiptLine) int {
+			return bytes.Compare(a.bookId[:], b.bookId[:])
+		})
+
+		for _, line := range lines {
+			item, ok := itemByBook[line.bookId]
+			if !ok {
+				return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Book %s is not on this purchase order", line.bookId))
+			}
+
+			updated, err := orderRepo.UpdateItemReceived(ctx, item.PurchaseOrderItemId, item.QuantityReceived+line.quantity)
+			if err != nil {
+				return err
+			}
+			*item = *updated
+
+			reason := "purchase order receipt"
+			if request.Reference != "" {
+				reason += " " + request.Reference
+			}
+
+			movement, err := newStockMovement(line.bookId, location.LocationId, entity.StockMovementReceipt, line.quantity, reason, id.String(), userId)
+			if err != nil {
+				return err
+			}
+
+			_, err = applyStockMovement(ctx, bookRepo, movementRepo, movement)
+			if err != nil {
+				return err
+			}
+			movements = append(movements, movement)
+		}
+
+		order, err = orderRepo.UpdateStatus(ctx, id, receivedStatus(items))
+		if err != nil {
+			return err
+		}
+
+		bookIds := make([]uuid.UUID, len(lines))
+		for i, line := range lines {
+			bookIds[i] = line.bookId
+		}
+
+		allocated, err = r.backorders.allocate(ctx, tx, location.LocationId, bookIds, userId)
+		return err
+	})
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return model.ReceivePurchaseOrderResponse{}, fe
+		}
+
+		if eris.Is(err, types.ErrNoRows) {
+			return model.ReceivePurchaseOrderResponse{}, fiber.NewError(fiber.StatusNotFound, "Purchase order not found")
+		}
+
+		return model.ReceivePurchaseOrderResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to receive purchase order"), eris.ToString(err, true))
+	}
+
+	response := model.ReceivePurchaseOrderResponse{
+		PurchaseOrder: model.PurchaseOrderToResponse(order, items),
+		Movements:     make([]model.StockMovementResponse, len(movements)),
+	}
+	for i, movement := range movements {
+		response.Movements[i] = model.StockMovementToResponse(movement)
+	}
+	if len(allocated) > 0 {
+		response.Allocations = model.BackordersToResponse(allocated)
+	}
+
+	return response, nil
+}
+
+// resolveReceiptLines mencari buku dari setiap kode hasil pindaian lalu menjumlahkan kuantitas
+// buku yang sama, urutan buku mengikuti pindaian pertamanya. Kuantitas kosong dihitung satu
+func (r ReceivingUsecase) resolveReceiptLines(ctx context.Context, bookRepo *repository.BookRepository, scans []model.ReceiptItemRequest) ([]receiptLine, error) {
+	var lines []receiptLine
+	index := make(map[uuid.UUID]int, len(scans))
+
+	for _, scan := range scans {
+		codeType, value := detectScanCode(scan.Code)
+		if codeType == "" {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Unrecognized code %q, expected a book ID, book QR code or an ISBN barcode", scan.Code))
+		}
+
+		book, err := getBookByScanCode(ctx, bookRepo, codeType, value)
+		if err != nil {
+			if eris.Is(err, types.ErrNoRows) {
+				return nil, fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("Book with code %q not found", scan.Code))
+			}
+
+			return nil, err
+		}
+
+		quantity := scan.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+
+		if i, ok := index[book.BookId]; ok {
+			lines[i].quantity += quantity
+			continue
+		}
+
+		index[book.BookId] = len(lines)
+		lines = append(lines, receiptLine{bookId: book.BookId, quantity: quantity})
+	}
+
+	return lines, nil
+}
+
+// receivedStatus menentukan status purchase order dari jumlah barang yang sudah diterima
+func receivedStatus(items []*entity.PurchaseOrderItem) entity.PurchaseOrderStatus {
+	for _, item := range items {
+		if item.QuantityReceived < item.QuantityOrdered {
+			return entity.PurchaseOrderPartiallyReceived
+		}
+	}
+
+	return entity.PurchaseOrderReceived
+}
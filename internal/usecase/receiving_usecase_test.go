@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+)
+
+func TestReceivedStatus(t *testing.T) {
+	item := func(ordered, received int64) *entity.PurchaseOrderItem {
+		return &entity.PurchaseOrderItem{QuantityOrdered: ordered, QuantityReceived: received}
+	}
+
+	tests := []struct {
+		name  string
+		items []*entity.PurchaseOrderItem
+		want  entity.PurchaseOrderStatus
+	}{
+		{name: "all lines complete", items: []*entity.PurchaseOrderItem{item(10, 10), item(3, 3)}, want: entity.PurchaseOrderReceived},
+		{name: "one line short", items: []*entity.PurchaseOrderItem{item(10, 10), item(3, 2)}, want: entity.PurchaseOrderPartiallyReceived},
+		{name: "nothing received yet", items: []*entity.PurchaseOrderItem{item(5, 0)}, want: entity.PurchaseOrderPartiallyReceived},
+		{name: "over received counts as complete", items: []*entity.PurchaseOrderItem{item(5, 6)}, want: entity.PurchaseOrderReceived},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := receivedStatus(tt.items); got != tt.want {
+				t.Errorf("receivedStatus() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,501 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/config"
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/repository"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+// jumlah reservasi kedaluwarsa yang dilepas dalam satu transaksi
+const reservationExpiryBatchSize = 100
+
+// ReservationUsecase menahan stok untuk pelanggan. Reservasi aktif menambah reserved pada buku
+// di lokasinya sehingga stok tersebut tidak bisa dijual ke orang lain, tanpa mengubah stok fisik.
+// Reservasi selesai saat dibeli, dibatalkan, atau dilepas otomatis setelah expires_at
+type ReservationUsecase struct {
+	transactor      *repository.Transactor
+	reservationRepo *repository.ReservationRepository
+	backorderRepo   *repository.BackorderRepository
+	bookRepo        *repository.BookRepository
+	locationRepo    *repository.LocationRepository
+	saleUsecase     *SaleUsecase
+	backorders      *BackorderUsecase
+	cfg             *config.Config
+	validator       *validator.Validate
+}
+
+func NewReservationUsecase(
+	transactor *repository.Transactor,
+	reservationRepo *repository.ReservationRepository,
+	backorderRepo *repository.BackorderRepository,
+	bookRepo *repository.BookRepository,
+	locationRepo *repository.LocationRepository,
+	saleUsecase *SaleUsecase,
+	backorders *BackorderUsecase,
+	cfg *config.Config,
+	validator *validator.Validate,
+) *ReservationUsecase {
+	return &ReservationUsecase{transactor, reservationRepo, backorderRepo, bookRepo, locationRepo, saleUsecase, backorders, cfg, validator}
+}
+
+// Create menahan stok setiap buku di lokasi reservasi. Reservasi ditolak jika stok lokasi yang
+// belum ditahan tidak mencukupi untuk salah satu buku
+func (r ReservationUsecase) Create(ctx context.Context, userId uuid.UUID, request *model.CreateReservationRequest) (model.ReservationResponse, error) {
+	err := r.validator.Struct(request)
+	if err != nil {
+		return model.ReservationResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	expiresAt := time.Now().Add(reservationTTL(r.cfg))
+	if request.ExpiresAt != nil {
+		if !request.ExpiresAt.After(time.Now()) {
+			return model.ReservationResponse{}, fiber.NewError(fiber.StatusBadRequest, "Expiry time must be in the future")
+		}
+		expiresAt = *request.ExpiresAt
+	}
+
+	location, err := resolveLocation(ctx, r.locationRepo, request.LocationID)
+	if err != nil {
+		return model.ReservationResponse{}, err
+	}
+
+	reservationId, err := uuid.NewV7()
+	if err != nil {
+		return model.ReservationResponse{}, eris.Errorf("Failed to generate reservation ID: %v", err)
+	}
+
+	items, err := newReservationItems(reservationId, request.Items)
+	if err != nil {
+		return model.ReservationResponse{}, err
+	}
+
+	reservation := &entity.Reservation{
+		ReservationId:   reservationId,
+		LocationId:      location.LocationId,
+		Status:          entity.ReservationActive,
+		Channel:         entity.ReservationChannel(request.Channel),
+		CustomerName:    request.CustomerName,
+		CustomerContact: request.CustomerContact,
+		Notes:           request.Notes,
+		ExpiresAt:       expiresAt,
+		CreatedBy:       uuid.NullUUID{UUID: userId, Valid: userId != uuid.Nil},
+	}
+
+	// stok ditahan berurutan berdasarkan book_id agar tidak deadlock dengan penjualan
+	ordered := slices.Clone(items)
+	slices.SortStableFunc(ordered, func(a, b *entity.ReservationItem) int {
+		return bytes.Compare(a.BookId[:], b.BookId[:])
+	})
+
+	var failedBookId uuid.UUID
+	err = r.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		reservationRepo := r.reservationRepo.WithTx(tx)
+		bookRepo := r.bookRepo.WithTx(tx)
+
+		reservation, err = reservationRepo.Create(ctx, reservation)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range ordered {
+			failedBookId = item.BookId
+			_, err = bookRepo.Reserve(ctx, item.BookId, reservation.LocationId, item.Quantity)
+			if err != nil {
+				return err
+			}
+
+			_, err = reservationRepo.CreateItem(ctx, item)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return model.ReservationResponse{}, reservationWriteError(err, failedBookId, "Failed to create reservation")
+	}
+
+	return model.ReservationToResponse(reservation, items), nil
+}
+
+func (r ReservationUsecase) GetById(ctx context.Context, reservationId string) (model.ReservationResponse, error) {
+	id, err := uuid.Parse(reservationId)
+	if err != nil {
+		return model.ReservationResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid reservation ID"), err.Error())
+	}
+
+	reservation, err := r.reservationRepo.GetById(ctx, id)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return model.ReservationResponse{}, fiber.NewError(fiber.StatusNotFound, "Reservation not found")
+		}
+
+		return model.ReservationResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get reservation"), eris.ToString(err, true))
+	}
+
+	items, err := r.reservationRepo.GetItemsByReservationId(ctx, id)
+	if err != nil {
+		return model.ReservationResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get reservation items"), eris.ToString(err, true))
+	}
+
+	return model.ReservationToResponse(reservation, items), nil
+}
+
+func (r ReservationUsecase) GetMany(ctx context.Context, request *model.ReservationSearchRequest, offset int64, limit int64) ([]model.ReservationResponse, int64, error) {
+	if limit <= 0 {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Limit must be greater than 0"), "Invalid limit")
+	}
+
+	err := r.validator.Struct(request)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters"), err.Error())
+	}
+
+	var locationId, bookId uuid.NullUUID
+	if request.LocationID != "" {
+		locationId = uuid.NullUUID{UUID: uuid.MustParse(request.LocationID), Valid: true}
+	}
+	if request.BookID != "" {
+		bookId = uuid.NullUUID{UUID: uuid.MustParse(request.BookID), Valid: true}
+	}
+
+	reservations, err := r.reservationRepo.GetMany(ctx, request.Status, locationId, bookId, offset, limit)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get reservations"), eris.ToString(err, true))
+	}
+
+	total, err := r.reservationRepo.GetTotalCount(ctx, request.Status, locationId, bookId)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get total count"), eris.ToString(err, true))
+	}
+
+	reservationsResp := make([]model.ReservationResponse, len(reservations))
+	for i, reservation := range reservations {
+		reservationsResp[i] = model.ReservationToResponse(reservation, nil)
+	}
+
+	return reservationsResp, total, nil
+}
+
+// Cancel membatalkan reservasi aktif dan melepas stok yang ditahan ke antrean backorder berikutnya.
+// Backorder yang stoknya ditahan oleh reservasi ini ikut dibatalkan
+func (r ReservationUsecase) Cancel(ctx context.Context, reservationId string) (model.ReservationResponse, error) {
+	id, err := uuid.Parse(reservationId)
+	if err != nil {
+		return model.ReservationResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid reservation ID"), err.Error())
+	}
+
+	var (
+		reservation *entity.Reservation
+		items       []*entity.ReservationItem
+	)
+	err = r.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		reservationRepo := r.reservationRepo.WithTx(tx)
+
+		reservation, err = reservationRepo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if reservation.Status != entity.ReservationActive {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Reservation is %s, only active reservations can be cancelled", reservation.Status))
+		}
+
+		items, err = reservationRepo.GetItemsByReservationId(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = releaseReservationItems(ctx, r.bookRepo.WithTx(tx), reservation.LocationId, items)
+		if err != nil {
+			return err
+		}
+
+		if reservation.Channel == entity.ReservationBackorder {
+			err = r.backorderRepo.WithTx(tx).CancelByReservation(ctx, id)
+			if err != nil {
+				return err
+			}
+		}
+
+		reservation, err = reservationRepo.SetStatus(ctx, id, entity.ReservationCancelled)
+		if err != nil {
+			return err
+		}
+
+		// stok yang dilepas diberikan dulu ke antrean backorder sebelum bisa dijual lagi
+		bookIds := make([]uuid.UUID, len(items))
+		for i, item := range items {
+			bookIds[i] = item.BookId
+		}
+
+		_, err = r.backorders.allocate(ctx, tx, reservation.LocationId, bookIds, uuid.Nil)
+		return err
+	})
+	if err != nil {
+		return model.ReservationResponse{}, reservationWriteError(err, uuid.Nil, "Failed to cancel reservation")
+	}
+
+	return model.ReservationToResponse(reservation, items), nil
+}
+
+// CreateSale mengubah reservasi aktif menjadi penjualan di lokasi reservasi. Stok yang ditahan
+// dilepas lalu langsung dijual di dalam transaksi penjualan yang sama, sehingga buku tidak
+// sempat terjual ke pelanggan lain
+func (r ReservationUsecase) CreateSale(ctx context.Context, cashierId uuid.UUID, reservationId string, request *model.ReservationSaleRequest) (model.ReservationSaleResponse, error) {
+	id, err := uuid.Parse(reservationId)
+	if err != nil {
+		return model.ReservationSaleResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid reservation ID"), err.Error())
+	}
+
+	err = r.validator.Struct(request)
+	if err != nil {
+		return model.ReservationSaleResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	current, err := r.GetById(ctx, reservationId)
+	if err != nil {
+		return model.ReservationSaleResponse{}, err
+	}
+
+	if current.Status != string(entity.ReservationActive) {
+		return model.ReservationSaleResponse{}, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Reservation is %s, only active reservations can be sold", current.Status))
+	}
+
+	saleRequest := &model.CreateSaleRequest{
+		PaymentMethod: request.PaymentMethod,
+		AmountPaid:    request.AmountPaid,
+		Notes:         request.Notes,
+		Items:         make([]model.SaleItemRequest, len(current.Items)),
+		LocationID:    &current.LocationID,
+	}
+	for i, item := range current.Items {
+		saleRequest.Items[i] = model.SaleItemRequest{BookID: item.BookID, Quantity: item.Quantity}
+	}
+
+	var (
+		reservation *entity.Reservation
+		items       []*entity.ReservationItem
+	)
+	sale, err := r.saleUsecase.create(ctx, cashierId, saleRequest, func(tx *sqlx.Tx, saleId uuid.UUID) error {
+		reservationRepo := r.reservationRepo.WithTx(tx)
+
+		reservation, err = reservationRepo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return reservationWriteError(err, uuid.Nil, "Failed to get reservation")
+		}
+
+		if reservation.Status != entity.ReservationActive {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Reservation is %s, only active reservations can be sold", reservation.Status))
+		}
+
+		// reservasi yang sudah lewat waktunya tetapi belum dilepas worker tidak boleh dipakai lagi
+		if !reservation.ExpiresAt.After(time.Now()) {
+			return fiber.NewError(fiber.StatusConflict, "Reservation has expired")
+		}
+
+		items, err = reservationRepo.GetItemsByReservationId(ctx, id)
+		if err != nil {
+			return reservationWriteError(err, uuid.Nil, "Failed to get reservation items")
+		}
+
+		err = releaseReservationItems(ctx, r.bookRepo.WithTx(tx), reservation.LocationId, items)
+		if err != nil {
+			return reservationWriteError(err, uuid.Nil, "Failed to release reservation")
+		}
+
+		reservation, err = reservationRepo.Fulfill(ctx, id, saleId)
+		if err != nil {
+			return reservationWriteError(err, uuid.Nil, "Failed to update reservation")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return model.ReservationSaleResponse{}, err
+	}
+
+	return model.ReservationSaleResponse{
+		Reservation: model.ReservationToResponse(reservation, items),
+		Sale:        sale,
+	}, nil
+}
+
+// ExpireDue melepas semua reservasi aktif yang sudah melewati expires_at dan mengembalikan
+// jumlahnya. Backorder yang stoknya ditahan reservasi tersebut dibatalkan, lalu stok yang dilepas
+// dialokasikan ke antrean backorder berikutnya dalam transaksi yang sama. Dipanggil berkala oleh
+// worker reservasi
+func (r ReservationUsecase) ExpireDue(ctx context.Context) (int, error) {
+	expired := 0
+	for {
+		var batch []*entity.Reservation
+		err := r.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+			reservationRepo := r.reservationRepo.WithTx(tx)
+			backorderRepo := r.backorderRepo.WithTx(tx)
+			bookRepo := r.bookRepo.WithTx(tx)
+
+			var err error
+			batch, err = reservationRepo.GetExpiredForUpdate(ctx, reservationExpiryBatchSize)
+			if err != nil {
+				return err
+			}
+
+			locations := make(map[uuid.UUID]uuid.UUID, len(batch))
+			var items []*entity.ReservationItem
+			for _, reservation := range batch {
+				locations[reservation.ReservationId] = reservation.LocationId
+
+				reservationItems, err := reservationRepo.GetItemsByReservationId(ctx, reservation.ReservationId)
+				if err != nil {
+					return err
+				}
+				items = append(items, reservationItems...)
+
+				_, err = reservationRepo.SetStatus(ctx, reservation.ReservationId, entity.ReservationExpired)
+				if err != nil {
+					return err
+				}
+			}
+
+			// stok seluruh batch dilepas berurutan berdasarkan book_id agar tidak deadlock dengan penjualan
+			slices.SortStableFunc(items, func(a, b *entity.ReservationItem) int {
+				return bytes.Compare(a.BookId[:], b.BookId[:])
+			})
+			for _, item := range items {
+				_, err = bookRepo.Reserve(ctx, item.BookId, locations[item.ReservationId], -item.Quantity)
+				if err != nil {
+					return err
+				}
+			}
+
+			// pelanggan yang tidak mengambil backorder-nya sampai reservasi habis dianggap batal
+			for _, reservation := range batch {
+				if reservation.Channel != entity.ReservationBackorder {
+					continue
+				}
+
+				err = backorderRepo.CancelByReservation(ctx, reservation.ReservationId)
+				if err != nil {
+					return err
+				}
+			}
+
+			// stok yang dilepas langsung dialokasikan ke antrean backorder sebelum bisa dijual lagi
+			released := make(map[uuid.UUID][]uuid.UUID)
+			for _, item := range items {
+				locationId := locations[item.ReservationId]
+				released[locationId] = append(released[locationId], item.BookId)
+			}
+
+			locationIds := slices.SortedFunc(maps.Keys(released), func(a, b uuid.UUID) int {
+				return bytes.Compare(a[:], b[:])
+			})
+			for _, locationId := range locationIds {
+				_, err = r.backorders.allocate(ctx, tx, locationId, released[locationId], uuid.Nil)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return expired, eris.Wrap(err, "failed to expire reservations")
+		}
+
+		expired += len(batch)
+		if len(batch) < reservationExpiryBatchSize {
+			return expired, nil
+		}
+	}
+}
+
+// reservationTTL mengembalikan lama reservasi ditahan dari konfigurasi RESERVATION_TTL
+func reservationTTL(cfg *config.Config) time.Duration {
+	if cfg.RESERVATION_TTL > 0 {
+		return cfg.RESERVATION_TTL
+	}
+
+	return config.DEFAULT_RESERVATION_TTL
+}
+
+// releaseReservationItems melepas stok yang ditahan item reservasi. items harus urut berdasarkan
+// book_id seperti hasil GetItemsByReservationId
+func releaseReservationItems(ctx context.Context, bookRepo *repository.BookRepository, locationId uuid.UUID, items []*entity.ReservationItem) error {
+	for _, item := range items {
+		_, err := bookRepo.Reserve(ctx, item.BookId, locationId, -item.Quantity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newReservationItems membuat entity item reservasi. Setiap buku hanya boleh muncul satu kali
+func newReservationItems(reservationId uuid.UUID, lines []model.ReservationItemRequest) ([]*entity.ReservationItem, error) {
+	items := make([]*entity.ReservationItem, len(lines))
+	seen := make(map[uuid.UUID]bool, len(lines))
+
+	for i, line := range lines {
+		if seen[line.BookID] {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Book %s is listed more than once", line.BookID))
+		}
+		seen[line.BookID] = true
+
+		itemId, err := uuid.NewV7()
+		if err != nil {
+			return nil, eris.Errorf("Failed to generate reservation item ID: %v", err)
+		}
+
+		items[i] = &entity.ReservationItem{
+			ReservationItemId: itemId,
+			ReservationId:     reservationId,
+			BookId:            line.BookID,
+			Quantity:          line.Quantity,
+		}
+	}
+
+	return items, nil
+}
+
+// reservationWriteError memetakan error dari transaksi reservasi menjadi error HTTP
+func reservationWriteError(err error, failedBookId uuid.UUID, message string) error {
+	var fe *fiber.Error
+	if eris.As(err, &fe) {
+		return fe
+	}
+
+	if eris.Is(err, types.ErrInsufficientStock) {
+		return eris.Wrap(fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Insufficient available stock for book %s at the reservation location", failedBookId)), err.Error())
+	}
+
+	if (eris.Is(err, types.ErrNoRows) || eris.Is(err, types.ErrMissingReference)) && failedBookId != uuid.Nil {
+		return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("Book %s not found", failedBookId))
+	}
+
+	if eris.Is(err, types.ErrMissingReference) {
+		return fiber.NewError(fiber.StatusNotFound, "Location not found")
+	}
+
+	if eris.Is(err, types.ErrNoRows) {
+		return fiber.NewError(fiber.StatusNotFound, "Reservation not found")
+	}
+
+	return eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, message), eris.ToString(err, true))
+}
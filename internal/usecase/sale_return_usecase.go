@@ -0,0 +1,308 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/repository"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+	"github.com/shopspring/decimal"
+)
+
+type SaleReturnUsecase struct {
+	transactor   *repository.Transactor
+	saleRepo     *repository.SaleRepository
+	returnRepo   *repository.SaleReturnRepository
+	bookRepo     *repository.BookRepository
+	movementRepo *repository.StockMovementRepository
+	locationRepo *repository.LocationRepository
+	backorders   *BackorderUsecase
+	validator    *validator.Validate
+}
+
+func NewSaleReturnUsecase(
+	transactor *repository.Transactor,
+	saleRepo *repository.SaleRepository,
+	returnRepo *repository.SaleReturnRepository,
+	bookRepo *repository.BookRepository,
+	movementRepo *repository.StockMovementRepository,
+	locationRepo *repository.LocationRepository,
+	backorders *BackorderUsecase,
+	validator *validator.Validate,
+) *SaleReturnUsecase {
+	return &SaleReturnUsecase{transactor, saleRepo, returnRepo, bookRepo, movementRepo, locationRepo, backorders, validator}
+}
+
+// Create mencatat retur barang dari sebuah penjualan dalam satu transaksi. Barang yang layak jual
+// dikembalikan ke stok, sedangkan barang rusak dicatat sebagai retur lalu langsung dikeluarkan
+// sebagai kerusakan di lokasi yang diminta atau lokasi default. Jumlah retur setiap item tidak boleh
+// melebihi sisa barang yang belum diretur. Barang layak jual dialokasikan lebih dulu ke antrean
+// backorder di lokasi tersebut
+func (s SaleReturnUsecase) Create(ctx context.Context, userId uuid.UUID, saleId string, request *model.CreateSaleReturnRequest) (model.SaleReturnResponse, error) {
+	id, err := uuid.Parse(saleId)
+	if err != nil {
+		return model.SaleReturnResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID"), err.Error())
+	}
+
+	err = s.validator.Struct(request)
+	if err != nil {
+		return model.SaleReturnResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	location, err := resolveLocation(ctx, s.locationRepo, request.LocationID)
+	if err != nil {
+		return model.SaleReturnResponse{}, err
+	}
+
+	returnId, err := uuid.NewV7()
+	if err != nil {
+		return model.SaleReturnResponse{}, eris.Errorf("Failed to generate sale return ID: %v", err)
+	}
+
+	saleReturn := &entity.SaleReturn{
+		SaleReturnId: returnId,
+		SaleId:       id,
+		Reason:       request.Reason,
+		CreatedBy:    uuid.NullUUID{UUID: userId, Valid: userId != uuid.Nil},
+	}
+
+	var (
+		items     []*entity.SaleReturnItem
+		allocated []*entity.Backorder
+	)
+	err = s.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		saleRepo := s.saleRepo.WithTx(tx)
+		returnRepo := s.returnRepo.WithTx(tx)
+		bookRepo := s.bookRepo.WithTx(tx)
+		movementRepo := s.movementRepo.WithTx(tx)
+
+		// penjualan dikunci agar dua retur untuk penjualan yang sama tidak melebihi jumlah terjual
+		_, err := saleRepo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		saleItems, err := saleRepo.GetItemsBySaleId(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		returned, err := returnRepo.GetReturnedQuantities(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		items, err = newSaleReturnItems(returnId, saleItems, returned, request.Items)
+		if err != nil {
+			return err
+		}
+
+		saleReturn.RefundTotal = decimal.Zero
+		for _, item := range items {
+			saleReturn.RefundTotal = saleReturn.RefundTotal.Add(item.RefundAmount)
+		}
+
+		saleReturn, err = returnRepo.Create(ctx, saleReturn)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			_, err = returnRepo.CreateItem(ctx, item)
+			if err != nil {
+				return err
+			}
+		}
+
+		// stok ditambah berurutan berdasarkan book_id agar tidak deadlock dengan transaksi lain
+		ordered := slices.Clone(items)
+		slices.SortStableFunc(ordered, func(a, b *entity.SaleReturnItem) int {
+			return bytes.Compare(a.BookId.UUID[:], b.BookId.UUID[:])
+		})
+
+		var bookIds []uuid.UUID
+		for _, item := range ordered {
+			err = restockReturnItem(ctx, bookRepo, movementRepo, item, location.LocationId, userId)
+			if err != nil {
+				return err
+			}
+
+			if item.BookId.Valid && item.Condition != entity.ReturnDamaged {
+				bookIds = append(bookIds, item.BookId.UUID)
+			}
+		}
+
+		allocated, err = s.backorders.allocate(ctx, tx, location.LocationId, bookIds, userId)
+		return err
+	})
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return model.SaleReturnResponse{}, fe
+		}
+
+		if eris.Is(err, types.ErrNoRows) {
+			return model.SaleReturnResponse{}, fiber.NewError(fiber.StatusNotFound, "Sale not found")
+		}
+
+		return model.SaleReturnResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to create sale return"), eris.ToString(err, true))
+	}
+
+	response := model.SaleReturnToResponse(saleReturn, items)
+	if len(allocated) > 0 {
+		response.Allocations = model.BackordersToResponse(allocated)
+	}
+
+	return response, nil
+}
+
+// GetBySaleId mengambil semua retur dari sebuah penjualan beserta item-itemnya
+func (s SaleReturnUsecase) GetBySaleId(ctx context.Context, saleId string) ([]model.SaleReturnResponse, error) {
+	id, err := uuid.Parse(saleId)
+	if err != nil {
+		return nil, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID"), err.Error())
+	}
+
+	_, err = s.saleRepo.GetById(ctx, id)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Sale not found")
+		}
+
+		return nil, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get sale"), eris.ToString(err, true))
+	}
+
+	returns, err := s.returnRepo.GetBySaleId(ctx, id)
+	if err != nil {
+		return nil, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get sale returns"), eris.ToString(err, true))
+	}
+
+	items, err := s.returnRepo.GetItemsBySaleId(ctx, id)
+	if err != nil {
+		return nil, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get sale return items"), eris.ToString(err, true))
+	}
+
+	itemsByReturn := make(map[uuid.UUID][]*entity.SaleReturnItem, len(returns))
+	for _, item := range items {
+		itemsByReturn[item.SaleReturnId] = append(itemsByReturn[item.SaleReturnId], item)
+	}
+
+	returnsResp := make([]model.SaleReturnResponse, len(returns))
+	for i, saleReturn := range returns {
+		returnsResp[i] = model.SaleReturnToResponse(saleReturn, itemsByReturn[saleReturn.SaleReturnId])
+	}
+
+	return returnsResp, nil
+}
+
+// newSaleReturnItems membuat entity item retur dan memastikan jumlah retur setiap item penjualan,
+// termasuk retur sebelumnya, tidak melebihi jumlah yang terjual
+func newSaleReturnItems(
+	returnId uuid.UUID,
+	saleItems []*entity.SaleItem,
+	returned map[uuid.UUID]int64,
+	lines []model.SaleReturnItemRequest,
+) ([]*entity.SaleReturnItem, error) {
+	saleItemsById := make(map[uuid.UUID]*entity.SaleItem, len(saleItems))
+	for _, item := range saleItems {
+		saleItemsById[item.SaleItemId] = item
+	}
+
+	items := make([]*entity.SaleReturnItem, len(lines))
+	for i, line := range lines {
+		saleItem, ok := saleItemsById[line.SaleItemID]
+		if !ok {
+			return nil, fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("Sale item %s not found in this sale", line.SaleItemID))
+		}
+
+		previous := returned[saleItem.SaleItemId]
+		if previous+line.Quantity > saleItem.Quantity {
+			return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf(
+				"Cannot return %d of sale item %s, only %d of %d sold can still be returned",
+				line.Quantity, saleItem.SaleItemId, saleItem.Quantity-previous, saleItem.Quantity,
+			))
+		}
+		returned[saleItem.SaleItemId] = previous + line.Quantity
+
+		itemId, err := uuid.NewV7()
+		if err != nil {
+			return nil, eris.Errorf("Failed to generate sale return item ID: %v", err)
+		}
+
+		condition := entity.ReturnCondition(line.Condition)
+		if condition == "" {
+			condition = entity.ReturnResellable
+		}
+
+		items[i] = &entity.SaleReturnItem{
+			SaleReturnItemId: itemId,
+			SaleReturnId:     returnId,
+			SaleItemId:       saleItem.SaleItemId,
+			BookId:           saleItem.BookId,
+			Quantity:         line.Quantity,
+			Condition:        condition,
+			RefundAmount:     refundAmount(saleItem, previous, line.Quantity),
+		}
+	}
+
+	return items, nil
+}
+
+// refundAmount menghitung refund untuk quantity barang dari item penjualan yang sebelumnya sudah
+// diretur sebanyak returned. Nilainya diambil dari line_total sehingga diskon ikut diperhitungkan.
+// Pembulatan dilakukan secara kumulatif agar total refund seluruh barang sama dengan line_total
+func refundAmount(item *entity.SaleItem, returned int64, quantity int64) decimal.Decimal {
+	share := func(n int64) decimal.Decimal {
+		return item.LineTotal.Mul(decimal.NewFromInt(n)).Div(decimal.NewFromInt(item.Quantity)).Round(2)
+	}
+
+	return share(returned + quantity).Sub(share(returned))
+}
+
+// restockReturnItem mengembalikan barang retur ke stok di locationId. Barang rusak langsung dikeluarkan lagi
+// dengan pergerakan damage sehingga stok yang bisa dijual tidak bertambah. Buku yang sudah
+// dihapus dari katalog dilewati karena tidak ada stok yang bisa diubah
+func restockReturnItem(
+	ctx context.Context,
+	bookRepo *repository.BookRepository,
+	movementRepo *repository.StockMovementRepository,
+	item *entity.SaleReturnItem,
+	locationId uuid.UUID,
+	userId uuid.UUID,
+) error {
+	if !item.BookId.Valid {
+		return nil
+	}
+
+	reference := item.SaleReturnId.String()
+	movement, err := newStockMovement(item.BookId.UUID, locationId, entity.StockMovementReturn, item.Quantity, "sale return", reference, userId)
+	if err != nil {
+		return err
+	}
+
+	_, err = applyStockMovement(ctx, bookRepo, movementRepo, movement)
+	if err != nil {
+		return err
+	}
+
+	if item.Condition != entity.ReturnDamaged {
+		return nil
+	}
+
+	movement, err = newStockMovement(item.BookId.UUID, locationId, entity.StockMovementDamage, -item.Quantity, "damaged sale return", reference, userId)
+	if err != nil {
+		return err
+	}
+
+	_, err = applyStockMovement(ctx, bookRepo, movementRepo, movement)
+	return err
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/shopspring/decimal"
+)
+
+func TestRefundAmount(t *testing.T) {
+	tests := []struct {
+		name      string
+		lineTotal string
+		quantity  int64
+		returned  int64
+		returning int64
+		want      string
+	}{
+		{name: "whole line", lineTotal: "178000", quantity: 2, returning: 2, want: "178000"},
+		{name: "one of two", lineTotal: "178000", quantity: 2, returning: 1, want: "89000"},
+		{name: "discount included in share", lineTotal: "160200", quantity: 2, returning: 1, want: "80100"},
+		{name: "first third rounds down", lineTotal: "100", quantity: 3, returning: 1, want: "33.33"},
+		{name: "second third takes rounding", lineTotal: "100", quantity: 3, returned: 1, returning: 1, want: "33.34"},
+		{name: "last third", lineTotal: "100", quantity: 3, returned: 2, returning: 1, want: "33.33"},
+		{name: "free item", lineTotal: "0", quantity: 1, returning: 1, want: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &entity.SaleItem{Quantity: tt.quantity, LineTotal: decimal.RequireFromString(tt.lineTotal)}
+
+			got := refundAmount(item, tt.returned, tt.returning)
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("refundAmount(%s, %d, %d) = %s, want %s", tt.lineTotal, tt.returned, tt.returning, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefundAmountSumsToLineTotal(t *testing.T) {
+	item := &entity.SaleItem{Quantity: 7, LineTotal: decimal.RequireFromString("100.01")}
+
+	sum := decimal.Zero
+	for returned := int64(0); returned < item.Quantity; returned++ {
+		sum = sum.Add(refundAmount(item, returned, 1))
+	}
+
+	if !sum.Equal(item.LineTotal) {
+		t.Errorf("sum of single item refunds = %s, want line total %s", sum, item.LineTotal)
+	}
+}
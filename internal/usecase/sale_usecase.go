@@ -0,0 +1,459 @@
+package usecase
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/config"
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/pricing"
+	"github.com/crazydw4rf/book-stock-manager/internal/receipt"
+	"github.com/crazydw4rf/book-stock-manager/internal/repository"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+	"github.com/shopspring/decimal"
+)
+
+type SaleUsecase struct {
+	transactor    *repository.Transactor
+	saleRepo      *repository.SaleRepository
+	bookRepo      *repository.BookRepository
+	movementRepo  *repository.StockMovementRepository
+	promotionRepo *repository.PromotionRepository
+	locationRepo  *repository.LocationRepository
+	userRepo      *repository.UserRepository
+	cfg           *config.Config
+	validator     *validator.Validate
+}
+
+func NewSaleUsecase(
+	transactor *repository.Transactor,
+	saleRepo *repository.SaleRepository,
+	bookRepo *repository.BookRepository,
+	movementRepo *repository.StockMovementRepository,
+	promotionRepo *repository.PromotionRepository,
+	locationRepo *repository.LocationRepository,
+	userRepo *repository.UserRepository,
+	cfg *config.Config,
+	validator *validator.Validate,
+) *SaleUsecase {
+	return &SaleUsecase{transactor, saleRepo, bookRepo, movementRepo, promotionRepo, locationRepo, userRepo, cfg, validator}
+}
+
+// Create mencatat penjualan dan mengurangi stok setiap item di dalam satu transaksi database.
+// Diskon dihitung dari promosi yang berlaku saat ini dan stok diambil dari lokasi yang diminta
+// atau lokasi default. Jika salah satu item gagal, seluruh penjualan dibatalkan
+func (s SaleUsecase) Create(ctx context.Context, cashierId uuid.UUID, request *model.CreateSaleRequest) (model.SaleResponse, error) {
+	return s.create(ctx, cashierId, request, nil)
+}
+
+// create menjalankan Create. Jika beforeStock tidak nil, fungsi itu dijalankan di dalam transaksi
+// penjualan setelah penjualan disimpan dan sebelum stok dikurangi, misalnya untuk melepas reservasi
+// yang dibeli. Error dari beforeStock sebaiknya sudah berupa *fiber.Error
+func (s SaleUsecase) create(
+	ctx context.Context,
+	cashierId uuid.UUID,
+	request *model.CreateSaleRequest,
+	beforeStock func(tx *sqlx.Tx, saleId uuid.UUID) error,
+) (model.SaleResponse, error) {
+	err := s.validator.Struct(request)
+	if err != nil {
+		return model.SaleResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	saleId, err := uuid.NewV7()
+	if err != nil {
+		return model.SaleResponse{}, eris.Errorf("Failed to generate sale ID: %v", err)
+	}
+
+	sale := &entity.Sale{
+		SaleId:        saleId,
+		CashierId:     uuid.NullUUID{UUID: cashierId, Valid: cashierId != uuid.Nil},
+		PaymentMethod: entity.PaymentMethod(request.PaymentMethod),
+		Notes:         request.Notes,
+	}
+
+	location, err := resolveLocation(ctx, s.locationRepo, request.LocationID)
+	if err != nil {
+		return model.SaleResponse{}, err
+	}
+
+	cart, err := s.priceCart(ctx, request.Items)
+	if err != nil {
+		return model.SaleResponse{}, err
+	}
+
+	items := make([]*entity.SaleItem, len(request.Items))
+	for i, line := range cart.lines {
+		itemId, err := uuid.NewV7()
+		if err != nil {
+			return model.SaleResponse{}, eris.Errorf("Failed to generate sale item ID: %v", err)
+		}
+
+		items[i] = &entity.SaleItem{
+			SaleItemId: itemId,
+			SaleId:     saleId,
+			BookId:     uuid.NullUUID{UUID: line.BookID, Valid: true},
+			Quantity:   line.Quantity,
+			UnitPrice:  line.UnitPrice,
+			Discount:   cart.result.Lines[i].Discount,
+		}
+	}
+
+	promotions := make([]*entity.SalePromotion, len(cart.result.Applied))
+	for i, applied := range cart.result.Applied {
+		salePromotionId, err := uuid.NewV7()
+		if err != nil {
+			return model.SaleResponse{}, eris.Errorf("Failed to generate sale promotion ID: %v", err)
+		}
+
+		promotions[i] = &entity.SalePromotion{
+			SalePromotionId: salePromotionId,
+			SaleId:          saleId,
+			PromotionId:     uuid.NullUUID{UUID: applied.RuleID, Valid: true},
+			Name:            applied.Name,
+			Discount:        applied.Discount,
+		}
+	}
+
+	calculateSaleTotals(sale, items)
+
+	sale.AmountPaid = request.AmountPaid.Round(2)
+	if sale.AmountPaid.IsZero() {
+		sale.AmountPaid = sale.Total
+	}
+
+	if sale.AmountPaid.LessThan(sale.Total) {
+		return model.SaleResponse{}, fiber.NewError(fiber.StatusBadRequest, "Amount paid is less than the sale total")
+	}
+	sale.ChangeDue = sale.AmountPaid.Sub(sale.Total)
+
+	// stok dikurangi berurutan berdasarkan book_id agar dua transaksi yang menjual
+	// buku yang sama tidak saling menunggu (deadlock)
+	ordered := slices.Clone(items)
+	slices.SortStableFunc(ordered, func(a, b *entity.SaleItem) int {
+		return bytes.Compare(a.BookId.UUID[:], b.BookId.UUID[:])
+	})
+
+	var failedBookId uuid.UUID
+	err = s.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		saleRepo := s.saleRepo.WithTx(tx)
+		bookRepo := s.bookRepo.WithTx(tx)
+		movementRepo := s.movementRepo.WithTx(tx)
+
+		sale, err = saleRepo.Create(ctx, sale)
+		if err != nil {
+			return err
+		}
+
+		for _, promotion := range promotions {
+			_, err = saleRepo.CreatePromotion(ctx, promotion)
+			if err != nil {
+				return err
+			}
+		}
+
+		if beforeStock != nil {
+			err = beforeStock(tx, saleId)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, item := range ordered {
+			movement, err := newStockMovement(item.BookId.UUID, location.LocationId, entity.StockMovementSale, -item.Quantity, "sale", saleId.String(), cashierId)
+			if err != nil {
+				return err
+			}
+
+			book, err := applyStockMovement(ctx, bookRepo, movementRepo, movement)
+			if err != nil {
+				failedBookId = item.BookId.UUID
+				return err
+			}
+
+			item.ISBN = book.ISBN
+			item.Title = book.Title
+
+			_, err = saleRepo.CreateItem(ctx, item)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		var fe *fiber.Error
+		if eris.As(err, &fe) {
+			return model.SaleResponse{}, err
+		}
+
+		if eris.Is(err, types.ErrNoRows) {
+			return model.SaleResponse{}, fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("Book %s not found", failedBookId))
+		}
+
+		if eris.Is(err, types.ErrInsufficientStock) {
+			return model.SaleResponse{}, eris.Wrap(fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Insufficient stock for book %s", failedBookId)), err.Error())
+		}
+
+		return model.SaleResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to create sale"), eris.ToString(err, true))
+	}
+
+	return model.SaleToResponse(sale, items, promotions), nil
+}
+
+// Preview menghitung harga keranjang dengan promosi yang sedang berlaku tanpa menyimpan
+// penjualan dan tanpa mengubah stok
+func (s SaleUsecase) Preview(ctx context.Context, request *model.PreviewSaleRequest) (model.SalePreviewResponse, error) {
+	err := s.validator.Struct(request)
+	if err != nil {
+		return model.SalePreviewResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	cart, err := s.priceCart(ctx, request.Items)
+	if err != nil {
+		return model.SalePreviewResponse{}, err
+	}
+
+	response := model.SalePreviewResponse{
+		Subtotal:      cart.result.Subtotal,
+		DiscountTotal: cart.result.DiscountTotal,
+		Total:         cart.result.Total,
+		Items:         make([]model.SalePreviewItemResponse, len(cart.lines)),
+		Promotions:    make([]model.SalePromotionResponse, len(cart.result.Applied)),
+	}
+
+	for i, line := range cart.lines {
+		promotionIds := cart.result.Lines[i].RuleIDs
+		if promotionIds == nil {
+			promotionIds = make([]uuid.UUID, 0)
+		}
+
+		response.Items[i] = model.SalePreviewItemResponse{
+			BookID:       line.BookID,
+			ISBN:         cart.books[i].ISBN,
+			Title:        cart.books[i].Title,
+			Quantity:     line.Quantity,
+			UnitPrice:    line.UnitPrice,
+			Discount:     cart.result.Lines[i].Discount,
+			LineTotal:    cart.result.Lines[i].Total,
+			PromotionIDs: promotionIds,
+		}
+	}
+
+	for i, applied := range cart.result.Applied {
+		response.Promotions[i] = model.SalePromotionResponse{
+			PromotionID: &applied.RuleID,
+			Name:        applied.Name,
+			Discount:    applied.Discount,
+		}
+	}
+
+	return response, nil
+}
+
+// pricedCart adalah isi keranjang beserta hasil perhitungan promosinya. Urutan books dan lines
+// sama dengan urutan item pada request
+type pricedCart struct {
+	books  []*entity.Book
+	lines  []pricing.Line
+	result pricing.Result
+}
+
+// priceCart mengambil data buku dan promosi yang berlaku lalu menghitung diskon setiap baris.
+// Harga satuan selalu diambil dari harga jual buku, buku yang belum diberi harga tidak bisa dijual
+func (s SaleUsecase) priceCart(ctx context.Context, items []model.SaleItemRequest) (*pricedCart, error) {
+	cart := &pricedCart{
+		books: make([]*entity.Book, len(items)),
+		lines: make([]pricing.Line, len(items)),
+	}
+
+	for i, item := range items {
+		book, err := s.bookRepo.GetById(ctx, item.BookID)
+		if err != nil {
+			if eris.Is(err, types.ErrNoRows) {
+				return nil, fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("Book %s not found", item.BookID))
+			}
+
+			return nil, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get book"), eris.ToString(err, true))
+		}
+
+		if !book.SalePrice.IsPositive() {
+			return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Book %s has no sale price", item.BookID))
+		}
+
+		cart.books[i] = book
+		cart.lines[i] = pricing.Line{
+			BookID:    book.BookId,
+			Publisher: book.Publisher,
+			Author:    book.Author,
+			Quantity:  item.Quantity,
+			UnitPrice: book.SalePrice,
+		}
+	}
+
+	rules, err := activePromotionRules(ctx, s.promotionRepo, time.Now())
+	if err != nil {
+		return nil, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get active promotions"), eris.ToString(err, true))
+	}
+
+	cart.result = pricing.Calculate(cart.lines, rules)
+
+	return cart, nil
+}
+
+func (s SaleUsecase) GetById(ctx context.Context, saleId string) (model.SaleResponse, error) {
+	id, err := uuid.Parse(saleId)
+	if err != nil {
+		return model.SaleResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid sale ID"), err.Error())
+	}
+
+	sale, err := s.saleRepo.GetById(ctx, id)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return model.SaleResponse{}, fiber.NewError(fiber.StatusNotFound, "Sale not found")
+		}
+
+		return model.SaleResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get sale"), eris.ToString(err, true))
+	}
+
+	items, err := s.saleRepo.GetItemsBySaleId(ctx, id)
+	if err != nil {
+		return model.SaleResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get sale items"), eris.ToString(err, true))
+	}
+
+	promotions, err := s.saleRepo.GetPromotionsBySaleId(ctx, id)
+	if err != nil {
+		return model.SaleResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get sale promotions"), eris.ToString(err, true))
+	}
+
+	return model.SaleToResponse(sale, items, promotions), nil
+}
+
+// GetReceipt membuat struk penjualan dalam format PDF, ESC/POS atau teks. QR code di struk
+// berisi URL ke endpoint detail penjualan
+func (s SaleUsecase) GetReceipt(ctx context.Context, saleId string, request *model.SaleReceiptRequest, baseURL string) ([]byte, receipt.Format, error) {
+	err := s.validator.Struct(request)
+	if err != nil {
+		return nil, "", eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters"), err.Error())
+	}
+
+	sale, err := s.GetById(ctx, saleId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cashier := ""
+	if sale.CashierID != nil {
+		user, err := s.userRepo.GetById(ctx, *sale.CashierID)
+		if err != nil && !eris.Is(err, types.ErrNoRows) {
+			return nil, "", eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get cashier"), eris.ToString(err, true))
+		}
+		if user != nil {
+			cashier = cmp.Or(user.FullName, user.Username)
+		}
+	}
+
+	r := receipt.Receipt{
+		Store: receipt.Store{
+			Name:    s.cfg.STORE_NAME,
+			Address: s.cfg.STORE_ADDRESS,
+			Phone:   s.cfg.STORE_PHONE,
+			TaxID:   s.cfg.STORE_TAX_ID,
+			Footer:  s.cfg.RECEIPT_FOOTER,
+		},
+		SaleID:        sale.SaleID.String(),
+		Cashier:       cashier,
+		CreatedAt:     sale.CreatedAt,
+		Items:         make([]receipt.Item, len(sale.Items)),
+		Promotions:    make([]receipt.Promotion, len(sale.Promotions)),
+		Subtotal:      sale.Subtotal,
+		DiscountTotal: sale.DiscountTotal,
+		Total:         sale.Total,
+		TaxRate:       s.cfg.STORE_TAX_RATE,
+		PaymentMethod: string(sale.PaymentMethod),
+		AmountPaid:    sale.AmountPaid,
+		ChangeDue:     sale.ChangeDue,
+		QRContent:     baseURL + config.BASE_API_HTTP_PATH + "/sales/" + sale.SaleID.String(),
+	}
+	for i, item := range sale.Items {
+		r.Items[i] = receipt.Item{
+			Title:     item.Title,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+			Discount:  item.Discount,
+		}
+	}
+	for i, promotion := range sale.Promotions {
+		r.Promotions[i] = receipt.Promotion{Name: promotion.Name, Discount: promotion.Discount}
+	}
+
+	format := receipt.Format(request.Format)
+	if format == "" {
+		format = receipt.FormatPDF
+	}
+
+	paper := receipt.Paper(request.Paper)
+	if paper == 0 {
+		paper = receipt.Paper58
+	}
+
+	var buf bytes.Buffer
+	err = receipt.Render(&buf, r, format, paper)
+	if err != nil {
+		return nil, "", eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to generate receipt"), err.Error())
+	}
+
+	return buf.Bytes(), format, nil
+}
+
+func (s SaleUsecase) GetMany(ctx context.Context, offset int64, limit int64) ([]model.SaleResponse, int64, error) {
+	if limit <= 0 {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Limit must be greater than 0"), "Invalid limit")
+	}
+
+	sales, err := s.saleRepo.GetMany(ctx, offset, limit)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get sales"), eris.ToString(err, true))
+	}
+
+	total, err := s.saleRepo.GetTotalCount(ctx)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get total count"), eris.ToString(err, true))
+	}
+
+	salesResp := make([]model.SaleResponse, len(sales))
+	for i, sale := range sales {
+		salesResp[i] = model.SaleToResponse(sale, nil, nil)
+	}
+
+	return salesResp, total, nil
+}
+
+// calculateSaleTotals menghitung total setiap baris dan total penjualan
+func calculateSaleTotals(sale *entity.Sale, items []*entity.SaleItem) {
+	sale.Subtotal = decimal.Zero
+	sale.DiscountTotal = decimal.Zero
+
+	for _, item := range items {
+		gross := item.UnitPrice.Mul(decimal.NewFromInt(item.Quantity))
+		item.LineTotal = gross.Sub(item.Discount).Round(2)
+
+		sale.Subtotal = sale.Subtotal.Add(gross)
+		sale.DiscountTotal = sale.DiscountTotal.Add(item.Discount)
+	}
+
+	sale.Subtotal = sale.Subtotal.Round(2)
+	sale.DiscountTotal = sale.DiscountTotal.Round(2)
+	sale.Total = sale.Subtotal.Sub(sale.DiscountTotal)
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/isbn"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/repository"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+// jenis kode yang dikenali oleh endpoint scan
+const (
+	scanCodeUUID   = "uuid"
+	scanCodeURL    = "url"
+	scanCodeISBN10 = "isbn10"
+	scanCodeISBN13 = "isbn13"
+)
+
+type ScanUsecase struct {
+	transactor   *repository.Transactor
+	bookRepo     *repository.BookRepository
+	movementRepo *repository.StockMovementRepository
+	locationRepo *repository.LocationRepository
+	authUsecase  *AuthUsecase
+	backorders   *BackorderUsecase
+	validator    *validator.Validate
+}
+
+func NewScanUsecase(
+	transactor *repository.Transactor,
+	bookRepo *repository.BookRepository,
+	movementRepo *repository.StockMovementRepository,
+	locationRepo *repository.LocationRepository,
+	authUsecase *AuthUsecase,
+	backorders *BackorderUsecase,
+	validator *validator.Validate,
+) *ScanUsecase {
+	return &ScanUsecase{transactor, bookRepo, movementRepo, locationRepo, authUsecase, backorders, validator}
+}
+
+// Scan mencari buku dari hasil pindaian dan, jika action diisi, langsung mencatat
+// penjualan atau penerimaan stok untuk buku tersebut. Barang yang diterima dialokasikan lebih
+// dulu ke antrean backorder di lokasi tersebut
+func (s ScanUsecase) Scan(ctx context.Context, userId uuid.UUID, request *model.ScanRequest) (model.ScanResponse, error) {
+	err := s.validator.Struct(request)
+	if err != nil {
+		return model.ScanResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	codeType, value := detectScanCode(request.Code)
+	if codeType == "" {
+		return model.ScanResponse{}, fiber.NewError(fiber.StatusBadRequest, "Unrecognized code, expected a book QR code or an ISBN barcode")
+	}
+
+	book, err := getBookByScanCode(ctx, s.bookRepo, codeType, value)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return model.ScanResponse{}, fiber.NewError(fiber.StatusNotFound, "Book not found")
+		}
+
+		return model.ScanResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get book"), eris.ToString(err, true))
+	}
+
+	response := model.ScanResponse{CodeType: codeType}
+	if request.Action == "" {
+		response.Book = model.BookToResponse(book)
+		return response, nil
+	}
+
+	quantity := request.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+
+	var (
+		movementType entity.StockMovementType
+		permission   string
+	)
+	switch request.Action {
+	case "sell":
+		movementType, permission, quantity = entity.StockMovementSale, entity.PermissionSalesCreate, -quantity
+	case "receive":
+		movementType, permission = entity.StockMovementReceipt, entity.PermissionStockAdjust
+	}
+
+	err = s.authUsecase.Authorize(ctx, userId, permission)
+	if err != nil {
+		return model.ScanResponse{}, err
+	}
+
+	location, err := resolveLocation(ctx, s.locationRepo, request.LocationID)
+	if err != nil {
+		return model.ScanResponse{}, err
+	}
+
+	movement, err := newStockMovement(book.BookId, location.LocationId, movementType, quantity, "scan "+request.Action, request.Reference, userId)
+	if err != nil {
+		return model.ScanResponse{}, err
+	}
+
+	var allocated []*entity.Backorder
+	err = s.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		book, err = applyStockMovement(ctx, s.bookRepo.WithTx(tx), s.movementRepo.WithTx(tx), movement)
+		if err != nil || !allocatesStock(movement) {
+			return err
+		}
+
+		allocated, err = s.backorders.allocate(ctx, tx, location.LocationId, []uuid.UUID{book.BookId}, userId)
+		return err
+	})
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return model.ScanResponse{}, fiber.NewError(fiber.StatusNotFound, "Book not found")
+		}
+
+		if eris.Is(err, types.ErrInsufficientStock) {
+			return model.ScanResponse{}, eris.Wrap(fiber.NewError(fiber.StatusConflict, "Insufficient stock, stock cannot be negative"), err.Error())
+		}
+
+		return model.ScanResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to record stock movement"), eris.ToString(err, true))
+	}
+
+	// stok yang baru ditahan untuk backorder tidak lagi tersedia untuk dijual
+	book.Reserved += allocatedQuantity(allocated)
+
+	movementResp := model.StockMovementToResponse(movement)
+	response.Book = model.BookToResponse(book)
+	response.Movement = &movementResp
+	if len(allocated) > 0 {
+		response.Allocations = model.BackordersToResponse(allocated)
+	}
+
+	return response, nil
+}
+
+// getBookByScanCode mengambil buku berdasarkan jenis dan nilai kode dari detectScanCode
+func getBookByScanCode(ctx context.Context, bookRepo *repository.BookRepository, codeType string, value string) (*entity.Book, error) {
+	switch codeType {
+	case scanCodeUUID, scanCodeURL:
+		return bookRepo.GetById(ctx, uuid.MustParse(value))
+	}
+
+	return bookRepo.GetByISBN(ctx, value)
+}
+
+// detectScanCode mengenali jenis kode hasil pindaian dan mengembalikan nilai yang sudah dibersihkan.
+// Jenis kode kosong berarti kode tidak dikenali
+func detectScanCode(code string) (string, string) {
+	code = strings.TrimSpace(code)
+
+	if id, err := uuid.Parse(code); err == nil {
+		return scanCodeUUID, id.String()
+	}
+
+	if u, err := url.Parse(code); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+		for i := len(segments) - 1; i > 0; i-- {
+			if segments[i-1] != "books" {
+				continue
+			}
+
+			if id, err := uuid.Parse(segments[i]); err == nil {
+				return scanCodeURL, id.String()
+			}
+		}
+
+		return "", ""
+	}
+
+	cleaned := isbn.Clean(code)
+	switch {
+	case isbn.IsValidISBN13(cleaned):
+		return scanCodeISBN13, cleaned
+	case isbn.IsValidISBN10(cleaned):
+		return scanCodeISBN10, cleaned
+	}
+
+	return "", ""
+}
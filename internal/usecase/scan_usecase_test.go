@@ -0,0 +1,35 @@
+package usecase
+
+import "testing"
+
+func TestDetectScanCode(t *testing.T) {
+	const bookId = "0196f1a2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+
+	tests := []struct {
+		name     string
+		code     string
+		wantType string
+		wantCode string
+	}{
+		{name: "bare uuid", code: bookId, wantType: scanCodeUUID, wantCode: bookId},
+		{name: "uppercase uuid with spaces", code: "  0196F1A2-7C3D-7E4F-8A9B-0C1D2E3F4A5B \n", wantType: scanCodeUUID, wantCode: bookId},
+		{name: "book url", code: "https://shop.example.com/books/" + bookId, wantType: scanCodeURL, wantCode: bookId},
+		{name: "book url with prefix and trailing slash", code: "http://shop.example.com/api/v1/books/" + bookId + "/", wantType: scanCodeURL, wantCode: bookId},
+		{name: "url without book segment", code: "https://shop.example.com/authors/" + bookId},
+		{name: "url with invalid book id", code: "https://shop.example.com/books/not-an-id"},
+		{name: "isbn-13 with hyphens", code: "978-0-306-40615-7", wantType: scanCodeISBN13, wantCode: "9780306406157"},
+		{name: "isbn-10 with x check digit", code: "0-8044-2957-x", wantType: scanCodeISBN10, wantCode: "080442957X"},
+		{name: "isbn-13 bad check digit", code: "9780306406158"},
+		{name: "unknown text", code: "hello"},
+		{name: "empty", code: "   "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotType, gotCode := detectScanCode(tt.code)
+			if gotType != tt.wantType || gotCode != tt.wantCode {
+				t.Errorf("detectScanCode(%q) = (%q, %q), want (%q, %q)", tt.code, gotType, gotCode, tt.wantType, tt.wantCode)
+			}
+		})
+	}
+}
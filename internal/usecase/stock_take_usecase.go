@@ -0,0 +1,419 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/repository"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+// StockTakeUsecase menjalankan penghitungan stok fisik per lokasi. Saat stock take dimulai stok
+// setiap buku di lokasi dicatat sebagai jumlah yang diharapkan, sehingga penjualan selama
+// penghitungan tidak mengubah selisih. Saat disetujui selisih hasil hitung terhadap jumlah yang
+// diharapkan dicatat sebagai pergerakan stok adjustment
+type StockTakeUsecase struct {
+	transactor    *repository.Transactor
+	stockTakeRepo *repository.StockTakeRepository
+	bookRepo      *repository.BookRepository
+	movementRepo  *repository.StockMovementRepository
+	locationRepo  *repository.LocationRepository
+	validator     *validator.Validate
+}
+
+func NewStockTakeUsecase(
+	transactor *repository.Transactor,
+	stockTakeRepo *repository.StockTakeRepository,
+	bookRepo *repository.BookRepository,
+	movementRepo *repository.StockMovementRepository,
+	locationRepo *repository.LocationRepository,
+	validator *validator.Validate,
+) *StockTakeUsecase {
+	return &StockTakeUsecase{transactor, stockTakeRepo, bookRepo, movementRepo, locationRepo, validator}
+}
+
+// Create memulai stock take di sebuah lokasi dan mencatat stok setiap buku saat itu.
+// Setiap lokasi hanya boleh memiliki satu stock take yang masih open
+func (s StockTakeUsecase) Create(ctx context.Context, userId uuid.UUID, request *model.CreateStockTakeRequest) (model.StockTakeResponse, error) {
+	err := s.validator.Struct(request)
+	if err != nil {
+		return model.StockTakeResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	location, err := resolveLocation(ctx, s.locationRepo, request.LocationID)
+	if err != nil {
+		return model.StockTakeResponse{}, err
+	}
+
+	stockTakeId, err := uuid.NewV7()
+	if err != nil {
+		return model.StockTakeResponse{}, eris.Errorf("Failed to generate stock take ID: %v", err)
+	}
+
+	stockTake := &entity.StockTake{
+		StockTakeId: stockTakeId,
+		LocationId:  location.LocationId,
+		Status:      entity.StockTakeOpen,
+		Notes:       request.Notes,
+		CreatedBy:   uuid.NullUUID{UUID: userId, Valid: userId != uuid.Nil},
+	}
+
+	var summary *entity.StockTakeSummary
+	err = s.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		stockTakeRepo := s.stockTakeRepo.WithTx(tx)
+
+		stockTake, err = stockTakeRepo.Create(ctx, stockTake)
+		if err != nil {
+			return err
+		}
+
+		err = stockTakeRepo.Snapshot(ctx, stockTakeId, location.LocationId)
+		if err != nil {
+			return err
+		}
+
+		summary, err = stockTakeRepo.GetSummary(ctx, stockTakeId)
+		return err
+	})
+	if err != nil {
+		if eris.Is(err, types.ErrDuplicateKey) {
+			return model.StockTakeResponse{}, fiber.NewError(fiber.StatusConflict, "Location already has an open stock take")
+		}
+
+		return model.StockTakeResponse{}, stockTakeWriteError(err, uuid.Nil, "Failed to create stock take")
+	}
+
+	return model.StockTakeToResponse(stockTake, summary), nil
+}
+
+// GetById mengambil stock take beserta ringkasan hasil hitungnya
+func (s StockTakeUsecase) GetById(ctx context.Context, stockTakeId string) (model.StockTakeResponse, error) {
+	id, err := uuid.Parse(stockTakeId)
+	if err != nil {
+		return model.StockTakeResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid stock take ID"), err.Error())
+	}
+
+	stockTake, err := s.stockTakeRepo.GetById(ctx, id)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return model.StockTakeResponse{}, fiber.NewError(fiber.StatusNotFound, "Stock take not found")
+		}
+
+		return model.StockTakeResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get stock take"), eris.ToString(err, true))
+	}
+
+	summary, err := s.stockTakeRepo.GetSummary(ctx, id)
+	if err != nil {
+		return model.StockTakeResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get stock take summary"), eris.ToString(err, true))
+	}
+
+	return model.StockTakeToResponse(stockTake, summary), nil
+}
+
+func (s StockTakeUsecase) GetMany(ctx context.Context, request *model.StockTakeSearchRequest, offset int64, limit int64) ([]model.StockTakeResponse, int64, error) {
+	if limit <= 0 {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Limit must be greater than 0"), "Invalid limit")
+	}
+
+	err := s.validator.Struct(request)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters"), err.Error())
+	}
+
+	var locationId uuid.NullUUID
+	if request.LocationID != "" {
+		locationId = uuid.NullUUID{UUID: uuid.MustParse(request.LocationID), Valid: true}
+	}
+
+	stockTakes, err := s.stockTakeRepo.GetMany(ctx, request.Status, locationId, offset, limit)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get stock takes"), eris.ToString(err, true))
+	}
+
+	total, err := s.stockTakeRepo.GetTotalCount(ctx, request.Status, locationId)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get total count"), eris.ToString(err, true))
+	}
+
+	stockTakesResp := make([]model.StockTakeResponse, len(stockTakes))
+	for i, stockTake := range stockTakes {
+		stockTakesResp[i] = model.StockTakeToResponse(stockTake, nil)
+	}
+
+	return stockTakesResp, total, nil
+}
+
+// GetLines mengambil laporan hasil hitung stock take, urut berdasarkan judul buku
+func (s StockTakeUsecase) GetLines(ctx context.Context, stockTakeId string, request *model.StockTakeLineSearchRequest, offset int64, limit int64) ([]model.StockTakeLineResponse, int64, error) {
+	if limit <= 0 {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Limit must be greater than 0"), "Invalid limit")
+	}
+
+	id, err := uuid.Parse(stockTakeId)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid stock take ID"), err.Error())
+	}
+
+	err = s.validator.Struct(request)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters"), err.Error())
+	}
+
+	_, err = s.stockTakeRepo.GetById(ctx, id)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return nil, 0, fiber.NewError(fiber.StatusNotFound, "Stock take not found")
+		}
+
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get stock take"), eris.ToString(err, true))
+	}
+
+	lines, err := s.stockTakeRepo.GetLines(ctx, id, request.Status, offset, limit)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get stock take lines"), eris.ToString(err, true))
+	}
+
+	total, err := s.stockTakeRepo.GetLinesTotalCount(ctx, id, request.Status)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get total count"), eris.ToString(err, true))
+	}
+
+	linesResp := make([]model.StockTakeLineResponse, len(lines))
+	for i, line := range lines {
+		linesResp[i] = model.StockTakeLineToResponse(line)
+	}
+
+	return linesResp, total, nil
+}
+
+// Count mencatat hasil pindaian ke stock take yang masih open. Buku yang belum ada di snapshot,
+// misalnya buku yang ditambahkan setelah stock take dimulai, memakai stok lokasi saat pertama kali
+// dihitung sebagai jumlah yang diharapkan
+func (s StockTakeUsecase) Count(ctx context.Context, userId uuid.UUID, stockTakeId string, request *model.StockTakeCountRequest) ([]model.StockTakeLineResponse, error) {
+	id, err := uuid.Parse(stockTakeId)
+	if err != nil {
+		return nil, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid stock take ID"), err.Error())
+	}
+
+	err = s.validator.Struct(request)
+	if err != nil {
+		return nil, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	add := request.Mode != "set"
+	countedBy := uuid.NullUUID{UUID: userId, Valid: userId != uuid.Nil}
+
+	lines := make([]*entity.StockTakeLine, len(request.Items))
+	for i, item := range request.Items {
+		codeType, value := detectScanCode(item.Code)
+		if codeType == "" {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Unrecognized code %q, expected a book QR code or an ISBN barcode", item.Code))
+		}
+
+		book, err := getBookByScanCode(ctx, s.bookRepo, codeType, value)
+		if err != nil {
+			if eris.Is(err, types.ErrNoRows) {
+				return nil, fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("Book not found for code %q", item.Code))
+			}
+
+			return nil, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get book"), eris.ToString(err, true))
+		}
+
+		quantity := int64(1)
+		if item.Quantity != nil {
+			quantity = *item.Quantity
+		}
+
+		lines[i] = &entity.StockTakeLine{
+			StockTakeId: id,
+			BookId:      book.BookId,
+			Title:       book.Title,
+			ISBN:        book.ISBN,
+			Counted:     sql.NullInt64{Int64: quantity, Valid: true},
+			CountedBy:   countedBy,
+		}
+	}
+
+	// baris diubah berurutan berdasarkan book_id agar penghitungan bersamaan tidak saling menunggu
+	// (deadlock). Urutan pindaian untuk buku yang sama tetap dipertahankan
+	slices.SortStableFunc(lines, func(a, b *entity.StockTakeLine) int {
+		return bytes.Compare(a.BookId[:], b.BookId[:])
+	})
+
+	var failedBookId uuid.UUID
+	err = s.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		stockTakeRepo := s.stockTakeRepo.WithTx(tx)
+
+		stockTake, err := stockTakeRepo.GetByIdForShare(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if stockTake.Status != entity.StockTakeOpen {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Stock take is %s, only open stock takes can be counted", stockTake.Status))
+		}
+
+		for _, line := range lines {
+			failedBookId = line.BookId
+			_, err = stockTakeRepo.Count(ctx, line, stockTake.LocationId, add)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, stockTakeWriteError(err, failedBookId, "Failed to record stock take count")
+	}
+
+	// buku yang dipindai beberapa kali cukup dikembalikan satu kali dengan hasil hitung terakhir
+	linesResp := make([]model.StockTakeLineResponse, 0, len(lines))
+	for i, line := range lines {
+		if i+1 < len(lines) && lines[i+1].BookId == line.BookId {
+			continue
+		}
+		linesResp = append(linesResp, model.StockTakeLineToResponse(line))
+	}
+
+	return linesResp, nil
+}
+
+// Approve menyetujui stock take dan mencatat selisih setiap buku sebagai pergerakan stok
+// adjustment di lokasi stock take dalam satu transaksi. Selisih ditambahkan ke stok saat ini,
+// sehingga penjualan yang terjadi selama penghitungan tetap terhitung
+func (s StockTakeUsecase) Approve(ctx context.Context, userId uuid.UUID, stockTakeId string, request *model.ApproveStockTakeRequest) (model.StockTakeApproveResponse, error) {
+	id, err := uuid.Parse(stockTakeId)
+	if err != nil {
+		return model.StockTakeApproveResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid stock take ID"), err.Error())
+	}
+
+	var (
+		stockTake    *entity.StockTake
+		summary      *entity.StockTakeSummary
+		movements    []*entity.StockMovement
+		failedBookId uuid.UUID
+	)
+	err = s.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		stockTakeRepo := s.stockTakeRepo.WithTx(tx)
+		bookRepo := s.bookRepo.WithTx(tx)
+		movementRepo := s.movementRepo.WithTx(tx)
+
+		stockTake, err = stockTakeRepo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if stockTake.Status != entity.StockTakeOpen {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Stock take is %s, only open stock takes can be approved", stockTake.Status))
+		}
+
+		// baris sudah urut berdasarkan book_id sehingga tidak deadlock dengan transaksi stok lain
+		lines, err := stockTakeRepo.GetVariances(ctx, id, request.ZeroUncounted)
+		if err != nil {
+			return err
+		}
+
+		for _, line := range lines {
+			variance := line.Counted.Int64 - line.Expected
+			movement, err := newStockMovement(line.BookId, stockTake.LocationId, entity.StockMovementAdjustment, variance, "stock take", id.String(), userId)
+			if err != nil {
+				return err
+			}
+
+			_, err = applyStockMovement(ctx, bookRepo, movementRepo, movement)
+			if err != nil {
+				failedBookId = line.BookId
+				return err
+			}
+			movements = append(movements, movement)
+		}
+
+		stockTake, err = stockTakeRepo.Approve(ctx, id, uuid.NullUUID{UUID: userId, Valid: userId != uuid.Nil})
+		if err != nil {
+			return err
+		}
+
+		summary, err = stockTakeRepo.GetSummary(ctx, id)
+		return err
+	})
+	if err != nil {
+		return model.StockTakeApproveResponse{}, stockTakeWriteError(err, failedBookId, "Failed to approve stock take")
+	}
+
+	response := model.StockTakeApproveResponse{
+		StockTake: model.StockTakeToResponse(stockTake, summary),
+		Movements: make([]model.StockMovementResponse, len(movements)),
+	}
+	for i, movement := range movements {
+		response.Movements[i] = model.StockMovementToResponse(movement)
+	}
+
+	return response, nil
+}
+
+// Cancel membatalkan stock take yang masih open tanpa mengubah stok
+func (s StockTakeUsecase) Cancel(ctx context.Context, stockTakeId string) (model.StockTakeResponse, error) {
+	id, err := uuid.Parse(stockTakeId)
+	if err != nil {
+		return model.StockTakeResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid stock take ID"), err.Error())
+	}
+
+	var stockTake *entity.StockTake
+	err = s.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		stockTakeRepo := s.stockTakeRepo.WithTx(tx)
+
+		stockTake, err = stockTakeRepo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if stockTake.Status != entity.StockTakeOpen {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Stock take is %s, only open stock takes can be cancelled", stockTake.Status))
+		}
+
+		stockTake, err = stockTakeRepo.Cancel(ctx, id)
+		return err
+	})
+	if err != nil {
+		return model.StockTakeResponse{}, stockTakeWriteError(err, uuid.Nil, "Failed to cancel stock take")
+	}
+
+	return model.StockTakeToResponse(stockTake, nil), nil
+}
+
+// stockTakeWriteError memetakan error dari transaksi stock take menjadi error HTTP
+func stockTakeWriteError(err error, failedBookId uuid.UUID, message string) error {
+	var fe *fiber.Error
+	if eris.As(err, &fe) {
+		return fe
+	}
+
+	if eris.Is(err, types.ErrInsufficientStock) {
+		return eris.Wrap(fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Insufficient stock for book %s to post the stock take variance", failedBookId)), err.Error())
+	}
+
+	if (eris.Is(err, types.ErrNoRows) || eris.Is(err, types.ErrMissingReference)) && failedBookId != uuid.Nil {
+		return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("Book %s not found", failedBookId))
+	}
+
+	if eris.Is(err, types.ErrMissingReference) {
+		return fiber.NewError(fiber.StatusNotFound, "Location not found")
+	}
+
+	if eris.Is(err, types.ErrNoRows) {
+		return fiber.NewError(fiber.StatusNotFound, "Stock take not found")
+	}
+
+	return eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, message), eris.ToString(err, true))
+}
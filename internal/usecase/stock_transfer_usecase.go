@@ -0,0 +1,376 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/repository"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+// StockTransferUsecase memindahkan stok antar lokasi. Transfer berjalan dari requested ke
+// in_transit saat dikirim (stok lokasi asal berkurang) lalu ke received saat diterima
+// (stok lokasi tujuan bertambah). Setiap langkah dicatat sebagai pergerakan stok transfer
+type StockTransferUsecase struct {
+	transactor   *repository.Transactor
+	transferRepo *repository.StockTransferRepository
+	bookRepo     *repository.BookRepository
+	movementRepo *repository.StockMovementRepository
+	locationRepo *repository.LocationRepository
+	backorders   *BackorderUsecase
+	validator    *validator.Validate
+}
+
+func NewStockTransferUsecase(
+	transactor *repository.Transactor,
+	transferRepo *repository.StockTransferRepository,
+	bookRepo *repository.BookRepository,
+	movementRepo *repository.StockMovementRepository,
+	locationRepo *repository.LocationRepository,
+	backorders *BackorderUsecase,
+	validator *validator.Validate,
+) *StockTransferUsecase {
+	return &StockTransferUsecase{transactor, transferRepo, bookRepo, movementRepo, locationRepo, backorders, validator}
+}
+
+// Create membuat transfer berstatus requested. Stok lokasi asal belum diubah, tetapi transfer
+// yang melebihi stok lokasi asal saat ini langsung ditolak
+func (s StockTransferUsecase) Create(ctx context.Context, userId uuid.UUID, request *model.CreateStockTransferRequest) (model.StockTransferResponse, error) {
+	err := s.validator.Struct(request)
+	if err != nil {
+		return model.StockTransferResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	if request.FromLocationID == request.ToLocationID {
+		return model.StockTransferResponse{}, fiber.NewError(fiber.StatusBadRequest, "Source and destination locations must be different")
+	}
+
+	for _, locationId := range []uuid.UUID{request.FromLocationID, request.ToLocationID} {
+		_, err = resolveLocation(ctx, s.locationRepo, &locationId)
+		if err != nil {
+			return model.StockTransferResponse{}, err
+		}
+	}
+
+	transferId, err := uuid.NewV7()
+	if err != nil {
+		return model.StockTransferResponse{}, eris.Errorf("Failed to generate stock transfer ID: %v", err)
+	}
+
+	items, err := newStockTransferItems(transferId, request.Items)
+	if err != nil {
+		return model.StockTransferResponse{}, err
+	}
+
+	transfer := &entity.StockTransfer{
+		TransferId:     transferId,
+		FromLocationId: request.FromLocationID,
+		ToLocationId:   request.ToLocationID,
+		Status:         entity.StockTransferRequested,
+		Notes:          request.Notes,
+		RequestedBy:    uuid.NullUUID{UUID: userId, Valid: userId != uuid.Nil},
+	}
+
+	var failedBookId uuid.UUID
+	err = s.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		transferRepo := s.transferRepo.WithTx(tx)
+		bookRepo := s.bookRepo.WithTx(tx)
+
+		for _, item := range items {
+			failedBookId = item.BookId
+			book, err := bookRepo.GetByIdAtLocation(ctx, item.BookId, transfer.FromLocationId)
+			if err != nil {
+				return err
+			}
+
+			// stok yang ditahan reservasi tidak bisa dikirim ke lokasi lain
+			if available := book.Stock - book.Reserved; available < item.Quantity {
+				return eris.Wrapf(types.ErrInsufficientStock, "%d available at source location", available)
+			}
+		}
+
+		transfer, err = transferRepo.Create(ctx, transfer)
+		if err != nil {
+			failedBookId = uuid.Nil
+			return err
+		}
+
+		for _, item := range items {
+			failedBookId = item.BookId
+			_, err = transferRepo.CreateItem(ctx, item)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return model.StockTransferResponse{}, stockTransferWriteError(err, failedBookId, "Failed to create stock transfer")
+	}
+
+	return model.StockTransferToResponse(transfer, items), nil
+}
+
+func (s StockTransferUsecase) GetById(ctx context.Context, transferId string) (model.StockTransferResponse, error) {
+	id, err := uuid.Parse(transferId)
+	if err != nil {
+		return model.StockTransferResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid stock transfer ID"), err.Error())
+	}
+
+	transfer, err := s.transferRepo.GetById(ctx, id)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return model.StockTransferResponse{}, fiber.NewError(fiber.StatusNotFound, "Stock transfer not found")
+		}
+
+		return model.StockTransferResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get stock transfer"), eris.ToString(err, true))
+	}
+
+	items, err := s.transferRepo.GetItemsByTransferId(ctx, id)
+	if err != nil {
+		return model.StockTransferResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get stock transfer items"), eris.ToString(err, true))
+	}
+
+	return model.StockTransferToResponse(transfer, items), nil
+}
+
+func (s StockTransferUsecase) GetMany(ctx context.Context, request *model.StockTransferSearchRequest, offset int64, limit int64) ([]model.StockTransferResponse, int64, error) {
+	if limit <= 0 {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Limit must be greater than 0"), "Invalid limit")
+	}
+
+	err := s.validator.Struct(request)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters"), err.Error())
+	}
+
+	var locationId uuid.NullUUID
+	if request.LocationID != "" {
+		locationId = uuid.NullUUID{UUID: uuid.MustParse(request.LocationID), Valid: true}
+	}
+
+	transfers, err := s.transferRepo.GetMany(ctx, request.Status, locationId, offset, limit)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get stock transfers"), eris.ToString(err, true))
+	}
+
+	total, err := s.transferRepo.GetTotalCount(ctx, request.Status, locationId)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get total count"), eris.ToString(err, true))
+	}
+
+	transfersResp := make([]model.StockTransferResponse, len(transfers))
+	for i, transfer := range transfers {
+		transfersResp[i] = model.StockTransferToResponse(transfer, nil)
+	}
+
+	return transfersResp, total, nil
+}
+
+// Dispatch mengirim transfer yang masih requested. Stok setiap buku dikurangi dari lokasi asal
+// dalam satu transaksi, jika salah satu buku tidak cukup seluruh pengiriman dibatalkan
+func (s StockTransferUsecase) Dispatch(ctx context.Context, userId uuid.UUID, transferId string) (model.StockTransferProcessResponse, error) {
+	return s.process(ctx, userId, transferId, entity.StockTransferRequested, "Failed to dispatch stock transfer")
+}
+
+// Receive menerima transfer yang sedang dikirim dan menambah stok setiap buku di lokasi tujuan.
+// Stok yang masuk dialokasikan lebih dulu ke antrean backorder dan preorder di lokasi tujuan
+func (s StockTransferUsecase) Receive(ctx context.Context, userId uuid.UUID, transferId string) (model.StockTransferProcessResponse, error) {
+	return s.process(ctx, userId, transferId, entity.StockTransferInTransit, "Failed to receive stock transfer")
+}
+
+// process menjalankan pengiriman (from requested) atau penerimaan (from in_transit) transfer
+func (s StockTransferUsecase) process(
+	ctx context.Context,
+	userId uuid.UUID,
+	transferId string,
+	from entity.StockTransferStatus,
+	message string,
+) (model.StockTransferProcessResponse, error) {
+	id, err := uuid.Parse(transferId)
+	if err != nil {
+		return model.StockTransferProcessResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid stock transfer ID"), err.Error())
+	}
+
+	var (
+		transfer     *entity.StockTransfer
+		items        []*entity.StockTransferItem
+		movements    []*entity.StockMovement
+		allocated    []*entity.Backorder
+		failedBookId uuid.UUID
+	)
+	err = s.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		transferRepo := s.transferRepo.WithTx(tx)
+		bookRepo := s.bookRepo.WithTx(tx)
+		movementRepo := s.movementRepo.WithTx(tx)
+
+		transfer, err = transferRepo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if transfer.Status != from {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Stock transfer is %s, expected %s", transfer.Status, from))
+		}
+
+		items, err = transferRepo.GetItemsByTransferId(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		locationId, sign, reason := transfer.FromLocationId, int64(-1), "transfer dispatch"
+		if from == entity.StockTransferInTransit {
+			locationId, sign, reason = transfer.ToLocationId, 1, "transfer receipt"
+		}
+
+		// stok diubah berurutan berdasarkan book_id agar transaksi lain yang mengubah
+		// buku yang sama tidak saling menunggu (deadlock)
+		ordered := slices.Clone(items)
+		slices.SortStableFunc(ordered, func(a, b *entity.StockTransferItem) int {
+			return bytes.Compare(a.BookId[:], b.BookId[:])
+		})
+
+		for _, item := range ordered {
+			movement, err := newStockMovement(item.BookId, locationId, entity.StockMovementTransfer, sign*item.Quantity, reason, id.String(), userId)
+			if err != nil {
+				return err
+			}
+
+			_, err = applyStockMovement(ctx, bookRepo, movementRepo, movement)
+			if err != nil {
+				failedBookId = item.BookId
+				return err
+			}
+			movements = append(movements, movement)
+		}
+
+		actor := uuid.NullUUID{UUID: userId, Valid: userId != uuid.Nil}
+		if from != entity.StockTransferInTransit {
+			transfer, err = transferRepo.Dispatch(ctx, id, actor)
+			return err
+		}
+
+		transfer, err = transferRepo.Receive(ctx, id, actor)
+		if err != nil {
+			return err
+		}
+
+		bookIds := make([]uuid.UUID, len(ordered))
+		for i, item := range ordered {
+			bookIds[i] = item.BookId
+		}
+
+		allocated, err = s.backorders.allocate(ctx, tx, locationId, bookIds, userId)
+		return err
+	})
+	if err != nil {
+		return model.StockTransferProcessResponse{}, stockTransferWriteError(err, failedBookId, message)
+	}
+
+	response := model.StockTransferProcessResponse{
+		Transfer:  model.StockTransferToResponse(transfer, items),
+		Movements: make([]model.StockMovementResponse, len(movements)),
+	}
+	for i, movement := range movements {
+		response.Movements[i] = model.StockMovementToResponse(movement)
+	}
+	if len(allocated) > 0 {
+		response.Allocations = model.BackordersToResponse(allocated)
+	}
+
+	return response, nil
+}
+
+// Cancel membatalkan transfer yang belum dikirim. Transfer yang sudah dikirim harus diterima
+// lalu dikembalikan dengan transfer baru
+func (s StockTransferUsecase) Cancel(ctx context.Context, transferId string) (model.StockTransferResponse, error) {
+	id, err := uuid.Parse(transferId)
+	if err != nil {
+		return model.StockTransferResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid stock transfer ID"), err.Error())
+	}
+
+	var transfer *entity.StockTransfer
+	err = s.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		transferRepo := s.transferRepo.WithTx(tx)
+
+		transfer, err = transferRepo.GetByIdForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if transfer.Status != entity.StockTransferRequested {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Stock transfer is %s, only requested transfers can be cancelled", transfer.Status))
+		}
+
+		transfer, err = transferRepo.Cancel(ctx, id)
+		return err
+	})
+	if err != nil {
+		return model.StockTransferResponse{}, stockTransferWriteError(err, uuid.Nil, "Failed to cancel stock transfer")
+	}
+
+	return model.StockTransferToResponse(transfer, nil), nil
+}
+
+// newStockTransferItems membuat entity item transfer. Setiap buku hanya boleh muncul satu kali
+func newStockTransferItems(transferId uuid.UUID, lines []model.StockTransferItemRequest) ([]*entity.StockTransferItem, error) {
+	items := make([]*entity.StockTransferItem, len(lines))
+	seen := make(map[uuid.UUID]bool, len(lines))
+
+	for i, line := range lines {
+		if seen[line.BookID] {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Book %s is listed more than once", line.BookID))
+		}
+		seen[line.BookID] = true
+
+		itemId, err := uuid.NewV7()
+		if err != nil {
+			return nil, eris.Errorf("Failed to generate stock transfer item ID: %v", err)
+		}
+
+		items[i] = &entity.StockTransferItem{
+			TransferItemId: itemId,
+			TransferId:     transferId,
+			BookId:         line.BookID,
+			Quantity:       line.Quantity,
+		}
+	}
+
+	return items, nil
+}
+
+// stockTransferWriteError memetakan error dari transaksi transfer menjadi error HTTP
+func stockTransferWriteError(err error, failedBookId uuid.UUID, message string) error {
+	var fe *fiber.Error
+	if eris.As(err, &fe) {
+		return fe
+	}
+
+	if eris.Is(err, types.ErrInsufficientStock) {
+		return eris.Wrap(fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Insufficient stock for book %s at the source location", failedBookId)), err.Error())
+	}
+
+	if (eris.Is(err, types.ErrNoRows) || eris.Is(err, types.ErrMissingReference)) && failedBookId != uuid.Nil {
+		return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("Book %s not found", failedBookId))
+	}
+
+	if eris.Is(err, types.ErrMissingReference) {
+		return fiber.NewError(fiber.StatusNotFound, "Location not found")
+	}
+
+	if eris.Is(err, types.ErrNoRows) {
+		return fiber.NewError(fiber.StatusNotFound, "Stock transfer not found")
+	}
+
+	return eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, message), eris.ToString(err, true))
+}
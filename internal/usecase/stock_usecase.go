@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/repository"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rotisserie/eris"
+)
+
+type StockUsecase struct {
+	transactor   *repository.Transactor
+	bookRepo     *repository.BookRepository
+	movementRepo *repository.StockMovementRepository
+	locationRepo *repository.LocationRepository
+	backorders   *BackorderUsecase
+	validator    *validator.Validate
+}
+
+func NewStockUsecase(
+	transactor *repository.Transactor,
+	bookRepo *repository.BookRepository,
+	movementRepo *repository.StockMovementRepository,
+	locationRepo *repository.LocationRepository,
+	backorders *BackorderUsecase,
+	validator *validator.Validate,
+) *StockUsecase {
+	return &StockUsecase{transactor, bookRepo, movementRepo, locationRepo, backorders, validator}
+}
+
+func (s StockUsecase) RecordMovement(ctx context.Context, userId uuid.UUID, bookId string, request *model.CreateStockMovementRequest) (model.StockMovementResponse, error) {
+	id, err := uuid.Parse(bookId)
+	if err != nil {
+		return model.StockMovementResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid book ID"), err.Error())
+	}
+
+	err = s.validator.Struct(request)
+	if err != nil {
+		return model.StockMovementResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	movementType := entity.StockMovementType(request.MovementType)
+	err = validateMovementQuantity(movementType, request.Quantity)
+	if err != nil {
+		return model.StockMovementResponse{}, err
+	}
+
+	location, err := resolveLocation(ctx, s.locationRepo, request.LocationID)
+	if err != nil {
+		return model.StockMovementResponse{}, err
+	}
+
+	movement, err := newStockMovement(id, location.LocationId, movementType, request.Quantity, request.Reason, request.Reference, userId)
+	if err != nil {
+		return model.StockMovementResponse{}, err
+	}
+
+	err = s.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		_, err := applyStockMovement(ctx, s.bookRepo.WithTx(tx), s.movementRepo.WithTx(tx), movement)
+		if err != nil || !allocatesStock(movement) {
+			return err
+		}
+
+		_, err = s.backorders.allocate(ctx, tx, location.LocationId, []uuid.UUID{id}, userId)
+		return err
+	})
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return model.StockMovementResponse{}, fiber.NewError(fiber.StatusNotFound, "Book not found")
+		}
+
+		if eris.Is(err, types.ErrInsufficientStock) {
+			return model.StockMovementResponse{}, eris.Wrap(fiber.NewError(fiber.StatusConflict, "Insufficient stock, stock cannot be negative"), err.Error())
+		}
+
+		return model.StockMovementResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to record stock movement"), eris.ToString(err, true))
+	}
+
+	return model.StockMovementToResponse(movement), nil
+}
+
+// AdjustStock menambah atau mengurangi stok buku secara relatif dan mencatatnya sebagai penyesuaian stok
+func (s StockUsecase) AdjustStock(ctx context.Context, userId uuid.UUID, bookId string, request *model.AdjustStockRequest) (model.BookResponse, error) {
+	id, err := uuid.Parse(bookId)
+	if err != nil {
+		return model.BookResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid book ID"), err.Error())
+	}
+
+	err = s.validator.Struct(request)
+	if err != nil {
+		return model.BookResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	location, err := resolveLocation(ctx, s.locationRepo, request.LocationID)
+	if err != nil {
+		return model.BookResponse{}, err
+	}
+
+	movement, err := newStockMovement(id, location.LocationId, entity.StockMovementAdjustment, request.Delta, request.Reason, request.Reference, userId)
+	if err != nil {
+		return model.BookResponse{}, err
+	}
+
+	var book *entity.Book
+	err = s.transactor.WithinTx(ctx, func(tx *sqlx.Tx) error {
+		book, err = applyStockMovement(ctx, s.bookRepo.WithTx(tx), s.movementRepo.WithTx(tx), movement)
+		return err
+	})
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return model.BookResponse{}, fiber.NewError(fiber.StatusNotFound, "Book not found")
+		}
+
+		if eris.Is(err, types.ErrInsufficientStock) {
+			return model.BookResponse{}, eris.Wrap(fiber.NewError(fiber.StatusConflict, "Insufficient stock, stock cannot be negative"), err.Error())
+		}
+
+		return model.BookResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to adjust stock"), eris.ToString(err, true))
+	}
+
+	return model.BookToResponse(book), nil
+}
+
+func (s StockUsecase) GetMovements(ctx context.Context, bookId string, offset int64, limit int64) ([]model.StockMovementResponse, int64, error) {
+	id, err := uuid.Parse(bookId)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid book ID"), err.Error())
+	}
+
+	if limit <= 0 {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Limit must be greater than 0"), "Invalid limit")
+	}
+
+	_, err = s.bookRepo.GetById(ctx, id)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return nil, 0, fiber.NewError(fiber.StatusNotFound, "Book not found")
+		}
+
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get book"), eris.ToString(err, true))
+	}
+
+	movements, err := s.movementRepo.GetManyByBookId(ctx, id, offset, limit)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get stock movements"), eris.ToString(err, true))
+	}
+
+	total, err := s.movementRepo.GetTotalCountByBookId(ctx, id)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get total count"), eris.ToString(err, true))
+	}
+
+	movementsResp := make([]model.StockMovementResponse, len(movements))
+	for i, movement := range movements {
+		movementsResp[i] = model.StockMovementToResponse(movement)
+	}
+
+	return movementsResp, total, nil
+}
+
+// GetLocationStock mengambil rincian stok buku di setiap lokasi
+func (s StockUsecase) GetLocationStock(ctx context.Context, bookId string) (model.BookLocationStockResponse, error) {
+	id, err := uuid.Parse(bookId)
+	if err != nil {
+		return model.BookLocationStockResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid book ID"), err.Error())
+	}
+
+	book, err := s.bookRepo.GetById(ctx, id)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return model.BookLocationStockResponse{}, fiber.NewError(fiber.StatusNotFound, "Book not found")
+		}
+
+		return model.BookLocationStockResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get book"), eris.ToString(err, true))
+	}
+
+	stocks, err := s.bookRepo.GetLocationStock(ctx, id)
+	if err != nil {
+		return model.BookLocationStockResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get location stock"), eris.ToString(err, true))
+	}
+
+	response := model.BookLocationStockResponse{
+		BookID:    book.BookId,
+		Stock:     book.Stock,
+		Locations: make([]model.LocationStockResponse, len(stocks)),
+	}
+	for i, stock := range stocks {
+		response.Locations[i] = model.LocationStockToResponse(stock)
+	}
+
+	return response, nil
+}
+
+// validateMovementQuantity memastikan tanda quantity sesuai dengan jenis pergerakan stok.
+// Penerimaan dan retur selalu menambah stok, penjualan dan kerusakan selalu mengurangi stok
+func validateMovementQuantity(movementType entity.StockMovementType, quantity int64) error {
+	switch movementType {
+	case entity.StockMovementReceipt, entity.StockMovementReturn:
+		if quantity < 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Quantity must be positive for "+string(movementType)+" movements")
+		}
+	case entity.StockMovementSale, entity.StockMovementDamage:
+		if quantity > 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Quantity must be negative for "+string(movementType)+" movements")
+		}
+	}
+
+	return nil
+}
+
+func newStockMovement(
+	bookId uuid.UUID,
+	locationId uuid.UUID,
+	movementType entity.StockMovementType,
+	quantity int64,
+	reason string,
+	reference string,
+	createdBy uuid.UUID,
+) (*entity.StockMovement, error) {
+	movementId, err := uuid.NewV7()
+	if err != nil {
+		return nil, eris.Errorf("Failed to generate movement ID: %v", err)
+	}
+
+	return &entity.StockMovement{
+		MovementId:   movementId,
+		BookId:       bookId,
+		LocationId:   uuid.NullUUID{UUID: locationId, Valid: true},
+		MovementType: movementType,
+		Quantity:     quantity,
+		Reason:       reason,
+		Reference:    reference,
+		CreatedBy:    uuid.NullUUID{UUID: createdBy, Valid: createdBy != uuid.Nil},
+	}, nil
+}
+
+// applyStockMovement menerapkan selisih stok pada buku di lokasi pergerakan secara atomik lalu mencatatnya
+// di ledger pergerakan stok. Fungsi ini harus dipanggil dengan repository yang terikat pada transaksi yang sama
+func applyStockMovement(
+	ctx context.Context,
+	bookRepo *repository.BookRepository,
+	movementRepo *repository.StockMovementRepository,
+	movement *entity.StockMovement,
+) (*entity.Book, error) {
+	adjust := bookRepo.AdjustStock
+	// buku yang ditahan reservasi tidak boleh terjual atau dikirim ke lokasi lain, tetapi
+	// penyesuaian dan pencatatan buku rusak tetap mengikuti jumlah fisik
+	if movement.Quantity < 0 && (movement.MovementType == entity.StockMovementSale || movement.MovementType == entity.StockMovementTransfer) {
+		adjust = bookRepo.AdjustAvailableStock
+	}
+
+	book, err := adjust(ctx, movement.BookId, movement.LocationId.UUID, movement.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	movement.StockAfter = book.Stock
+
+	_, err = movementRepo.Create(ctx, movement)
+	if err != nil {
+		return nil, err
+	}
+
+	return book, nil
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+)
+
+func TestValidateMovementQuantity(t *testing.T) {
+	tests := []struct {
+		name         string
+		movementType entity.StockMovementType
+		quantity     int64
+		wantErr      bool
+	}{
+		{name: "receipt positive", movementType: entity.StockMovementReceipt, quantity: 5},
+		{name: "receipt negative", movementType: entity.StockMovementReceipt, quantity: -5, wantErr: true},
+		{name: "return positive", movementType: entity.StockMovementReturn, quantity: 1},
+		{name: "return negative", movementType: entity.StockMovementReturn, quantity: -1, wantErr: true},
+		{name: "sale negative", movementType: entity.StockMovementSale, quantity: -2},
+		{name: "sale positive", movementType: entity.StockMovementSale, quantity: 2, wantErr: true},
+		{name: "damage negative", movementType: entity.StockMovementDamage, quantity: -1},
+		{name: "damage positive", movementType: entity.StockMovementDamage, quantity: 1, wantErr: true},
+		{name: "adjustment positive", movementType: entity.StockMovementAdjustment, quantity: 3},
+		{name: "adjustment negative", movementType: entity.StockMovementAdjustment, quantity: -3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMovementQuantity(tt.movementType, tt.quantity)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateMovementQuantity(%s, %d) error = %v, want error %v", tt.movementType, tt.quantity, err, tt.wantErr)
+			}
+
+			var fe *fiber.Error
+			if err != nil && (!eris.As(err, &fe) || fe.Code != fiber.StatusBadRequest) {
+				t.Errorf("error = %v, want 400 fiber error", err)
+			}
+		})
+	}
+}
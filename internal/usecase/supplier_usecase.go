@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/repository"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+)
+
+type SupplierUsecase struct {
+	supplierRepo *repository.SupplierRepository
+	validator    *validator.Validate
+}
+
+func NewSupplierUsecase(supplierRepo *repository.SupplierRepository, validator *validator.Validate) *SupplierUsecase {
+	return &SupplierUsecase{supplierRepo, validator}
+}
+
+func (s SupplierUsecase) Create(ctx context.Context, request *model.CreateSupplierRequest) (model.SupplierResponse, error) {
+	err := s.validator.Struct(request)
+	if err != nil {
+		return model.SupplierResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	supplierId, err := uuid.NewV7()
+	if err != nil {
+		return model.SupplierResponse{}, eris.Errorf("Failed to generate supplier ID: %v", err)
+	}
+
+	supplier, err := s.supplierRepo.Create(ctx, &entity.Supplier{
+		SupplierId:   supplierId,
+		Name:         request.Name,
+		ContactName:  request.ContactName,
+		Email:        request.Email,
+		Phone:        request.Phone,
+		Address:      request.Address,
+		LeadTimeDays: request.LeadTimeDays,
+	})
+	if err != nil {
+		if eris.Is(err, types.ErrDuplicateKey) {
+			return model.SupplierResponse{}, fiber.NewError(fiber.StatusConflict, "Supplier with the same name already exists")
+		}
+
+		return model.SupplierResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to create supplier"), eris.ToString(err, true))
+	}
+
+	return model.SupplierToResponse(supplier), nil
+}
+
+func (s SupplierUsecase) GetById(ctx context.Context, supplierId string) (model.SupplierResponse, error) {
+	id, err := uuid.Parse(supplierId)
+	if err != nil {
+		return model.SupplierResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid supplier ID"), err.Error())
+	}
+
+	supplier, err := s.supplierRepo.GetById(ctx, id)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return model.SupplierResponse{}, fiber.NewError(fiber.StatusNotFound, "Supplier not found")
+		}
+
+		return model.SupplierResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get supplier"), eris.ToString(err, true))
+	}
+
+	return model.SupplierToResponse(supplier), nil
+}
+
+func (s SupplierUsecase) GetMany(ctx context.Context, offset int64, limit int64) ([]model.SupplierResponse, int64, error) {
+	if limit <= 0 {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Limit must be greater than 0"), "Invalid limit")
+	}
+
+	suppliers, err := s.supplierRepo.GetMany(ctx, offset, limit)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get suppliers"), eris.ToString(err, true))
+	}
+
+	total, err := s.supplierRepo.GetTotalCount(ctx)
+	if err != nil {
+		return nil, 0, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to get total count"), eris.ToString(err, true))
+	}
+
+	suppliersResp := make([]model.SupplierResponse, len(suppliers))
+	for i, supplier := range suppliers {
+		suppliersResp[i] = model.SupplierToResponse(supplier)
+	}
+
+	return suppliersResp, total, nil
+}
+
+func (s SupplierUsecase) Update(ctx context.Context, request *model.UpdateSupplierRequest) (model.SupplierResponse, error) {
+	err := s.validator.Struct(request)
+	if err != nil {
+		return model.SupplierResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	supplier, err := s.supplierRepo.Update(ctx, request.SupplierID, repository.SupplierPatch{
+		Name:         request.Name,
+		ContactName:  request.ContactName,
+		Email:        request.Email,
+		Phone:        request.Phone,
+		Address:      request.Address,
+		LeadTimeDays: request.LeadTimeDays,
+	})
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return model.SupplierResponse{}, fiber.NewError(fiber.StatusNotFound, "Supplier not found")
+		}
+
+		if eris.Is(err, types.ErrDuplicateKey) {
+			return model.SupplierResponse{}, fiber.NewError(fiber.StatusConflict, "Supplier with the same name already exists")
+		}
+
+		return model.SupplierResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to update supplier"), eris.ToString(err, true))
+	}
+
+	return model.SupplierToResponse(supplier), nil
+}
+
+func (s SupplierUsecase) Delete(ctx context.Context, supplierId string) error {
+	id, err := uuid.Parse(supplierId)
+	if err != nil {
+		return eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid supplier ID"), err.Error())
+	}
+
+	err = s.supplierRepo.Delete(ctx, id)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "Supplier not found")
+		}
+
+		if eris.Is(err, types.ErrReferenced) {
+			return fiber.NewError(fiber.StatusConflict, "Supplier has purchase orders and cannot be deleted")
+		}
+
+		return eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to delete supplier"), err.Error())
+	}
+
+	return nil
+}
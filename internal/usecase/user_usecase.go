@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"github.com/crazydw4rf/book-stock-manager/internal/entity"
+	"github.com/crazydw4rf/book-stock-manager/internal/model"
+	"github.com/crazydw4rf/book-stock-manager/internal/repository"
+	"github.com/crazydw4rf/book-stock-manager/internal/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type UserUsecase struct {
+	userRepo  *repository.UserRepository
+	roleRepo  *repository.RoleRepository
+	validator *validator.Validate
+}
+
+func NewUserUsecase(userRepo *repository.UserRepository, roleRepo *repository.RoleRepository, validator *validator.Validate) *UserUsecase {
+	return &UserUsecase{userRepo, roleRepo, validator}
+}
+
+func (u UserUsecase) Create(ctx context.Context, request *model.CreateUserRequest) (model.UserResponse, error) {
+	err := u.validator.Struct(request)
+	if err != nil {
+		return model.UserResponse{}, eris.Wrap(fiber.NewError(fiber.StatusBadRequest, "Invalid request payload"), err.Error())
+	}
+
+	role, err := u.roleRepo.GetByName(ctx, request.Role)
+	if err != nil {
+		if eris.Is(err, types.ErrNoRows) {
+			return model.UserResponse{}, fiber.NewError(fiber.StatusBadRequest, "Unknown role")
+		}
+
+		return model.UserResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to create user"), eris.ToString(err, true))
+	}
+
+	user, err := newUser(request.Username, request.FullName, request.Password, role.RoleId)
+	if err != nil {
+		return model.UserResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to create user"), eris.ToString(err, true))
+	}
+
+	user, err = u.userRepo.Create(ctx, user)
+	if err != nil {
+		if eris.Is(err, types.ErrDuplicateKey) {
+			return model.UserResponse{}, fiber.NewError(fiber.StatusConflict, "Username already exists")
+		}
+
+		return model.UserResponse{}, eris.Wrap(fiber.NewError(fiber.StatusInternalServerError, "Failed to create user"), eris.ToString(err, true))
+	}
+
+	return model.UserToResponse(user, role), nil
+}
+
+// EnsureInitialUser membuat user pertama dengan role admin jika tabel users masih kosong.
+// Dipakai saat aplikasi pertama kali dijalankan agar ada akun yang bisa login
+func (u UserUsecase) EnsureInitialUser(ctx context.Context, username string, password string) error {
+	if username == "" || password == "" {
+		return nil
+	}
+
+	total, err := u.userRepo.GetTotalCount(ctx)
+	if err != nil {
+		return err
+	}
+
+	if total > 0 {
+		return nil
+	}
+
+	role, err := u.roleRepo.GetByName(ctx, entity.RoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	user, err := newUser(username, username, password, role.RoleId)
+	if err != nil {
+		return err
+	}
+
+	_, err = u.userRepo.Create(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Initial user %q has been created\n", username)
+
+	return nil
+}
+
+func newUser(username string, fullName string, password string, roleId int64) (*entity.User, error) {
+	userId, err := uuid.NewV7()
+	if err != nil {
+		return nil, eris.Errorf("Failed to generate user ID: %v", err)
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to hash password")
+	}
+
+	return &entity.User{
+		UserId:       userId,
+		Username:     username,
+		FullName:     fullName,
+		PasswordHash: string(passwordHash),
+		RoleId:       roleId,
+		IsActive:     true,
+	}, nil
+}